@@ -5,9 +5,11 @@
 package plot
 
 import (
+	"errors"
 	"fmt"
 	"image/color"
 	"math"
+	"sort"
 
 	"github.com/gonum/plot/vg"
 )
@@ -30,15 +32,30 @@ type Axis struct {
 	// LineStyle is the style of the axis line.
 	LineStyle
 
+	// HideLine hides the axis line, leaving its tick
+	// marks and labels.
+	HideLine bool
+
 	// Padding between the axis line and the data.  Having
 	// non-zero padding ensures that the data is never drawn
-	// on the axis, thus making it easier to see.
+	// on the axis, thus making it easier to see.  The X and
+	// Y axis lines are each padded away from the data area,
+	// so with non-zero padding they do not meet at the
+	// corner of the plot.
 	Padding vg.Length
 
 	Tick struct {
 		// Label is the TextStyle on the tick labels.
 		Label TextStyle
 
+		// LabelRotation is the angle, in radians
+		// counter-clockwise, by which the tick labels
+		// are rotated.  Rotated labels are anchored at
+		// the tick mark by the middle of their end
+		// that is nearest to the axis, and the axis
+		// reserves space for their rotated bounds.
+		LabelRotation float64
+
 		// LineStyle is the LineStyle of the tick lines.
 		LineStyle
 
@@ -47,6 +64,20 @@ type Axis struct {
 		// tick marks.
 		Length vg.Length
 
+		// Direction is the side of the axis line on
+		// which the tick marks are drawn.  The default
+		// is TicksOutside.
+		Direction TickDirection
+
+		// Mirror, if true, draws the tick marks again,
+		// without labels, at the same data positions on
+		// the opposite edge of the data area: the top for
+		// the X axis, and the right for the Y axis if the
+		// plot has no secondary Y axis.  Mirrored tick
+		// marks take no space in the layout of the plot,
+		// so TicksInside keeps them within the data area.
+		Mirror bool
+
 		// Marker returns the tick marks.  Any tick marks
 		// returned by the Marker function that are not in
 		// range of the axis are not drawn.
@@ -57,6 +88,55 @@ type Axis struct {
 	// to the normalized coordinate system of the axis—its distance
 	// along the axis as a fraction of the axis range.
 	Scale func(min, max, x float64) float64
+
+	// Breaks are ranges of data values that are
+	// excluded from the axis.  The axis is compressed
+	// so that the breaks take no space, and a zigzag
+	// break mark is drawn across the axis line at the
+	// place of each break.
+	Breaks []Break
+
+	// Categories, if non-empty, makes the axis a
+	// categorical axis with the given, ordered names.
+	// The data value of each category is its index,
+	// and when the plot is drawn the range of the axis
+	// is set so that each category has an equal share
+	// of the axis centered on its value.  The tick marks
+	// are at the category values, labeled with the names,
+	// and the Tick.Marker function is not used.
+	Categories []string
+
+	// scale is the scale set by SetScale.
+	scale namedScale
+
+	// breakCache is the cached result of breaks.
+	breakCache breakCache
+}
+
+// TickDirection is the side of an axis line on
+// which its tick marks are drawn.
+type TickDirection int
+
+const (
+	// TicksOutside draws tick marks outside of
+	// the data area, between the axis line and
+	// the tick labels.
+	TicksOutside TickDirection = iota
+
+	// TicksInside draws tick marks inside of
+	// the data area, and the tick labels are
+	// placed next to the axis line.
+	TicksInside
+
+	// TicksBoth draws tick marks that cross
+	// the axis line.
+	TicksBoth
+)
+
+// A Break is a range of data values that
+// is excluded from an axis.
+type Break struct {
+	Min, Max float64
 }
 
 // makeAxis returns a default Axis.
@@ -102,9 +182,24 @@ func makeAxis() (Axis, error) {
 	return a, nil
 }
 
+// clone returns a copy of the axis that
+// shares no slices with the original.
+func (a *Axis) clone() Axis {
+	c := *a
+	c.LineStyle.Dashes = append([]vg.Length(nil), a.LineStyle.Dashes...)
+	c.Tick.LineStyle.Dashes = append([]vg.Length(nil), a.Tick.LineStyle.Dashes...)
+	c.Breaks = append([]Break(nil), a.Breaks...)
+	c.Categories = append([]string(nil), a.Categories...)
+	return c
+}
+
 // sanitizeRange ensures that the range of the
 // axis makes sense.
 func (a *Axis) sanitizeRange() {
+	if n := len(a.Categories); n > 0 {
+		a.Min, a.Max = -0.5, float64(n)-0.5
+		return
+	}
 	if math.IsInf(a.Min, 0) {
 		a.Min = 0
 	}
@@ -120,6 +215,42 @@ func (a *Axis) sanitizeRange() {
 	}
 }
 
+// IncludeZero extends the range of the axis, if
+// necessary, so that it includes zero.  The range of
+// the axis is also extended by plotters that are added
+// to the plot, so the axis includes zero and all of the
+// data regardless of whether IncludeZero is called
+// before or after the plotters are added.
+//
+// IncludeZero returns an error and leaves the axis
+// unchanged if the scale of the axis cannot show zero,
+// as a log scale cannot.
+func (a *Axis) IncludeZero() error {
+	min, max := math.Min(a.Min, 0), math.Max(a.Max, 0)
+	if max == min {
+		max = min + 1
+	}
+	if !scalesZero(a.Scale, min, max) {
+		return errors.New("Axis scale cannot include zero")
+	}
+	a.Min = math.Min(a.Min, 0)
+	a.Max = math.Max(a.Max, 0)
+	return nil
+}
+
+// scalesZero returns true if the scale maps zero to a
+// finite value on an axis from min to max.  A scale
+// that panics, as LogScale does, cannot map zero.
+func scalesZero(scale func(min, max, x float64) float64, min, max float64) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	v := scale(min, max, 0)
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
 // LinearScale an be used as the value of an Axis.Scale function to
 // set the axis to a standard linear scale.
 func LinearScale(min, max, x float64) float64 {
@@ -133,12 +264,344 @@ func LogScale(min, max, x float64) float64 {
 	return (log(x) - logMin) / (log(max) - logMin)
 }
 
+// SymLogScale returns a function, suitable for the
+// Scale field of an Axis, that sets the axis to a
+// symmetric log scale, which can show data on both
+// sides of zero.  The scale is linear between -threshold
+// and threshold, and logarithmic beyond them, with each
+// decade the length of the linear part from zero to
+// threshold.  SymLogTicks(threshold) returns suitable
+// tick marks.  SymLogScale panics if the threshold is
+// not positive and finite.
+func SymLogScale(threshold float64) func(min, max, x float64) float64 {
+	checkSymLogThreshold(threshold)
+	return func(min, max, x float64) float64 {
+		lo := symLog(threshold, min)
+		return (symLog(threshold, x) - lo) / (symLog(threshold, max) - lo)
+	}
+}
+
+// checkSymLogThreshold panics if threshold is not
+// a valid threshold for a symmetric log scale.
+func checkSymLogThreshold(threshold float64) {
+	if !(threshold > 0) || math.IsInf(threshold, 1) {
+		panic("Threshold must be finite and greater than 0 for a symmetric log scale.")
+	}
+}
+
+// symLog returns the symmetric log transform of x
+// with the given linear threshold: x/threshold
+// within the threshold, and 1 more than the log of
+// |x|/threshold, with the sign of x, beyond it.
+func symLog(threshold, x float64) float64 {
+	a := math.Abs(x)
+	if a <= threshold {
+		return x / threshold
+	}
+	return math.Copysign(1+math.Log10(a/threshold), x)
+}
+
+// symExp is the inverse of symLog.
+func symExp(threshold, y float64) float64 {
+	a := math.Abs(y)
+	if a <= 1 {
+		return y * threshold
+	}
+	return math.Copysign(threshold*math.Pow(10, a-1), y)
+}
+
 // Norm returns the value of x, given in the data coordinate
 // system, normalized to its distance as a fraction of the
 // range of this axis.  For example, if x is a.Min then the return
 // value is 0, and if x is a.Max then the return value is 1.
+//
+// If the axis has breaks then the normalized coordinate
+// system excludes them, and values within a break are
+// normalized to the place of the break.
 func (a *Axis) Norm(x float64) float64 {
-	return a.Scale(a.Min, a.Max, x)
+	n := a.Scale(a.Min, a.Max, x)
+	if len(a.Breaks) == 0 {
+		return n
+	}
+	var cut, total float64
+	for _, b := range a.breaks() {
+		lo, hi := a.Scale(a.Min, a.Max, b.Min), a.Scale(a.Min, a.Max, b.Max)
+		total += hi - lo
+		switch {
+		case n >= hi:
+			cut += hi - lo
+		case n > lo:
+			cut += n - lo
+		}
+	}
+	if total >= 1 {
+		return n
+	}
+	return (n - cut) / (1 - total)
+}
+
+// Unnorm returns the data value that is normalized to
+// n, the inverse of Norm.  The value is found by
+// bisection, so Unnorm works with any monotonic Scale
+// function.  If n is not in the range [0, 1] then it
+// is not on the axis, and Unnorm returns NaN.
+func (a *Axis) Unnorm(n float64) float64 {
+	if n < 0 || n > 1 || math.IsNaN(n) {
+		return math.NaN()
+	}
+	return a.bisect(a.Min, a.Max, n)
+}
+
+// bisect returns the data value between lo and hi
+// that is normalized to n.
+func (a *Axis) bisect(lo, hi, n float64) float64 {
+	if a.Norm(lo) > a.Norm(hi) {
+		lo, hi = hi, lo
+	}
+	for i := 0; i < 100; i++ {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			break
+		}
+		if a.Norm(mid) < n {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo + (hi-lo)/2
+}
+
+// breaks returns the breaks of the axis clipped to
+// its range, sorted, and with overlapping breaks
+// merged.  The result is cached until the breaks or
+// the range of the axis change, because Norm needs
+// it for every value.
+func (a *Axis) breaks() []Break {
+	c := &a.breakCache
+	if c.valid && c.min == a.Min && c.max == a.Max && sameBreaks(c.src, a.Breaks) {
+		return c.merged
+	}
+	var bs []Break
+	for _, b := range a.Breaks {
+		if b.Min > b.Max {
+			b.Min, b.Max = b.Max, b.Min
+		}
+		b.Min = math.Max(b.Min, a.Min)
+		b.Max = math.Min(b.Max, a.Max)
+		if b.Min < b.Max {
+			bs = append(bs, b)
+		}
+	}
+	sort.Sort(byBreakMin(bs))
+	merged := bs[:0]
+	for _, b := range bs {
+		if n := len(merged); n > 0 && b.Min <= merged[n-1].Max {
+			merged[n-1].Max = math.Max(merged[n-1].Max, b.Max)
+			continue
+		}
+		merged = append(merged, b)
+	}
+	*c = breakCache{
+		valid:  true,
+		min:    a.Min,
+		max:    a.Max,
+		src:    append([]Break(nil), a.Breaks...),
+		merged: merged,
+	}
+	return merged
+}
+
+// sameBreaks returns true if a and b are the same breaks
+// in the same order.
+func sameBreaks(a, b []Break) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// A breakCache holds the result of breaks for the
+// Breaks and range from which it was computed.
+type breakCache struct {
+	valid    bool
+	min, max float64
+	src      []Break
+	merged   []Break
+}
+
+type byBreakMin []Break
+
+func (b byBreakMin) Len() int           { return len(b) }
+func (b byBreakMin) Less(i, j int) bool { return b[i].Min < b[j].Min }
+func (b byBreakMin) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+
+// Category returns the data value of the named
+// category of a categorical axis, and whether the
+// axis has the category.
+func (a *Axis) Category(name string) (float64, bool) {
+	for i, c := range a.Categories {
+		if c == name {
+			return float64(i), true
+		}
+	}
+	return 0, false
+}
+
+// TickInfo is a tick mark of an axis along with
+// its position on the drawn axis.
+type TickInfo struct {
+	// Value is the data value of the tick mark.
+	Value float64
+
+	// Label is the text of the tick mark's label.
+	// Minor tick marks have no label.
+	Label string
+
+	// Device is the distance of the tick mark from
+	// the minimum end of the axis.
+	Device vg.Length
+}
+
+// TickInfo returns the tick marks of the axis, along
+// with their positions on an axis of the given length,
+// so that they can be drawn by an external renderer.
+// Tick marks outside of the axis range are omitted.
+//
+// For a plot drawn to a DrawArea, the length is the
+// width or height of the area returned by the plot's
+// DataDrawArea method, and the device position of a
+// tick mark is its Device added to the minimum X or
+// Y of that area.
+func (a *Axis) TickInfo(length vg.Length) []TickInfo {
+	a.sanitizeRange()
+	var info []TickInfo
+	for _, t := range a.Ticks() {
+		n := a.Norm(t.Value)
+		if n < 0 || n > 1 {
+			continue
+		}
+		info = append(info, TickInfo{
+			Value:  t.Value,
+			Label:  t.Label,
+			Device: vg.Length(n) * length,
+		})
+	}
+	return info
+}
+
+// Ticks returns the tick marks of the axis.  For a
+// categorical axis they are the categories.  If the
+// axis has breaks then the tick marks are computed
+// separately for each unbroken part of the axis, and
+// no tick marks are returned within a break.  Since
+// the two ends of a break are at the same place on
+// the axis, there is at most one tick mark there.
+func (a *Axis) Ticks() []Tick {
+	if len(a.Categories) > 0 {
+		ticks := make([]Tick, len(a.Categories))
+		for i, name := range a.Categories {
+			ticks[i] = Tick{Value: float64(i), Label: name}
+		}
+		return ticks
+	}
+	if len(a.Breaks) == 0 {
+		return a.Tick.Marker(a.Min, a.Max)
+	}
+	var ticks []Tick
+	lo := a.Min
+	// The two ends of a break are at the same place
+	// on the axis, so of tick marks at both ends
+	// only one is kept, the first unless only the
+	// second is labeled.
+	joined := math.NaN()
+	add := func(min, max float64) {
+		for _, t := range a.Tick.Marker(min, max) {
+			if t.Value < min || t.Value > max {
+				continue
+			}
+			if n := len(ticks); n > 0 && t.Value == min && ticks[n-1].Value == joined {
+				if ticks[n-1].Label == "" {
+					ticks[n-1] = t
+				}
+				continue
+			}
+			ticks = append(ticks, t)
+		}
+	}
+	for _, b := range a.breaks() {
+		if b.Min > lo {
+			add(lo, b.Min)
+		}
+		lo, joined = b.Max, b.Min
+	}
+	if lo < a.Max {
+		add(lo, a.Max)
+	}
+	return ticks
+}
+
+// breakMarks returns the zigzag break marks of the
+// axis, crossing an axis line that passes through
+// the given point.  The function tr maps normalized
+// axis coordinates to positions along the line.
+// Also returned are the pieces of the axis line
+// between min and max that are not covered by marks.
+func (a *Axis) breakMarks(vertical bool, pt Point, min, max vg.Length, tr func(float64) vg.Length) (marks, line [][]Point) {
+	w := a.Tick.Length / 2
+	at := func(along, across vg.Length) Point {
+		if vertical {
+			return Point{pt.X + across, along}
+		}
+		return Point{along, pt.Y + across}
+	}
+	start := min
+	for _, b := range a.breaks() {
+		c := tr(a.Norm(b.Min))
+		marks = append(marks, []Point{
+			at(c-w, 0),
+			at(c-w/2, w),
+			at(c, -w),
+			at(c+w/2, w),
+			at(c+w, 0),
+		})
+		if c-w > start {
+			line = append(line, []Point{at(start, 0), at(c-w, 0)})
+		}
+		start = c + w
+	}
+	if start < max {
+		line = append(line, []Point{at(start, 0), at(max, 0)})
+	}
+	return marks, line
+}
+
+// tickOutside returns the length by which major tick
+// marks extend outside of the axis line, which is the
+// space that the axis reserves for them.
+func (a *Axis) tickOutside() vg.Length {
+	if a.Tick.Direction == TicksInside {
+		return 0
+	}
+	return a.Tick.Length
+}
+
+// tickSpan returns the lengths by which a tick mark
+// extends outside of and inside of the axis line.
+func (a *Axis) tickSpan(t Tick) (out, in vg.Length) {
+	l := a.Tick.Length - t.lengthOffset(a.Tick.Length)
+	switch a.Tick.Direction {
+	case TicksInside:
+		return 0, l
+	case TicksBoth:
+		return l, l
+	}
+	return l, 0
 }
 
 // drawTicks returns true if the tick marks should be drawn.
@@ -158,17 +621,38 @@ func (a *horizontalAxis) size() (h vg.Length) {
 		h -= a.Label.Font.Extents().Descent
 		h += a.Label.Height(a.Label.Text)
 	}
-	if marks := a.Tick.Marker(a.Min, a.Max); len(marks) > 0 {
+	if marks := a.Ticks(); len(marks) > 0 {
 		if a.drawTicks() {
-			h += a.Tick.Length
+			h += a.tickOutside()
 		}
-		h += tickLabelHeight(a.Tick.Label, marks)
+		h += a.tickLabelHeight(marks)
 	}
 	h += a.Width / 2
 	h += a.Padding
 	return
 }
 
+// minLength returns a lower bound on the length of
+// the axis at which its major tick labels fit side by
+// side, separated by spaces, and its label fits along
+// it.
+func (a *horizontalAxis) minLength() vg.Length {
+	var l vg.Length
+	n := 0
+	for _, t := range a.Ticks() {
+		if t.IsMinor() {
+			continue
+		}
+		min, max := a.labelBounds(t.Label, a.labelAlign())
+		l += max.X - min.X
+		n++
+	}
+	if n > 1 {
+		l += vg.Length(n-1) * a.Tick.Label.Width(" ")
+	}
+	return vg.Length(math.Max(float64(l), float64(a.Label.Width(a.Label.Text))))
+}
+
 // draw draws the axis along the lower edge of a DrawArea.
 func (a *horizontalAxis) draw(da DrawArea) {
 	y := da.Min.Y
@@ -178,40 +662,67 @@ func (a *horizontalAxis) draw(da DrawArea) {
 		y += a.Label.Height(a.Label.Text)
 	}
 
-	marks := a.Tick.Marker(a.Min, a.Max)
+	marks := a.Ticks()
+	top := y + a.tickLabelHeight(marks)
 	for _, t := range marks {
 		x := da.X(a.Norm(t.Value))
 		if !da.ContainsX(x) || t.IsMinor() {
 			continue
 		}
-		da.FillText(a.Tick.Label, x, y, -0.5, 0, t.Label)
+		if a.Tick.LabelRotation == 0 {
+			da.FillText(a.Tick.Label, x, y, -0.5, 0, t.Label)
+			continue
+		}
+		_, max := a.labelBounds(t.Label, a.labelAlign())
+		a.fillRotated(da, x, top-max.Y, a.labelAlign(), t.Label)
 	}
 
 	if len(marks) > 0 {
-		y += tickLabelHeight(a.Tick.Label, marks)
+		y = top
 	} else {
 		y += a.Width / 2
 	}
 
 	if len(marks) > 0 && a.drawTicks() {
-		len := a.Tick.Length
+		y += a.tickOutside()
 		for _, t := range marks {
 			x := da.X(a.Norm(t.Value))
 			if !da.ContainsX(x) {
 				continue
 			}
-			start := t.lengthOffset(len)
-			da.StrokeLine2(a.Tick.LineStyle, x, y+start, x, y+len)
+			out, in := a.tickSpan(t)
+			da.StrokeLine2(a.Tick.LineStyle, x, y-out, x, y+in)
 		}
-		y += len
 	}
 
-	da.StrokeLine2(a.LineStyle, da.Min.X, y, da.Max().X, y)
+	zigzags, line := a.breakMarks(false, Point{da.Min.X, y}, da.Min.X, da.Max().X, da.X)
+	if !a.HideLine {
+		da.StrokeLines(a.LineStyle, line...)
+		da.StrokeLines(a.LineStyle, zigzags...)
+	}
+}
+
+// drawMirror draws the tick marks of the axis on the
+// top edge of the draw area, as though it were an axis
+// line with the tick marks flipped.
+func (a *horizontalAxis) drawMirror(da DrawArea) {
+	if !a.drawTicks() {
+		return
+	}
+	y := da.Max().Y
+	for _, t := range a.Ticks() {
+		x := da.X(a.Norm(t.Value))
+		if !da.ContainsX(x) {
+			continue
+		}
+		out, in := a.tickSpan(t)
+		da.StrokeLine2(a.Tick.LineStyle, x, y+out, x, y-in)
+	}
 }
 
 // GlyphBoxes returns the GlyphBoxes for the tick labels.
 func (a *horizontalAxis) GlyphBoxes(*Plot) (boxes []GlyphBox) {
-	for _, t := range a.Tick.Marker(a.Min, a.Max) {
+	for _, t := range a.Ticks() {
 		if t.IsMinor() {
 			continue
 		}
@@ -220,11 +731,43 @@ func (a *horizontalAxis) GlyphBoxes(*Plot) (boxes []GlyphBox) {
 			X:    a.Norm(t.Value),
 			Rect: Rect{Point{X: -w / 2}, Point{X: w}},
 		}
+		if a.Tick.LabelRotation != 0 {
+			min, max := a.labelBounds(t.Label, a.labelAlign())
+			box.Rect = Rect{Point{X: min.X}, Point{X: max.X - min.X}}
+		}
 		boxes = append(boxes, box)
 	}
 	return
 }
 
+// labelAlign returns the horizontal alignment of
+// rotated tick labels, so that the end of each label
+// that is nearest to the axis is at its tick mark.
+func (a *horizontalAxis) labelAlign() float64 {
+	if a.Tick.LabelRotation > 0 {
+		return -1
+	}
+	return 0
+}
+
+// tickLabelHeight returns the height of the
+// possibly rotated tick labels.
+func (a *horizontalAxis) tickLabelHeight(ticks []Tick) vg.Length {
+	if a.Tick.LabelRotation == 0 {
+		return tickLabelHeight(a.Tick.Label, ticks)
+	}
+	var height vg.Length
+	for _, t := range ticks {
+		if t.IsMinor() {
+			continue
+		}
+		if min, max := a.labelBounds(t.Label, a.labelAlign()); max.Y-min.Y > height {
+			height = max.Y - min.Y
+		}
+	}
+	return height
+}
+
 // A verticalAxis is drawn vertically up the left side of a plot.
 type verticalAxis struct {
 	Axis
@@ -234,15 +777,15 @@ type verticalAxis struct {
 func (a *verticalAxis) size() (w vg.Length) {
 	if a.Label.Text != "" {
 		w -= a.Label.Font.Extents().Descent
-		w += a.Label.Height(a.Label.Text)
+		w += a.labelWidth()
 	}
-	if marks := a.Tick.Marker(a.Min, a.Max); len(marks) > 0 {
-		if lwidth := tickLabelWidth(a.Tick.Label, marks); lwidth > 0 {
+	if marks := a.Ticks(); len(marks) > 0 {
+		if lwidth := a.tickLabelWidth(marks); lwidth > 0 {
 			w += lwidth
 			w += a.Label.Width(" ")
 		}
 		if a.drawTicks() {
-			w += a.Tick.Length
+			w += a.tickOutside()
 		}
 	}
 	w += a.Width / 2
@@ -250,19 +793,52 @@ func (a *verticalAxis) size() (w vg.Length) {
 	return
 }
 
+// minLength returns a lower bound on the length of
+// the axis at which its major tick labels fit one
+// above another and its label fits along it.
+func (a *verticalAxis) minLength() vg.Length {
+	var l vg.Length
+	for _, t := range a.Ticks() {
+		if t.IsMinor() {
+			continue
+		}
+		min, max := a.labelBounds(t.Label, -1)
+		l += max.Y - min.Y
+	}
+	label := a.Label.Width(a.Label.Text)
+	if a.Label.Direction == TopToBottom {
+		label = a.Label.Height(a.Label.Text)
+	}
+	return vg.Length(math.Max(float64(l), float64(label)))
+}
+
+// labelWidth returns the width of the axis label.
+// The label is rotated to read up the axis unless
+// its text is already vertical.
+func (a *verticalAxis) labelWidth() vg.Length {
+	if a.Label.Direction == TopToBottom {
+		return a.Label.Width(a.Label.Text)
+	}
+	return a.Label.Height(a.Label.Text)
+}
+
 // draw draws the axis along the left side of a DrawArea.
 func (a *verticalAxis) draw(da DrawArea) {
 	x := da.Min.X
 	if a.Label.Text != "" {
-		x += a.Label.Height(a.Label.Text)
-		da.Push()
-		da.Rotate(math.Pi / 2)
-		da.FillText(a.Label.TextStyle, da.Center().Y, -x, -0.5, 0, a.Label.Text)
-		da.Pop()
+		x += a.labelWidth()
+		if a.Label.Direction == TopToBottom {
+			da.FillText(a.Label.TextStyle, x, da.Center().Y, -1, -0.5, a.Label.Text)
+		} else {
+			da.Push()
+			da.Rotate(math.Pi / 2)
+			da.FillText(a.Label.TextStyle, da.Center().Y, -x, -0.5, 0, a.Label.Text)
+			da.Pop()
+		}
 		x += -a.Label.Font.Extents().Descent
 	}
-	marks := a.Tick.Marker(a.Min, a.Max)
-	if w := tickLabelWidth(a.Tick.Label, marks); len(marks) > 0 && w > 0 {
+	marks := a.Ticks()
+	if w := a.tickLabelWidth(marks); len(marks) > 0 && w > 0 {
 		x += w
 	}
 	major := false
@@ -271,30 +847,115 @@ func (a *verticalAxis) draw(da DrawArea) {
 		if !da.ContainsY(y) || t.IsMinor() {
 			continue
 		}
-		da.FillText(a.Tick.Label, x, y, -1, -0.5, t.Label)
 		major = true
+		if a.Tick.LabelRotation == 0 {
+			da.FillText(a.Tick.Label, x, y, -1, -0.5, t.Label)
+			continue
+		}
+		_, max := a.labelBounds(t.Label, -1)
+		a.fillRotated(da, x-max.X, y, -1, t.Label)
 	}
 	if major {
 		x += a.Tick.Label.Width(" ")
 	}
 	if a.drawTicks() && len(marks) > 0 {
-		len := a.Tick.Length
+		x += a.tickOutside()
+		for _, t := range marks {
+			y := da.Y(a.Norm(t.Value))
+			if !da.ContainsY(y) {
+				continue
+			}
+			out, in := a.tickSpan(t)
+			da.StrokeLine2(a.Tick.LineStyle, x-out, y, x+in, y)
+		}
+	}
+	zigzags, line := a.breakMarks(true, Point{x, da.Min.Y}, da.Min.Y, da.Max().Y, da.Y)
+	if !a.HideLine {
+		da.StrokeLines(a.LineStyle, line...)
+		da.StrokeLines(a.LineStyle, zigzags...)
+	}
+}
+
+// drawMirror draws the tick marks of the axis on the
+// right edge of the draw area, as though it were an
+// axis line with the tick marks flipped.
+func (a *verticalAxis) drawMirror(da DrawArea) {
+	if !a.drawTicks() {
+		return
+	}
+	x := da.Max().X
+	for _, t := range a.Ticks() {
+		y := da.Y(a.Norm(t.Value))
+		if !da.ContainsY(y) {
+			continue
+		}
+		out, in := a.tickSpan(t)
+		da.StrokeLine2(a.Tick.LineStyle, x+out, y, x-in, y)
+	}
+}
+
+// drawRight draws the axis along the right side of a
+// DrawArea, as a mirror image of draw: the tick marks
+// and labels are to the right of the axis line.
+func (a *verticalAxis) drawRight(da DrawArea) {
+	x := da.Max().X
+	if a.Label.Text != "" {
+		if a.Label.Direction == TopToBottom {
+			x -= a.labelWidth()
+			da.FillText(a.Label.TextStyle, x, da.Center().Y, 0, -0.5, a.Label.Text)
+		} else {
+			// The label reads up the axis, so its
+			// descenders are on the outer side.
+			da.Push()
+			da.Rotate(math.Pi / 2)
+			da.FillText(a.Label.TextStyle, da.Center().Y, -(x + a.Label.Font.Extents().Descent), -0.5, 0, a.Label.Text)
+			da.Pop()
+			x -= a.labelWidth()
+		}
+		x -= -a.Label.Font.Extents().Descent
+	}
+	marks := a.Ticks()
+	if w := a.tickLabelWidth(marks); len(marks) > 0 && w > 0 {
+		x -= w
+	}
+	major := false
+	for _, t := range marks {
+		y := da.Y(a.Norm(t.Value))
+		if !da.ContainsY(y) || t.IsMinor() {
+			continue
+		}
+		major = true
+		if a.Tick.LabelRotation == 0 {
+			da.FillText(a.Tick.Label, x, y, 0, -0.5, t.Label)
+			continue
+		}
+		min, _ := a.labelBounds(t.Label, 0)
+		a.fillRotated(da, x-min.X, y, 0, t.Label)
+	}
+	if major {
+		x -= a.Tick.Label.Width(" ")
+	}
+	if a.drawTicks() && len(marks) > 0 {
+		x -= a.tickOutside()
 		for _, t := range marks {
 			y := da.Y(a.Norm(t.Value))
 			if !da.ContainsY(y) {
 				continue
 			}
-			start := t.lengthOffset(len)
-			da.StrokeLine2(a.Tick.LineStyle, x+start, y, x+len, y)
+			out, in := a.tickSpan(t)
+			da.StrokeLine2(a.Tick.LineStyle, x-in, y, x+out, y)
 		}
-		x += len
 	}
-	da.StrokeLine2(a.LineStyle, x, da.Min.Y, x, da.Max().Y)
+	zigzags, line := a.breakMarks(true, Point{x, da.Min.Y}, da.Min.Y, da.Max().Y, da.Y)
+	if !a.HideLine {
+		da.StrokeLines(a.LineStyle, line...)
+		da.StrokeLines(a.LineStyle, zigzags...)
+	}
 }
 
 // GlyphBoxes returns the GlyphBoxes for the tick labels
 func (a *verticalAxis) GlyphBoxes(*Plot) (boxes []GlyphBox) {
-	for _, t := range a.Tick.Marker(a.Min, a.Max) {
+	for _, t := range a.Ticks() {
 		if t.IsMinor() {
 			continue
 		}
@@ -303,11 +964,78 @@ func (a *verticalAxis) GlyphBoxes(*Plot) (boxes []GlyphBox) {
 			Y:    a.Norm(t.Value),
 			Rect: Rect{Point{Y: -h / 2}, Point{Y: h}},
 		}
+		if a.Tick.LabelRotation != 0 {
+			min, max := a.labelBounds(t.Label, -1)
+			box.Rect = Rect{Point{Y: min.Y}, Point{Y: max.Y - min.Y}}
+		}
 		boxes = append(boxes, box)
 	}
 	return
 }
 
+// tickLabelWidth returns the width of the
+// possibly rotated tick labels.
+func (a *verticalAxis) tickLabelWidth(ticks []Tick) vg.Length {
+	if a.Tick.LabelRotation == 0 {
+		return tickLabelWidth(a.Tick.Label, ticks)
+	}
+	var width vg.Length
+	for _, t := range ticks {
+		if t.IsMinor() {
+			continue
+		}
+		if min, max := a.labelBounds(t.Label, -1); max.X-min.X > width {
+			width = max.X - min.X
+		}
+	}
+	return width
+}
+
+// labelBounds returns the minimum and maximum points,
+// relative to its anchor, of a tick label rotated by
+// the axis's LabelRotation.  The label is anchored at
+// the middle of its left end if xalign is 0 and of its
+// right end if xalign is -1.
+func (a *Axis) labelBounds(label string, xalign float64) (min, max Point) {
+	w := a.Tick.Label.Width(label)
+	h := a.Tick.Label.Height(label)
+	sin, cos := math.Sincos(a.Tick.LabelRotation)
+	min = Point{vg.Length(math.Inf(1)), vg.Length(math.Inf(1))}
+	max = Point{vg.Length(math.Inf(-1)), vg.Length(math.Inf(-1))}
+	for _, x := range []vg.Length{vg.Length(xalign) * w, vg.Length(xalign+1) * w} {
+		for _, y := range []vg.Length{-h / 2, h / 2} {
+			p := Point{
+				X: x*vg.Length(cos) - y*vg.Length(sin),
+				Y: x*vg.Length(sin) + y*vg.Length(cos),
+			}
+			if p.X < min.X {
+				min.X = p.X
+			}
+			if p.Y < min.Y {
+				min.Y = p.Y
+			}
+			if p.X > max.X {
+				max.X = p.X
+			}
+			if p.Y > max.Y {
+				max.Y = p.Y
+			}
+		}
+	}
+	return min, max
+}
+
+// fillRotated fills a tick label rotated by the
+// axis's LabelRotation and anchored at x, y as
+// described by labelBounds.
+func (a *Axis) fillRotated(da DrawArea, x, y vg.Length, xalign float64, label string) {
+	da.Push()
+	da.Translate(x, y)
+	da.Rotate(a.Tick.LabelRotation)
+	da.FillText(a.Tick.Label, 0, 0, xalign, -0.5, label)
+	da.Pop()
+}
+
 // DefaultTicks is suitable for the Tick.Marker field of an Axis,
 // it returns a resonable default set of tick marks.
 func DefaultTicks(min, max float64) (ticks []Tick) {
@@ -333,7 +1061,7 @@ func DefaultTicks(min, max float64) (ticks []Tick) {
 	val := math.Floor(min/majorDelta) * majorDelta
 	for val <= max {
 		if val >= min && val <= max {
-			ticks = append(ticks, Tick{Value: val, Label: fmt.Sprintf("%g", float32(val))})
+			ticks = append(ticks, Tick{Value: val, Label: FormatFloat(val, 'g', -1, 32)})
 		}
 		if math.Nextafter(val, val+majorDelta) == val {
 			break
@@ -371,6 +1099,36 @@ func DefaultTicks(min, max float64) (ticks []Tick) {
 // LogTicks is suitable for the Tick.Marker field of an Axis,
 // it returns tick marks suitable for a log-scale axis.
 func LogTicks(min, max float64) []Tick {
+	return logTicks(min, max, func(v float64) string {
+		return FormatFloat(v, 'g', -1, 32)
+	})
+}
+
+// SILogTicks is suitable for the Tick.Marker field of an
+// Axis, it returns the same tick marks as LogTicks but
+// labels each decade with an SI prefix, for example 1µ,
+// 100m, 1, 10k and 1M.  Decades outside of the range of
+// the SI prefixes are labeled as by LogTicks.
+func SILogTicks(min, max float64) []Tick {
+	return logTicks(min, max, siLabel)
+}
+
+// PowerLogTicks is suitable for the Tick.Marker field of
+// an Axis, it returns the same tick marks as LogTicks but
+// labels each decade as a power of ten, for example
+// 10^-3, 10^0 and 10^6.
+func PowerLogTicks(min, max float64) []Tick {
+	return logTicks(min, max, func(v float64) string {
+		return fmt.Sprintf("10^%d", decade(v))
+	})
+}
+
+// logTicks returns tick marks for a log-scale axis,
+// with a labeled major tick mark at each decade and
+// unlabeled minor tick marks at 2 through 9 times
+// each decade.  The decades are labeled by the
+// label function.
+func logTicks(min, max float64, label func(float64) string) []Tick {
 	var ticks []Tick
 	val := math.Pow10(int(math.Floor(math.Log10(min))))
 	if min <= 0 {
@@ -380,17 +1138,81 @@ func LogTicks(min, max float64) []Tick {
 		for i := 1; i < 10; i++ {
 			tick := Tick{Value: val * float64(i)}
 			if i == 1 {
-				tick.Label = fmt.Sprintf("%g", float32(val)*float32(i))
+				tick.Label = label(val)
 			}
 			ticks = append(ticks, tick)
 		}
 		val *= 10
 	}
-	tick := Tick{Value: val, Label: fmt.Sprintf("%g", float32(val))}
+	tick := Tick{Value: val, Label: label(val)}
 	ticks = append(ticks, tick)
 	return ticks
 }
 
+// SymLogTicks returns a function suitable for the
+// Tick.Marker field of an Axis with the scale returned
+// by SymLogScale(threshold).  It returns labeled major
+// tick marks at zero, at plus and minus the threshold,
+// and at each decade beyond them, with unlabeled minor
+// tick marks at fifths of the linear part and at 2
+// through 9 times the start of each decade.  Like
+// SymLogScale, it panics if the threshold is not
+// positive and finite.
+func SymLogTicks(threshold float64) func(min, max float64) []Tick {
+	checkSymLogThreshold(threshold)
+	return func(min, max float64) []Tick {
+		var ticks []Tick
+		add := func(v float64, major bool) {
+			if v < min || v > max {
+				return
+			}
+			t := Tick{Value: v}
+			if major {
+				t.Label = FormatFloat(v, 'g', -1, 32)
+			}
+			ticks = append(ticks, t)
+		}
+		lo := int(math.Floor(symLog(threshold, min)))
+		hi := int(math.Ceil(symLog(threshold, max)))
+		for k := lo; k <= hi; k++ {
+			v := symExp(threshold, float64(k))
+			add(v, true)
+			if k == 0 {
+				for i := 1; i < 5; i++ {
+					add(threshold*float64(i)/5, false)
+					add(-threshold*float64(i)/5, false)
+				}
+				continue
+			}
+			for i := 2; i < 10; i++ {
+				add(v*float64(i), false)
+			}
+		}
+		return ticks
+	}
+}
+
+// decade returns the exponent of a power of ten.
+func decade(v float64) int {
+	return int(math.Floor(math.Log10(v) + 0.5))
+}
+
+// siPrefixes are the SI prefixes for powers of
+// 1000, starting at 10^-24.
+var siPrefixes = []string{"y", "z", "a", "f", "p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E", "Z", "Y"}
+
+// siLabel returns the label of a power of ten
+// using an SI prefix.
+func siLabel(v float64) string {
+	e := decade(v)
+	p := int(math.Floor(float64(e) / 3))
+	i := p + 8
+	if i < 0 || i >= len(siPrefixes) {
+		return FormatFloat(v, 'g', -1, 32)
+	}
+	return FormatFloat(math.Pow10(e-3*p), 'g', -1, 64) + siPrefixes[i]
+}
+
 // ConstantTicks returns a function suitable for the Tick.Marker
 // field of an Axis.  This function returns the given set of ticks.
 func ConstantTicks(ts []Tick) func(float64, float64) []Tick {
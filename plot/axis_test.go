@@ -0,0 +1,262 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAxisBreaks(t *testing.T) {
+	a := Axis{
+		Min:    0,
+		Max:    1010,
+		Scale:  LinearScale,
+		Breaks: []Break{{Min: 10, Max: 1000}},
+	}
+	a.Tick.Marker = DefaultTicks
+
+	for _, test := range []struct {
+		x, want float64
+	}{
+		{0, 0},
+		{5, 0.25},
+		{10, 0.5},
+		{500, 0.5},
+		{1000, 0.5},
+		{1005, 0.75},
+		{1010, 1},
+	} {
+		if got := a.Norm(test.x); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("Norm(%g) = %g, want %g", test.x, got, test.want)
+		}
+	}
+
+	for _, tk := range a.Ticks() {
+		if tk.Value > 10 && tk.Value < 1000 {
+			t.Errorf("tick at %g is within the break", tk.Value)
+		}
+	}
+
+	// Ticks at both ends of a break are at the
+	// same place, so only one of them is kept.
+	a = Axis{
+		Min:    0,
+		Max:    100,
+		Scale:  LinearScale,
+		Breaks: []Break{{Min: 20, Max: 80}},
+	}
+	a.Tick.Marker = ConstantTicks([]Tick{{0, "0"}, {20, "20"}, {80, "80"}, {100, "100"}})
+	var labels []string
+	for _, tk := range a.Ticks() {
+		labels = append(labels, tk.Label)
+	}
+	if want := []string{"0", "20", "100"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("tick labels = %q, want %q", labels, want)
+	}
+
+	// The breaks are recomputed when they change.
+	if n := a.Norm(20); math.Abs(n-0.5) > 1e-12 {
+		t.Errorf("Norm(20) = %g, want 0.5", n)
+	}
+	a.Breaks[0].Max = 60
+	if n := a.Norm(60); math.Abs(n-20.0/60) > 1e-12 {
+		t.Errorf("Norm(60) after changing the break = %g, want %g", n, 20.0/60)
+	}
+}
+
+func TestIncludeZero(t *testing.T) {
+	a := Axis{Min: 3.2, Max: 8.7, Scale: LinearScale}
+	if err := a.IncludeZero(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Min != 0 || a.Max != 8.7 {
+		t.Errorf("range is [%g, %g], want [0, 8.7]", a.Min, a.Max)
+	}
+
+	a = Axis{Min: 1, Max: 100, Scale: LogScale}
+	if err := a.IncludeZero(); err == nil {
+		t.Errorf("expected an error for a log scale")
+	}
+	if a.Min != 1 {
+		t.Errorf("log axis was changed to [%g, %g]", a.Min, a.Max)
+	}
+
+	// Scales are checked by whether they can map zero,
+	// so a wrapped log scale is rejected as well, before
+	// the axis has a range.
+	a = Axis{Min: math.Inf(1), Max: math.Inf(-1)}
+	a.Scale = func(min, max, x float64) float64 { return LogScale(min, max, x) }
+	if err := a.IncludeZero(); err == nil {
+		t.Errorf("expected an error for a wrapped log scale")
+	}
+
+	a = Axis{Min: -10, Max: -2, Scale: SymLogScale(1)}
+	if err := a.IncludeZero(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Min != -10 || a.Max != 0 {
+		t.Errorf("range is [%g, %g], want [-10, 0]", a.Min, a.Max)
+	}
+}
+
+func TestCategories(t *testing.T) {
+	a := Axis{Min: 0, Max: 10, Scale: LinearScale}
+	a.Categories = []string{"a", "b", "c", "d"}
+	a.sanitizeRange()
+	for i, tk := range a.Ticks() {
+		if tk.Label != a.Categories[i] || tk.Value != float64(i) {
+			t.Errorf("tick %d is %+v", i, tk)
+		}
+		if want := (float64(i) + 0.5) / 4; math.Abs(a.Norm(tk.Value)-want) > 1e-12 {
+			t.Errorf("category %s is at %g, want %g", tk.Label, a.Norm(tk.Value), want)
+		}
+	}
+	if v, ok := a.Category("c"); !ok || v != 2 {
+		t.Errorf("Category(c) = %g, %t", v, ok)
+	}
+}
+
+func TestSILogTicks(t *testing.T) {
+	want := []string{"1µ", "10µ", "100µ", "1m", "10m", "100m", "1", "10", "100", "1k", "10k", "100k", "1M"}
+	var got []string
+	for _, tk := range SILogTicks(1e-6, 1e6) {
+		if !tk.IsMinor() && tk.Value <= 1e6 {
+			got = append(got, tk.Label)
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got labels %q, want %q", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("label %d is %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestUnnorm(t *testing.T) {
+	for _, a := range []Axis{
+		{Min: -3, Max: 7, Scale: LinearScale},
+		{Min: 1, Max: 1e4, Scale: LogScale},
+		{Min: 0, Max: 1010, Scale: LinearScale, Breaks: []Break{{Min: 10, Max: 1000}}},
+		{Min: -1000, Max: 1000, Scale: SymLogScale(1)},
+	} {
+		for _, x := range []float64{a.Min, 5, 7, a.Max} {
+			if x < a.Min || x > a.Max {
+				continue
+			}
+			got := a.Unnorm(a.Norm(x))
+			if math.Abs(got-x) > 1e-9*math.Max(1, math.Abs(x)) {
+				t.Errorf("Unnorm(Norm(%g)) = %g on %v..%v", x, got, a.Min, a.Max)
+			}
+		}
+		if !math.IsNaN(a.Unnorm(1.5)) {
+			t.Errorf("expected NaN outside of the axis")
+		}
+	}
+}
+
+func TestSymLog(t *testing.T) {
+	for _, x := range []float64{-1000, -2.5, -0.5, 0, 0.5, 2.5, 1000} {
+		if got := symExp(2, symLog(2, x)); math.Abs(got-x) > 1e-9*math.Max(1, math.Abs(x)) {
+			t.Errorf("symExp(symLog(%g)) = %g", x, got)
+		}
+	}
+
+	var labels []string
+	for _, tick := range SymLogTicks(1)(-1000, 1000) {
+		if tick.Label != "" {
+			labels = append(labels, tick.Label)
+		}
+	}
+	want := []string{"-1000", "-100", "-10", "-1", "0", "1", "10", "100", "1000"}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("major tick labels are %q, want %q", labels, want)
+	}
+
+	// The decades of 0.07 are not exact in float64,
+	// so the labels are rounded as by other tickers.
+	labels = nil
+	for _, tick := range SymLogTicks(0.07)(-100, 100) {
+		if tick.Label != "" {
+			labels = append(labels, tick.Label)
+		}
+	}
+	want = []string{"-70", "-7", "-0.7", "-0.07", "0", "0.07", "0.7", "7", "70"}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("major tick labels are %q, want %q", labels, want)
+	}
+
+	for _, threshold := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("no panic for the threshold %g", threshold)
+				}
+			}()
+			SymLogTicks(threshold)
+		}()
+	}
+}
+
+func TestTickInfo(t *testing.T) {
+	a := Axis{Min: 0, Max: 10, Scale: LinearScale}
+	a.Tick.Marker = func(min, max float64) []Tick {
+		return []Tick{{Value: -5, Label: "-5"}, {Value: 0, Label: "0"}, {Value: 2.5}, {Value: 10, Label: "10"}}
+	}
+	got := a.TickInfo(200)
+	want := []TickInfo{{0, "0", 0}, {2.5, "", 50}, {10, "10", 200}}
+	if len(got) != len(want) {
+		t.Fatalf("got %d ticks, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("tick %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNumberFormatLocalize(t *testing.T) {
+	german := NumberFormat{Decimal: ",", Thousands: "."}
+	french := NumberFormat{Decimal: ",", Thousands: " "}
+	for _, test := range []struct {
+		f        NumberFormat
+		in, want string
+	}{
+		{german, "1234.5", "1.234,5"},
+		{french, "1234.5", "1 234,5"},
+		{french, "-1234567", "-1 234 567"},
+		{french, "123", "123"},
+		{french, "0.25", "0,25"},
+		{french, "1.5e+06", "1,5e+06"},
+		{german, "NaN", "NaN"},
+		{NumberFormat{}, "1234.5", "1234.5"},
+	} {
+		if got := test.f.Localize(test.in); got != test.want {
+			t.Errorf("Localize(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestTraceUnnorm(t *testing.T) {
+	for _, test := range []struct {
+		a    Axis
+		n    float64
+		want float64
+	}{
+		{Axis{Min: 0, Max: 10, Scale: LinearScale}, 0.25, 2.5},
+		{Axis{Min: 0, Max: 10, Scale: LinearScale}, -0.5, -5},
+		{Axis{Min: 0, Max: 10, Scale: LinearScale}, 3.5, 35},
+		{Axis{Min: 1, Max: 100, Scale: LogScale}, 1.5, 1000},
+		{Axis{Min: 1, Max: 100, Scale: LogScale}, -1, 0.01},
+		{Axis{Min: 0, Max: 100, Scale: LinearScale, Breaks: []Break{{Min: 20, Max: 80}}}, -0.5, -20},
+	} {
+		if got := traceUnnorm(&test.a, test.n); math.Abs(got-test.want) > 1e-9*math.Max(1, math.Abs(test.want)) {
+			t.Errorf("traceUnnorm(%g) on [%g, %g] = %g, want %g", test.n, test.a.Min, test.a.Max, got, test.want)
+		}
+	}
+}
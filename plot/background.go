@@ -0,0 +1,149 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/vg"
+)
+
+// defaultGradientBands is the number of bands used
+// to draw a LinearGradient whose Bands is zero.
+const defaultGradientBands = 64
+
+// Background wraps the DrawBackground method, which
+// is used to fill the rectangle of a plot before
+// anything else is drawn.
+type Background interface {
+	// DrawBackground fills the draw area.
+	DrawBackground(da *DrawArea)
+}
+
+// ImageBackground is a Background that fills a
+// plot with an image.  Each pixel is drawn as a
+// filled rectangle so that the image can be drawn
+// to any canvas, which suits small or simple
+// images, such as textures.
+type ImageBackground struct {
+	// Image is the image that is drawn.
+	Image image.Image
+
+	// Tiled, if true, repeats the image from the
+	// top left of the plot.  Otherwise the image
+	// is stretched to fill the plot.
+	Tiled bool
+
+	// TileWidth is the width of each tile when
+	// the image is tiled.  The aspect ratio of the
+	// image is kept.  If TileWidth is zero then
+	// each pixel is one point wide.
+	TileWidth vg.Length
+}
+
+// DrawBackground implements the Background interface.
+func (b ImageBackground) DrawBackground(da *DrawArea) {
+	if b.Image == nil || b.Image.Bounds().Empty() {
+		return
+	}
+	if !b.Tiled {
+		drawPixels(da, b.Image, da.Rect, da.Rect)
+		return
+	}
+
+	size := b.Image.Bounds().Size()
+	w := b.TileWidth
+	if w <= 0 {
+		w = vg.Length(size.X)
+	}
+	h := w * vg.Length(size.Y) / vg.Length(size.X)
+	for top := da.Max().Y; top > da.Min.Y; top -= h {
+		for x := da.Min.X; x < da.Max().X; x += w {
+			tile := Rect{Min: Point{x, top - h}, Size: Point{w, h}}
+			drawPixels(da, b.Image, tile, da.Rect)
+		}
+	}
+}
+
+// LinearGradient is a Background that fills a plot
+// with colors that change linearly from one color
+// to another across the plot.  The canvases have
+// no gradient fills, so the gradient is drawn as a
+// number of bands of constant color.
+type LinearGradient struct {
+	// From and To are the colors at the start
+	// and the end of the gradient.
+	From, To color.Color
+
+	// Angle is the direction of the gradient in
+	// radians counter clockwise from the X axis.
+	// An angle of zero runs from left to right,
+	// and an angle of π/2 from bottom to top.
+	Angle float64
+
+	// Bands is the number of bands used to draw
+	// the gradient.  If Bands is zero then 64 bands
+	// are used.
+	Bands int
+}
+
+// DrawBackground implements the Background interface.
+func (g LinearGradient) DrawBackground(da *DrawArea) {
+	n := g.Bands
+	if n <= 0 {
+		n = defaultGradientBands
+	}
+	sin, cos := math.Sincos(g.Angle)
+	dir := Point{vg.Length(cos), vg.Length(sin)}
+	perp := Point{-dir.Y, dir.X}
+
+	// Find the extent of the draw area along the
+	// direction of the gradient.
+	c := da.Center()
+	tmin, tmax := vg.Length(math.Inf(1)), vg.Length(math.Inf(-1))
+	for _, p := range []Point{da.Min, da.Max(), {da.Min.X, da.Max().Y}, {da.Max().X, da.Min.Y}} {
+		t := p.minus(c).dot(dir)
+		tmin = vg.Length(math.Min(float64(tmin), float64(t)))
+		tmax = vg.Length(math.Max(float64(tmax), float64(t)))
+	}
+
+	// Each band is a strip across the whole draw
+	// area, which is clipped to it.
+	across := perp.scale(da.Size.X + da.Size.Y)
+	step := (tmax - tmin) / vg.Length(n)
+	for i := 0; i < n; i++ {
+		start := c.plus(dir.scale(tmin + step*vg.Length(i)))
+		end := start.plus(dir.scale(step))
+		band := []Point{
+			start.minus(across), end.minus(across),
+			end.plus(across), start.plus(across),
+		}
+		clr := lerpColor(g.From, g.To, (float64(i)+0.5)/float64(n))
+		da.FillPolygon(clr, da.ClipPolygonXY(band))
+	}
+}
+
+// lerpColor returns the color a fraction f of the
+// way from a to b.  Nil colors are transparent.
+func lerpColor(a, b color.Color, f float64) color.Color {
+	var ar, ag, ab, aa, br, bg, bb, ba uint32
+	if a != nil {
+		ar, ag, ab, aa = a.RGBA()
+	}
+	if b != nil {
+		br, bg, bb, ba = b.RGBA()
+	}
+	lerp := func(x, y uint32) uint16 {
+		return uint16(float64(x) + f*(float64(y)-float64(x)) + 0.5)
+	}
+	return color.RGBA64{
+		R: lerp(ar, br),
+		G: lerp(ag, bg),
+		B: lerp(ab, bb),
+		A: lerp(aa, ba),
+	}
+}
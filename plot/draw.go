@@ -27,8 +27,45 @@ type TextStyle struct {
 
 	// Font is the font description.
 	Font vg.Font
+
+	// Fallbacks are fonts that are tried in turn,
+	// rune by rune, for the runes of the text that
+	// Font has no glyph for.  Each rune is drawn in
+	// the first of the fonts that has a glyph for it,
+	// or in Font if none of them has.  The extents of
+	// the text, and so the spacing of its lines, are
+	// those of Font.
+	Fallbacks []vg.Font
+
+	// Direction is the direction in which the
+	// glyphs of the text advance.  The zero
+	// value is LeftToRight.
+	Direction TextDirection
 }
 
+// A TextDirection is the direction in which the
+// glyphs of a line of text advance.  Text is not
+// shaped: glyphs are placed one after another in
+// the given direction, in the order of the runes
+// of the text.
+type TextDirection int
+
+const (
+	// LeftToRight text advances to the right,
+	// with lines advancing down the page.
+	LeftToRight TextDirection = iota
+
+	// RightToLeft text advances to the left,
+	// with lines advancing down the page.
+	RightToLeft
+
+	// TopToBottom text is vertical, each glyph
+	// centered below the one before it.  Lines
+	// are columns that advance to the left, as
+	// in vertical CJK text.
+	TopToBottom
+)
+
 // LineStyle describes what a line will look like.
 type LineStyle struct {
 	// Color is the color of the line.
@@ -39,6 +76,12 @@ type LineStyle struct {
 
 	Dashes   []vg.Length
 	DashOffs vg.Length
+
+	// Alpha, if non-zero, scales the opacity of
+	// Color, so that a line can be dimmed without
+	// changing its color.  E.g., an Alpha of 0.3
+	// draws an opaque color at 30% opacity.
+	Alpha float64
 }
 
 // A GlyphStyle specifies the look of a glyph used to draw
@@ -52,6 +95,19 @@ type GlyphStyle struct {
 
 	// Shape draws the shape of the glyph.
 	Shape GlyphDrawer
+
+	// Alpha, if non-zero, scales the opacity
+	// of Color, as for LineStyle.
+	Alpha float64
+
+	// Outline is the style of the line stroked
+	// around solid glyphs, such as CircleGlyph,
+	// BoxGlyph and PyramidGlyph, after they are
+	// filled.  If its Color is nil or its Width
+	// is zero then no outline is drawn.  A solid
+	// glyph with an outline and a nil Color is
+	// drawn hollow, as the outline alone.
+	Outline LineStyle
 }
 
 // A GlyphDrawer wraps the DrawGlyph function.
@@ -68,7 +124,14 @@ func (da *DrawArea) DrawGlyph(sty GlyphStyle, pt Point) {
 	if sty.Shape == nil || !da.Contains(pt) {
 		return
 	}
+	if sty.Color != nil {
+		sty.Color, sty.Alpha = withAlpha(sty.Color, sty.Alpha), 0
+	}
 	da.SetColor(sty.Color)
+	if t, ok := da.Canvas.(*traceCanvas); ok {
+		t.beginGlyph(pt)
+		defer t.endGlyph()
+	}
 	sty.Shape.DrawGlyph(da, sty, pt)
 }
 
@@ -78,16 +141,81 @@ func (da *DrawArea) DrawGlyphNoClip(sty GlyphStyle, pt Point) {
 	if sty.Shape == nil {
 		return
 	}
+	if sty.Color != nil {
+		sty.Color, sty.Alpha = withAlpha(sty.Color, sty.Alpha), 0
+	}
 	da.SetColor(sty.Color)
+	if t, ok := da.Canvas.(*traceCanvas); ok {
+		t.beginGlyph(pt)
+		defer t.endGlyph()
+	}
 	sty.Shape.DrawGlyph(da, sty, pt)
 }
 
 // Rect returns the rectangle surrounding this glyph,
-// assuming that it is drawn centered at 0,0
+// assuming that it is drawn centered at 0,0.  If the
+// glyph's Shape is a GlyphBounder then the rectangle
+// is its exact bounds, otherwise it is the square
+// surrounding a circle of the glyph's radius.
 func (g GlyphStyle) Rect() Rect {
+	if b, ok := g.Shape.(GlyphBounder); ok {
+		return b.GlyphBounds(g)
+	}
 	return Rect{Point{-g.Radius, -g.Radius}, Point{g.Radius * 2, g.Radius * 2}}
 }
 
+// A GlyphBounder is a GlyphDrawer that can give the
+// exact bounds of the glyphs that it draws.
+type GlyphBounder interface {
+	// GlyphBounds returns the rectangle surrounding
+	// all of the marks of a glyph of the given style
+	// drawn centered at 0,0, including the width of
+	// any stroked lines.
+	GlyphBounds(GlyphStyle) Rect
+}
+
+// glyphLineWidth is the width in points of
+// the lines of the outlined glyphs.
+const glyphLineWidth = 0.5
+
+// hasOutline returns true if the style
+// has an outline for solid glyphs.
+func (sty GlyphStyle) hasOutline() bool {
+	return sty.Outline.Color != nil && sty.Outline.Width > 0
+}
+
+// fillGlyph fills the path of a solid glyph with the
+// current color, unless the glyph is hollow, and then
+// strokes its outline if the style has one.
+func (da *DrawArea) fillGlyph(sty GlyphStyle, p vg.Path) {
+	if sty.Color != nil || !sty.hasOutline() {
+		da.Fill(p)
+	}
+	if sty.hasOutline() {
+		da.SetLineStyle(sty.Outline)
+		da.Stroke(p)
+	}
+}
+
+// solidRect returns the bounds r of a solid glyph
+// grown by the amount that its outline, if it
+// has one, extends beyond it.  Corners of the
+// outline may be mitered, so the bounds are grown
+// by the full width of the line.
+func (sty GlyphStyle) solidRect(r Rect) Rect {
+	if !sty.hasOutline() {
+		return r
+	}
+	w := sty.Outline.Width
+	return Rect{Point{r.Min.X - w, r.Min.Y - w}, Point{r.Size.X + 2*w, r.Size.Y + 2*w}}
+}
+
+// centeredRect returns a Rect centered at 0,0
+// extending by r in each direction.
+func centeredRect(r vg.Length) Rect {
+	return Rect{Point{-r, -r}, Point{2 * r, 2 * r}}
+}
+
 // CircleGlyph is a glyph that draws a solid circle.
 type CircleGlyph struct{}
 
@@ -97,7 +225,15 @@ func (c CircleGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
 	p.Move(pt.X+sty.Radius, pt.Y)
 	p.Arc(pt.X, pt.Y, sty.Radius, 0, 2*math.Pi)
 	p.Close()
-	da.Fill(p)
+	da.fillGlyph(sty, p)
+}
+
+// GlyphBounds implements the GlyphBounder interface.
+func (CircleGlyph) GlyphBounds(sty GlyphStyle) Rect {
+	if sty.hasOutline() {
+		return centeredRect(sty.Radius + sty.Outline.Width/2)
+	}
+	return centeredRect(sty.Radius)
 }
 
 // RingGlyph is a glyph that draws the outline of a circle.
@@ -105,7 +241,7 @@ type RingGlyph struct{}
 
 // DrawGlyph implements the Glyph interface.
 func (RingGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
-	da.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
+	da.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(glyphLineWidth)})
 	var p vg.Path
 	p.Move(pt.X+sty.Radius, pt.Y)
 	p.Arc(pt.X, pt.Y, sty.Radius, 0, 2*math.Pi)
@@ -113,6 +249,11 @@ func (RingGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
 	da.Stroke(p)
 }
 
+// GlyphBounds implements the GlyphBounder interface.
+func (RingGlyph) GlyphBounds(sty GlyphStyle) Rect {
+	return centeredRect(sty.Radius + vg.Points(glyphLineWidth)/2)
+}
+
 const (
 	cosπover4 = vg.Length(.707106781202420)
 	sinπover6 = vg.Length(.500000000025921)
@@ -124,8 +265,8 @@ type SquareGlyph struct{}
 
 // DrawGlyph implements the Glyph interface.
 func (SquareGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
-	da.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
-	x := (sty.Radius-sty.Radius*cosπover4)/2 + sty.Radius*cosπover4
+	da.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(glyphLineWidth)})
+	x := squareHalfWidth(sty.Radius)
 	var p vg.Path
 	p.Move(pt.X-x, pt.Y-x)
 	p.Line(pt.X+x, pt.Y-x)
@@ -135,19 +276,35 @@ func (SquareGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
 	da.Stroke(p)
 }
 
+// GlyphBounds implements the GlyphBounder interface.
+func (SquareGlyph) GlyphBounds(sty GlyphStyle) Rect {
+	return centeredRect(squareHalfWidth(sty.Radius) + vg.Points(glyphLineWidth)/2)
+}
+
 // BoxGlyph is a glyph that draws a filled square.
 type BoxGlyph struct{}
 
 // DrawGlyph implements the Glyph interface.
 func (BoxGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
-	x := (sty.Radius-sty.Radius*cosπover4)/2 + sty.Radius*cosπover4
+	x := squareHalfWidth(sty.Radius)
 	var p vg.Path
 	p.Move(pt.X-x, pt.Y-x)
 	p.Line(pt.X+x, pt.Y-x)
 	p.Line(pt.X+x, pt.Y+x)
 	p.Line(pt.X-x, pt.Y+x)
 	p.Close()
-	da.Fill(p)
+	da.fillGlyph(sty, p)
+}
+
+// GlyphBounds implements the GlyphBounder interface.
+func (BoxGlyph) GlyphBounds(sty GlyphStyle) Rect {
+	return sty.solidRect(centeredRect(squareHalfWidth(sty.Radius)))
+}
+
+// squareHalfWidth returns half of the width of
+// a square glyph of the given radius.
+func squareHalfWidth(r vg.Length) vg.Length {
+	return (r-r*cosπover4)/2 + r*cosπover4
 }
 
 // TriangleGlyph is a glyph that draws the outline of a triangle.
@@ -155,7 +312,7 @@ type TriangleGlyph struct{}
 
 // DrawGlyph implements the Glyph interface.
 func (TriangleGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
-	da.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
+	da.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(glyphLineWidth)})
 	r := sty.Radius + (sty.Radius-sty.Radius*sinπover6)/2
 	var p vg.Path
 	p.Move(pt.X, pt.Y+r)
@@ -165,6 +322,16 @@ func (TriangleGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
 	da.Stroke(p)
 }
 
+// GlyphBounds implements the GlyphBounder interface.
+// The bounds allow for the mitered corners of the
+// outline, which extend beyond the triangle by up to
+// the width of the line.
+func (TriangleGlyph) GlyphBounds(sty GlyphStyle) Rect {
+	r := triangleRect(sty.Radius)
+	w := vg.Points(glyphLineWidth)
+	return Rect{Point{r.Min.X - w, r.Min.Y - w}, Point{r.Size.X + 2*w, r.Size.Y + 2*w}}
+}
+
 // PyramidGlyph is a glyph that draws a filled triangle.
 type PyramidGlyph struct{}
 
@@ -176,7 +343,19 @@ func (PyramidGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
 	p.Line(pt.X-r*cosπover6, pt.Y-r*sinπover6)
 	p.Line(pt.X+r*cosπover6, pt.Y-r*sinπover6)
 	p.Close()
-	da.Fill(p)
+	da.fillGlyph(sty, p)
+}
+
+// GlyphBounds implements the GlyphBounder interface.
+func (PyramidGlyph) GlyphBounds(sty GlyphStyle) Rect {
+	return sty.solidRect(triangleRect(sty.Radius))
+}
+
+// triangleRect returns the bounds of a triangle
+// glyph of the given radius.
+func triangleRect(radius vg.Length) Rect {
+	r := radius + (radius-radius*sinπover6)/2
+	return Rect{Point{-r * cosπover6, -r * sinπover6}, Point{2 * r * cosπover6, r + r*sinπover6}}
 }
 
 // PlusGlyph is a glyph that draws a plus sign
@@ -184,7 +363,7 @@ type PlusGlyph struct{}
 
 // DrawGlyph implements the Glyph interface.
 func (PlusGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
-	da.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
+	da.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(glyphLineWidth)})
 	r := sty.Radius
 	var p vg.Path
 	p.Move(pt.X, pt.Y+r)
@@ -196,12 +375,17 @@ func (PlusGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
 	da.Stroke(p)
 }
 
+// GlyphBounds implements the GlyphBounder interface.
+func (PlusGlyph) GlyphBounds(sty GlyphStyle) Rect {
+	return centeredRect(sty.Radius)
+}
+
 // CrossGlyph is a glyph that draws a big X.
 type CrossGlyph struct{}
 
 // DrawGlyph implements the Glyph interface.
 func (CrossGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
-	da.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
+	da.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(glyphLineWidth)})
 	r := sty.Radius * cosπover4
 	var p vg.Path
 	p.Move(pt.X-r, pt.Y-r)
@@ -213,6 +397,11 @@ func (CrossGlyph) DrawGlyph(da *DrawArea, sty GlyphStyle, pt Point) {
 	da.Stroke(p)
 }
 
+// GlyphBounds implements the GlyphBounder interface.
+func (CrossGlyph) GlyphBounds(sty GlyphStyle) Rect {
+	return centeredRect((sty.Radius + vg.Points(glyphLineWidth)/2) * cosπover4)
+}
+
 // MakeDrawArea returns a new DrawArea for a canvas with a
 // Size method.
 func MakeDrawArea(c interface {
@@ -288,14 +477,33 @@ func (da DrawArea) crop(minx, miny, maxx, maxy vg.Length) DrawArea {
 		Y: da.Max().Y + maxy - minpt.Y,
 	}
 	return DrawArea{
-		Canvas: vg.Canvas(da),
+		Canvas: da.Canvas,
 		Rect:   Rect{Min: minpt, Size: sz},
 	}
 }
 
+// withAlpha returns the color with its opacity
+// scaled by alpha.  If alpha is zero then the
+// color is returned unchanged.
+func withAlpha(c color.Color, alpha float64) color.Color {
+	if alpha == 0 {
+		return c
+	}
+	if c == nil {
+		c = color.Black
+	}
+	r, g, b, a := c.RGBA()
+	// The components are premultiplied by the
+	// alpha, so they are all scaled.
+	scale := func(v uint32) uint16 {
+		return uint16(float64(v) * math.Max(0, math.Min(1, alpha)))
+	}
+	return color.RGBA64{R: scale(r), G: scale(g), B: scale(b), A: scale(a)}
+}
+
 // SetLineStyle sets the current line style
 func (da *DrawArea) SetLineStyle(sty LineStyle) {
-	da.SetColor(sty.Color)
+	da.SetColor(withAlpha(sty.Color, sty.Alpha))
 	da.SetLineWidth(sty.Width)
 	var dashDots []vg.Length
 	for _, dash := range sty.Dashes {
@@ -512,28 +720,161 @@ func (da *DrawArea) FillText(sty TextStyle, x, y vg.Length, xalign, yalign float
 
 	da.SetColor(sty.Color)
 
+	if sty.Direction == TopToBottom {
+		da.fillVertical(sty, x+sty.Width(txt)*vg.Length(xalign), y+sty.Height(txt)*vg.Length(yalign), txt)
+		return
+	}
+
 	ht := sty.Height(txt)
 	y += ht*vg.Length(yalign) - sty.Font.Extents().Ascent
 	nl := textNLines(txt)
 	for i, line := range strings.Split(txt, "\n") {
-		xoffs := vg.Length(xalign) * sty.Font.Width(line)
+		if sty.Direction == RightToLeft {
+			line = reverse(line)
+		}
+		xoffs := vg.Length(xalign) * sty.lineWidth(line)
 		n := vg.Length(nl - i)
-		da.FillString(sty.Font, x+xoffs, y+n*sty.Font.Size, line)
+		for _, run := range sty.fontRuns(line) {
+			da.FillString(run.font, x+xoffs, y+n*sty.Font.Size, run.text)
+			xoffs += run.font.Width(run.text)
+		}
+	}
+}
+
+// fillVertical fills lines of top-to-bottom text
+// with the bottom left corner of its bounds at x, y.
+// Each glyph is centered in a column as wide as the
+// widest glyph, and each line of the text is a column
+// to the left of the one before it.
+func (da *DrawArea) fillVertical(sty TextStyle, x, y vg.Length, txt string) {
+	e := sty.Font.Extents()
+	colw := sty.runeWidth(txt)
+	top := y + sty.Height(txt)
+	cx := x + sty.Width(txt) - colw/2
+	for _, line := range strings.Split(txt, "\n") {
+		y := top - e.Ascent
+		for _, r := range line {
+			s, f := string(r), sty.fontFor(r)
+			da.FillString(f, cx-f.Width(s)/2, y, s)
+			y -= e.Height
+		}
+		cx -= e.Height
+	}
+}
+
+// reverse returns a string with the
+// runes of s in reverse order.
+func reverse(s string) string {
+	rs := []rune(s)
+	for i, j := 0, len(rs)-1; i < j; i, j = i+1, j-1 {
+		rs[i], rs[j] = rs[j], rs[i]
+	}
+	return string(rs)
+}
+
+// runeWidth returns the width of the widest
+// rune in the text.
+func (sty TextStyle) runeWidth(txt string) (max vg.Length) {
+	for _, r := range txt {
+		if r == '\n' {
+			continue
+		}
+		f := sty.fontFor(r)
+		if w := f.Width(string(r)); w > max {
+			max = w
+		}
 	}
+	return
 }
 
 // Width returns the width of lines of text
 // when using the given font.
 func (sty TextStyle) Width(txt string) (max vg.Length) {
 	txt = strings.TrimRight(txt, "\n")
+	if sty.Direction == TopToBottom {
+		nl := textNLines(txt)
+		if nl == 0 {
+			return 0
+		}
+		return sty.Font.Extents().Height*vg.Length(nl-1) + sty.runeWidth(txt)
+	}
 	for _, line := range strings.Split(txt, "\n") {
-		if w := sty.Font.Width(line); w > max {
+		if w := sty.lineWidth(line); w > max {
 			max = w
 		}
 	}
 	return
 }
 
+// fontFor returns the font in which a rune is
+// drawn: the first of Font and the Fallbacks that
+// has a glyph for it, or Font if none of them has.
+func (sty TextStyle) fontFor(r rune) vg.Font {
+	return sty.fontAt(sty.fontIndex(r))
+}
+
+// fontIndex returns the index in Fallbacks of the
+// font in which a rune is drawn, or -1 for Font.
+func (sty TextStyle) fontIndex(r rune) int {
+	if len(sty.Fallbacks) == 0 || sty.Font.HasGlyph(r) {
+		return -1
+	}
+	for i := range sty.Fallbacks {
+		if sty.Fallbacks[i].HasGlyph(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// fontAt returns the font with the given index in
+// Fallbacks, or Font for the index -1.
+func (sty TextStyle) fontAt(i int) vg.Font {
+	if i < 0 {
+		return sty.Font
+	}
+	return sty.Fallbacks[i]
+}
+
+// A fontRun is a run of text that is
+// drawn in a single font.
+type fontRun struct {
+	font vg.Font
+	text string
+}
+
+// fontRuns splits a line of text into the runs
+// of consecutive runes that are drawn in the same
+// font.
+func (sty TextStyle) fontRuns(line string) []fontRun {
+	if len(sty.Fallbacks) == 0 {
+		return []fontRun{{sty.Font, line}}
+	}
+	var runs []fontRun
+	start, cur := 0, -1
+	for i, r := range line {
+		idx := sty.fontIndex(r)
+		if idx != cur && i > start {
+			runs = append(runs, fontRun{sty.fontAt(cur), line[start:i]})
+			start = i
+		}
+		cur = idx
+	}
+	if start < len(line) {
+		runs = append(runs, fontRun{sty.fontAt(cur), line[start:]})
+	}
+	return runs
+}
+
+// lineWidth returns the width of a line of text,
+// drawn in its font runs.
+func (sty TextStyle) lineWidth(line string) (w vg.Length) {
+	for _, run := range sty.fontRuns(line) {
+		w += run.font.Width(run.text)
+	}
+	return w
+}
+
 // Height returns the height of the text when using
 // the given font.
 func (sty TextStyle) Height(txt string) vg.Length {
@@ -542,6 +883,18 @@ func (sty TextStyle) Height(txt string) vg.Length {
 		return vg.Length(0)
 	}
 	e := sty.Font.Extents()
+	if sty.Direction == TopToBottom {
+		n := 0
+		for _, line := range strings.Split(strings.TrimRight(txt, "\n"), "\n") {
+			if k := len([]rune(line)); k > n {
+				n = k
+			}
+		}
+		if n == 0 {
+			return 0
+		}
+		return e.Height*vg.Length(n-1) + e.Ascent
+	}
 	return e.Height*vg.Length(nl-1) + e.Ascent
 }
 
@@ -0,0 +1,159 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/vg"
+)
+
+// A Frame is a rectangle with rounded corners that is
+// drawn around the data area of a plot.  The plotters
+// are clipped to the inside of the frame on canvases
+// that implement vg.Clipper, as all of the canvases in
+// the vg packages do.  Other canvases draw the plotters
+// clipped only by the plotters themselves, to the
+// rectangle of the data area.
+type Frame struct {
+	// Radius is the radius of the corners.  It is
+	// limited to half of the width and height of
+	// the frame, so a large radius makes a pill
+	// shape.
+	Radius vg.Length
+
+	// Color is the color that fills the frame
+	// behind the plotters.  If Color is nil then
+	// the frame is not filled.
+	Color color.Color
+
+	// LineStyle is the style of the border of the
+	// frame, which is drawn over the plotters.  If
+	// the width is zero then no border is drawn.
+	LineStyle
+
+	// HideTop, HideRight, HideBottom and HideLeft
+	// hide sides of the border, such as to draw only
+	// the left and bottom sides in the style of many
+	// journals.  The border of a rounded frame ends
+	// where the rounded corner next to a hidden side
+	// would begin.  Hiding sides does not change the
+	// fill or the clipping of the frame.
+	HideTop, HideRight, HideBottom, HideLeft bool
+}
+
+// path returns the outline of the frame around
+// the given rectangle.
+func (f *Frame) path(r Rect) vg.Path {
+	return roundedRectPath(r, f.Radius)
+}
+
+// drawBackground fills the frame around the given
+// draw area and, if the canvas is a vg.Clipper,
+// clips later drawing to the inside of it.  It
+// returns true if a clip was started, in which case
+// the caller must call da.Pop to end it.
+func (f *Frame) drawBackground(da DrawArea) bool {
+	path := f.path(da.Rect)
+	if f.Color != nil {
+		da.SetColor(f.Color)
+		da.Fill(path)
+	}
+	cl, ok := da.Canvas.(vg.Clipper)
+	if !ok {
+		return false
+	}
+	da.Push()
+	cl.Clip(path)
+	return true
+}
+
+// drawBorder strokes the border of the frame
+// around the given draw area.
+func (f *Frame) drawBorder(da DrawArea) {
+	if f.Width <= 0 {
+		return
+	}
+	da.SetLineStyle(f.LineStyle)
+	da.Stroke(f.borderPath(da.Rect))
+}
+
+// borderPath returns the path of the visible sides
+// of the border around the given rectangle.
+func (f *Frame) borderPath(r Rect) vg.Path {
+	// The sides are in counter-clockwise order,
+	// starting with the bottom.  Side i runs from
+	// corners[i] to the next corner in the direction
+	// dirs[i].
+	hidden := [4]bool{f.HideBottom, f.HideRight, f.HideTop, f.HideLeft}
+	first := -1
+	for i, h := range hidden {
+		if h {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return f.path(r)
+	}
+
+	rad := vg.Length(math.Min(float64(f.Radius), math.Min(float64(r.Size.X), float64(r.Size.Y))/2))
+	if rad < 0 {
+		rad = 0
+	}
+	min, max := r.Min, r.Max()
+	corners := [4]Point{min, {max.X, min.Y}, max, {min.X, max.Y}}
+	dirs := [4]Point{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}
+
+	// Starting after a hidden side means that no
+	// run of visible sides wraps around the end
+	// of the arrays.
+	var p vg.Path
+	for j := 1; j <= 4; j++ {
+		i := (first + j) % 4
+		if hidden[i] {
+			continue
+		}
+		prev, next := (i+3)%4, (i+1)%4
+		if hidden[prev] {
+			from := corners[i].plus(dirs[i].scale(rad))
+			p.Move(from.X, from.Y)
+		}
+		to := corners[next].minus(dirs[i].scale(rad))
+		p.Line(to.X, to.Y)
+		if !hidden[next] && rad > 0 {
+			// The center of a corner is inward
+			// along this side and the next one.
+			c := to.plus(dirs[next].scale(rad))
+			p.Arc(c.X, c.Y, rad, float64(next-2)*math.Pi/2, math.Pi/2)
+		}
+	}
+	return p
+}
+
+// roundedRectPath returns the path of a rectangle
+// whose corners are quarter circles of the given
+// radius, which is limited to half of the width and
+// height of the rectangle.
+func roundedRectPath(r Rect, rad vg.Length) vg.Path {
+	rad = vg.Length(math.Min(float64(rad), math.Min(float64(r.Size.X), float64(r.Size.Y))/2))
+	if rad <= 0 {
+		return rectPath(r)
+	}
+	min, max := r.Min, r.Max()
+	var p vg.Path
+	p.Move(min.X+rad, min.Y)
+	p.Line(max.X-rad, min.Y)
+	p.Arc(max.X-rad, min.Y+rad, rad, -math.Pi/2, math.Pi/2)
+	p.Line(max.X, max.Y-rad)
+	p.Arc(max.X-rad, max.Y-rad, rad, 0, math.Pi/2)
+	p.Line(min.X+rad, max.Y)
+	p.Arc(min.X+rad, max.Y-rad, rad, math.Pi/2, math.Pi/2)
+	p.Line(min.X, min.Y+rad)
+	p.Arc(min.X+rad, min.Y+rad, rad, math.Pi, math.Pi/2)
+	p.Close()
+	return p
+}
@@ -0,0 +1,196 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gonum/plot/vg"
+)
+
+// A HatchPattern is a pattern of marks
+// used to fill a region.
+type HatchPattern int
+
+const (
+	// NoHatch draws nothing.
+	NoHatch HatchPattern = iota
+
+	// DiagonalHatch draws lines rising
+	// to the right at 45°.
+	DiagonalHatch
+
+	// BackDiagonalHatch draws lines falling
+	// to the right at 45°.
+	BackDiagonalHatch
+
+	// CrossHatch draws both diagonal and
+	// back-diagonal lines.
+	CrossHatch
+
+	// HorizontalHatch draws horizontal lines.
+	HorizontalHatch
+
+	// VerticalHatch draws vertical lines.
+	VerticalHatch
+
+	// DotHatch draws a square grid of dots.
+	DotHatch
+)
+
+// HatchStyle describes how a region is hatched.
+// Hatching distinguishes filled regions without
+// relying on color, for example in a grayscale
+// print.
+type HatchStyle struct {
+	// Pattern is the pattern of the hatching.
+	Pattern HatchPattern
+
+	// LineStyle is the style of the hatch lines.
+	// The dots of a DotHatch are filled circles
+	// with a diameter of the line width.
+	LineStyle
+
+	// Spacing is the distance between the lines,
+	// or dots, of the hatching.
+	Spacing vg.Length
+}
+
+// DefaultHatchSpacing is the Spacing used by
+// FillHatch if a HatchStyle's Spacing is zero.
+var DefaultHatchSpacing = vg.Points(4)
+
+// FillHatch draws a hatch pattern within a polygon.
+// The polygon may be concave; points are considered
+// to be inside it by the even-odd rule.  Like
+// FillPolygon, FillHatch does not clip: the polygon
+// should be clipped to the draw area, for example by
+// ClipPolygonXY.
+//
+// The hatching is aligned to the canvas, not to the
+// polygon, so the hatching of adjacent regions with
+// the same style lines up.
+func (da *DrawArea) FillHatch(sty HatchStyle, pts []Point) {
+	if sty.Pattern == NoHatch || sty.Color == nil || len(pts) < 3 {
+		return
+	}
+	space := sty.Spacing
+	if space <= 0 {
+		space = DefaultHatchSpacing
+	}
+	switch sty.Pattern {
+	case DiagonalHatch:
+		da.StrokeLines(sty.LineStyle, hatchLines(pts, math.Pi/4, space)...)
+	case BackDiagonalHatch:
+		da.StrokeLines(sty.LineStyle, hatchLines(pts, -math.Pi/4, space)...)
+	case CrossHatch:
+		da.StrokeLines(sty.LineStyle, hatchLines(pts, math.Pi/4, space)...)
+		da.StrokeLines(sty.LineStyle, hatchLines(pts, -math.Pi/4, space)...)
+	case HorizontalHatch:
+		da.StrokeLines(sty.LineStyle, hatchLines(pts, 0, space)...)
+	case VerticalHatch:
+		da.StrokeLines(sty.LineStyle, hatchLines(pts, math.Pi/2, space)...)
+	case DotHatch:
+		da.fillDots(sty, pts, space)
+	}
+}
+
+// hatchLines returns the segments of the parallel
+// lines at the given angle and spacing that are
+// within the polygon.
+func hatchLines(pts []Point, angle float64, space vg.Length) [][]Point {
+	sin, cos := math.Sincos(angle)
+	dir := Point{vg.Length(cos), vg.Length(sin)}
+	norm := Point{-dir.Y, dir.X}
+
+	min, max := vg.Length(math.Inf(1)), vg.Length(math.Inf(-1))
+	for _, p := range pts {
+		c := p.dot(norm)
+		if c < min {
+			min = c
+		}
+		if c > max {
+			max = c
+		}
+	}
+
+	var lines [][]Point
+	for c := vg.Length(math.Ceil(float64(min/space))) * space; c <= max; c += space {
+		// Find where the line crosses the edges of
+		// the polygon, ordered along the line.  The
+		// line is inside the polygon between each
+		// pair of crossings.
+		var ts []float64
+		for i, a := range pts {
+			b := pts[(i+1)%len(pts)]
+			ea, eb := a.dot(norm)-c, b.dot(norm)-c
+			if (ea < 0) == (eb < 0) {
+				continue
+			}
+			p := a.plus(b.minus(a).scale(ea / (ea - eb)))
+			ts = append(ts, float64(p.dot(dir)))
+		}
+		sort.Float64s(ts)
+		for i := 0; i+1 < len(ts); i += 2 {
+			lines = append(lines, []Point{
+				norm.scale(c).plus(dir.scale(vg.Length(ts[i]))),
+				norm.scale(c).plus(dir.scale(vg.Length(ts[i+1]))),
+			})
+		}
+	}
+	return lines
+}
+
+// fillDots fills a grid of dots within the polygon.
+func (da *DrawArea) fillDots(sty HatchStyle, pts []Point, space vg.Length) {
+	min, max := pts[0], pts[0]
+	for _, p := range pts[1:] {
+		if p.X < min.X {
+			min.X = p.X
+		}
+		if p.Y < min.Y {
+			min.Y = p.Y
+		}
+		if p.X > max.X {
+			max.X = p.X
+		}
+		if p.Y > max.Y {
+			max.Y = p.Y
+		}
+	}
+	r := sty.Width / 2
+	if r <= 0 {
+		return
+	}
+	da.SetColor(withAlpha(sty.Color, sty.Alpha))
+	var p vg.Path
+	for y := vg.Length(math.Ceil(float64(min.Y/space))) * space; y <= max.Y; y += space {
+		for x := vg.Length(math.Ceil(float64(min.X/space))) * space; x <= max.X; x += space {
+			if !insidePolygon(Point{x, y}, pts) {
+				continue
+			}
+			p.Move(x+r, y)
+			p.Arc(x, y, r, 0, 2*math.Pi)
+			p.Close()
+		}
+	}
+	if len(p) > 0 {
+		da.Fill(p)
+	}
+}
+
+// insidePolygon returns whether a point is inside
+// a polygon by the even-odd rule.
+func insidePolygon(pt Point, pts []Point) bool {
+	in := false
+	for i, a := range pts {
+		b := pts[(i+1)%len(pts)]
+		if (a.Y > pt.Y) != (b.Y > pt.Y) && pt.X < a.X+(b.X-a.X)*(pt.Y-a.Y)/(b.Y-a.Y) {
+			in = !in
+		}
+	}
+	return in
+}
@@ -0,0 +1,38 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHatchLines(t *testing.T) {
+	// An L-shaped, concave polygon.
+	poly := []Point{{0, 0}, {10, 0}, {10, 4}, {4, 4}, {4, 10}, {0, 10}}
+
+	lines := hatchLines(poly, 0, 3)
+	if len(lines) != 3 {
+		t.Fatalf("got %d horizontal lines, want 3", len(lines))
+	}
+	for _, l := range lines {
+		y := l[0].Y
+		want := 10.0
+		if y > 4 {
+			want = 4
+		}
+		if l[0].X > 1e-9 || math.Abs(float64(l[1].X)-want) > 1e-9 {
+			t.Errorf("line at y=%v spans %v to %v, want 0 to %v", y, l[0].X, l[1].X, want)
+		}
+	}
+
+	for _, l := range hatchLines(poly, math.Pi/4, 2) {
+		for _, p := range l {
+			if p.X < -1e-9 || p.Y < -1e-9 || p.X > 10+1e-9 || p.Y > 10+1e-9 {
+				t.Errorf("diagonal hatch point %v is outside the polygon", p)
+			}
+		}
+	}
+}
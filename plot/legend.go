@@ -5,6 +5,10 @@
 package plot
 
 import (
+	"image"
+	"image/color"
+	"math"
+
 	"github.com/gonum/plot/vg"
 )
 
@@ -40,6 +44,21 @@ type Legend struct {
 	// ThumbnailWidth is the width of legend thumbnails.
 	ThumbnailWidth vg.Length
 
+	// Border is the style of the line drawn around
+	// the legend.  If its Color is nil or its Width
+	// is zero then no border is drawn.
+	Border LineStyle
+
+	// BackgroundColor is the color that fills the
+	// legend's box, beneath its entries.  If it is
+	// nil then the background is not filled.
+	BackgroundColor color.Color
+
+	// BoxPadding is the amount of space between
+	// the entries and the edges of the legend's
+	// box.
+	BoxPadding vg.Length
+
 	// entries are all of the legendEntries described
 	// by this legend.
 	entries []legendEntry
@@ -66,6 +85,108 @@ type Thumbnailer interface {
 	Thumbnail(da *DrawArea)
 }
 
+// ThumbnailFunc is a function that draws a legend
+// thumbnail, implementing the Thumbnailer interface.
+// It allows arbitrary icons to be used in a legend.
+type ThumbnailFunc func(da *DrawArea)
+
+// Thumbnail implements the Thumbnailer interface.
+func (f ThumbnailFunc) Thumbnail(da *DrawArea) {
+	f(da)
+}
+
+// ImageThumbnail returns a Thumbnailer that draws an
+// image as a legend thumbnail.  The image is scaled
+// to fit the thumbnail, keeping its aspect ratio, and
+// is centered in it.  Each pixel is drawn as a filled
+// rectangle so that the image can be drawn to any
+// canvas, which suits small icons.
+func ImageThumbnail(img image.Image) Thumbnailer {
+	return ThumbnailFunc(func(da *DrawArea) {
+		drawImage(da, img)
+	})
+}
+
+// drawImage draws an image scaled to fit and centered
+// in the draw area.
+func drawImage(da *DrawArea, img image.Image) {
+	b := img.Bounds()
+	if b.Empty() {
+		return
+	}
+	px := da.Size.X / vg.Length(b.Dx())
+	if h := da.Size.Y / vg.Length(b.Dy()); h < px {
+		px = h
+	}
+	size := Point{px * vg.Length(b.Dx()), px * vg.Length(b.Dy())}
+	r := Rect{Min: da.Center().minus(size.scale(0.5)), Size: size}
+	drawPixels(da, img, r, r)
+}
+
+// drawPixels draws an image stretched to fill the
+// rectangle r, drawing only the parts of the image
+// that are within the rectangle clip.  Runs of
+// identical pixels in a row are drawn as a single
+// rectangle, and fully transparent pixels are not
+// drawn.
+func drawPixels(da *DrawArea, img image.Image, r, clip Rect) {
+	b := img.Bounds()
+	if b.Empty() {
+		return
+	}
+	pw := r.Size.X / vg.Length(b.Dx())
+	ph := r.Size.Y / vg.Length(b.Dy())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		top := r.Max().Y - ph*vg.Length(y-b.Min.Y)
+		if top-ph >= clip.Max().Y || top <= clip.Min.Y {
+			continue
+		}
+		for x := b.Min.X; x < b.Max.X; {
+			c := img.At(x, y)
+			end := x + 1
+			for end < b.Max.X && sameColor(img.At(end, y), c) {
+				end++
+			}
+			run := Rect{
+				Min:  Point{r.Min.X + pw*vg.Length(x-b.Min.X), top - ph},
+				Size: Point{pw * vg.Length(end-x), ph},
+			}
+			if _, _, _, a := c.RGBA(); a != 0 {
+				if run, ok := intersect(run, clip); ok {
+					da.SetColor(c)
+					da.Fill(rectPath(run))
+				}
+			}
+			x = end
+		}
+	}
+}
+
+// intersect returns the intersection of two
+// rectangles, and false if they do not overlap.
+func intersect(a, b Rect) (Rect, bool) {
+	min := Point{
+		X: vg.Length(math.Max(float64(a.Min.X), float64(b.Min.X))),
+		Y: vg.Length(math.Max(float64(a.Min.Y), float64(b.Min.Y))),
+	}
+	max := Point{
+		X: vg.Length(math.Min(float64(a.Max().X), float64(b.Max().X))),
+		Y: vg.Length(math.Min(float64(a.Max().Y), float64(b.Max().Y))),
+	}
+	if max.X <= min.X || max.Y <= min.Y {
+		return Rect{}, false
+	}
+	return Rect{Min: min, Size: max.minus(min)}, true
+}
+
+// sameColor returns true if the colors are
+// equal after conversion to RGBA.
+func sameColor(a, b color.Color) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
+
 // makeLegend returns a legend with the default
 // parameter settings.
 func makeLegend() (Legend, error) {
@@ -80,24 +201,42 @@ func makeLegend() (Legend, error) {
 }
 
 // draw draws the legend to the given DrawArea.
-func (l *Legend) draw(da DrawArea) {
-	iconx := da.Min.X
+// Each thumbnail is drawn in a group with the class
+// returned for it by class, if the class is not empty.
+//
+// The legend's box is computed first so that its
+// background can be filled before the entries are
+// drawn, and its border stroked after them.
+func (l *Legend) draw(da DrawArea, class func(Thumbnailer) string) {
+	if len(l.entries) == 0 {
+		return
+	}
+	enth := l.entryHeight()
+	size := l.size()
+	box := Rect{Min: Point{da.Min.X, da.Min.Y}, Size: size}
+	if !l.Left {
+		box.Min.X = da.Max().X - size.X
+	}
+	if l.Top {
+		box.Min.Y = da.Max().Y - size.Y
+	}
+	box.Min.X += l.XOffs
+	box.Min.Y += l.YOffs
+
+	if l.BackgroundColor != nil {
+		da.SetColor(l.BackgroundColor)
+		da.Fill(rectPath(box))
+	}
+
+	iconx := box.Min.X + l.BoxPadding
 	textx := iconx + l.ThumbnailWidth + l.TextStyle.Width(" ")
 	xalign := 0.0
 	if !l.Left {
-		iconx = da.Max().X - l.ThumbnailWidth
+		iconx = box.Max().X - l.BoxPadding - l.ThumbnailWidth
 		textx = iconx - l.TextStyle.Width(" ")
 		xalign = -1
 	}
-	textx += l.XOffs
-	iconx += l.XOffs
-
-	enth := l.entryHeight()
-	y := da.Max().Y - enth
-	if !l.Top {
-		y = da.Min.Y + (enth+l.Padding)*(vg.Length(len(l.entries))-1)
-	}
-	y += l.YOffs
+	y := box.Max().Y - l.BoxPadding - enth
 
 	icon := &DrawArea{
 		Canvas: da.Canvas,
@@ -105,12 +244,49 @@ func (l *Legend) draw(da DrawArea) {
 	}
 	for _, e := range l.entries {
 		for _, t := range e.thumbs {
+			if c := class(t); c != "" {
+				end := beginClass(da.Canvas, c)
+				t.Thumbnail(icon)
+				end()
+				continue
+			}
 			t.Thumbnail(icon)
 		}
 		yoffs := (enth - l.TextStyle.Height(e.text)) / 2
 		da.FillText(l.TextStyle, textx, icon.Min.Y+yoffs, xalign, 0, e.text)
 		icon.Min.Y -= enth + l.Padding
 	}
+
+	if l.Border.Color != nil && l.Border.Width > 0 {
+		max := box.Max()
+		da.StrokeLines(l.Border, []Point{
+			box.Min, {max.X, box.Min.Y}, max, {box.Min.X, max.Y}, box.Min,
+		})
+	}
+}
+
+// size returns the size of the legend's box, which
+// is zero if the legend has no entries.
+func (l *Legend) size() Point {
+	if len(l.entries) == 0 {
+		return Point{}
+	}
+	n := vg.Length(len(l.entries))
+	return Point{
+		X: l.ThumbnailWidth + l.TextStyle.Width(" ") + l.entryWidth() + 2*l.BoxPadding,
+		Y: n*l.entryHeight() + (n-1)*l.Padding + 2*l.BoxPadding,
+	}
+}
+
+// entryWidth returns the width of the widest legend
+// entry text.
+func (l *Legend) entryWidth() (width vg.Length) {
+	for _, e := range l.entries {
+		if w := l.TextStyle.Width(e.text); w > width {
+			width = w
+		}
+	}
+	return
 }
 
 // entryHeight returns the height of the tallest legend
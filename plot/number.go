@@ -0,0 +1,102 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// A NumberFormat gives the separators with which
+// numbers are written in labels, such as the comma
+// decimal separator and the point thousands separator
+// of German, in which 1234.5 is written 1.234,5.
+type NumberFormat struct {
+	// Decimal is the decimal separator.  If
+	// Decimal is empty then "." is used.
+	Decimal string
+
+	// Thousands separates each group of three
+	// digits of the integer part of a number with
+	// more than three digits before the decimal
+	// separator.  If Thousands is empty then the
+	// digits are not grouped.
+	Thousands string
+}
+
+// Numbers is the format of the numbers in the labels
+// that are made by this package and by the plotter
+// package, such as the tick labels of DefaultTicks and
+// LogTicks and the value labels of bar charts.  The
+// zero value writes numbers as strconv does.
+var Numbers NumberFormat
+
+// FormatFloat returns the number v written as by
+// strconv.FormatFloat with the given format, precision
+// and bit size, and then localized by Numbers.  It is
+// intended for the labels of custom tick markers and
+// plotters, so that they follow Numbers.
+func FormatFloat(v float64, format byte, prec, bitSize int) string {
+	return Numbers.Localize(strconv.FormatFloat(v, format, prec, bitSize))
+}
+
+// Localize returns a number, written with a "." decimal
+// separator and no thousands separators as by strconv,
+// rewritten with the separators of the format.  An
+// exponent, as in 1.5e+06, is not changed.
+func (f NumberFormat) Localize(s string) string {
+	if f.Decimal == "" && f.Thousands == "" {
+		return s
+	}
+	var sign, exp string
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], s[1:]
+	}
+	if i := strings.IndexAny(s, "eEpP"); i >= 0 {
+		s, exp = s[:i], s[i:]
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i+1:]
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(sign)
+	if f.Thousands != "" && len(intPart) > 3 && isDigits(intPart) {
+		head := len(intPart) % 3
+		if head == 0 {
+			head = 3
+		}
+		buf.WriteString(intPart[:head])
+		for i := head; i < len(intPart); i += 3 {
+			buf.WriteString(f.Thousands)
+			buf.WriteString(intPart[i : i+3])
+		}
+	} else {
+		buf.WriteString(intPart)
+	}
+	if frac != "" || strings.Contains(s, ".") {
+		if f.Decimal == "" {
+			buf.WriteString(".")
+		} else {
+			buf.WriteString(f.Decimal)
+		}
+		buf.WriteString(frac)
+	}
+	buf.WriteString(exp)
+	return buf.String()
+}
+
+// isDigits returns true if s is made up only of
+// decimal digits.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -22,6 +22,9 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/gonum/plot/vg"
@@ -41,7 +44,9 @@ type Plot struct {
 	Title struct {
 		// Text is the text of the plot title.  If
 		// Text is the empty string then the plot
-		// will not have a title.
+		// will not have a title.  The text may
+		// contain newlines, in which case each
+		// line is aligned separately.
 		Text string
 
 		// Padding is the amount of padding
@@ -49,6 +54,27 @@ type Plot struct {
 		// the top of the plot.
 		Padding vg.Length
 
+		// XAlign is the horizontal alignment of
+		// the title within the plot.  The default
+		// is XCenter.
+		XAlign XAlignment
+
+		// Subtitle is drawn below the title,
+		// in a smaller font by default.
+		Subtitle struct {
+			// Text is the text of the subtitle.  If
+			// Text is the empty string then the plot
+			// will not have a subtitle.
+			Text string
+
+			// XAlign is the horizontal alignment
+			// of the subtitle within the plot.
+			// The default is XCenter.
+			XAlign XAlignment
+
+			TextStyle
+		}
+
 		TextStyle
 	}
 
@@ -56,16 +82,86 @@ type Plot struct {
 	// The default is White.
 	BackgroundColor color.Color
 
+	// Background, if non-nil, is drawn over the
+	// BackgroundColor before anything else is
+	// drawn, for example to fill the plot with an
+	// ImageBackground or a LinearGradient.
+	Background Background
+
+	// Frame, if non-nil, is drawn around the data
+	// area, and the plotters are clipped to it.
+	Frame *Frame
+
+	// Margins, where they are non-zero, fix the
+	// space between the edges of the plot and its
+	// data area instead of the space needed by the
+	// title and the axes, for example so that the
+	// axes of plots drawn side by side line up.
+	Margins Margins
+
+	// ColorCycle, if non-empty, is a palette of
+	// colors that are given in turn to the plotters
+	// added to the plot that implement AutoColorer
+	// and whose color has not been set.  The colors
+	// are cycled when the palette is exhausted.
+	ColorCycle []color.Color
+
+	// nextColor is the index in ColorCycle of the
+	// color given to the next plotter.
+	nextColor int
+
 	// X and Y are the horizontal and vertical axes
 	// of the plot respectively.
 	X, Y Axis
 
+	// Y2 is the secondary vertical axis.  It is
+	// drawn on the right side of the plot if any
+	// of the plotters is bound to it; see
+	// AxisBinder.
+	Y2 Axis
+
 	// Legend is the plot's legend.
 	Legend Legend
 
 	// plotters are drawn by calling their Plot method
 	// after the axes are drawn.
 	plotters []Plotter
+
+	// layers is the layer of each plotter.
+	layers []int
+}
+
+// Margins are the widths of the space between each
+// edge of a plot and its data area, in which the title
+// and the axes are drawn.  A zero margin is computed
+// from the space that the title or axis on its side
+// needs.  A fixed margin that is narrower than that
+// space leaves the title or axis overlapping the edge
+// of the plot or its data area.
+type Margins struct {
+	Top, Right, Bottom, Left vg.Length
+}
+
+// margins returns the widths of the margins of the
+// plot, using the fixed Margins where they are set.
+// The ranges of the axes must be sanitized.
+func (p *Plot) margins() Margins {
+	m := p.Margins
+	if m.Top == 0 {
+		m.Top = p.titleHeight()
+	}
+	if m.Right == 0 {
+		m.Right = p.y2Width()
+	}
+	if m.Bottom == 0 {
+		x := horizontalAxis{p.X}
+		m.Bottom = x.size()
+	}
+	if m.Left == 0 {
+		y := verticalAxis{p.Y}
+		m.Left = y.size()
+	}
+	return m
 }
 
 // Plotter is an interface that wraps the Plot method.
@@ -73,6 +169,13 @@ type Plot struct {
 // found in the github.com/gonum/plot/plotter
 // package, documented here:
 // http://godoc.org/github.com/gonum/plot/plotter
+//
+// The DrawArea passed to Plot is the data area of the
+// plot.  The canvas is not clipped to this area, so
+// Plotters should clip their lines and polygons using
+// the DrawArea's ClipLines and ClipPolygon methods
+// in order to avoid drawing over the axes when the
+// data extends beyond the axis ranges.
 type Plotter interface {
 	// Plot draws the data to a DrawArea.
 	Plot(DrawArea, *Plot)
@@ -84,6 +187,41 @@ type DataRanger interface {
 	DataRange() (xmin, xmax, ymin, ymax float64)
 }
 
+// XAlignment is the horizontal alignment of text
+// relative to the area in which it is drawn.  The
+// zero value is XCenter.
+type XAlignment int
+
+const (
+	// XCenter centers text horizontally.
+	XCenter XAlignment = iota
+
+	// XLeft aligns text with the left edge.
+	XLeft
+
+	// XRight aligns text with the right edge.
+	XRight
+)
+
+// offset returns the offset of text with this
+// alignment as a fraction of its width, as
+// accepted by DrawArea.FillText.
+func (a XAlignment) offset() float64 {
+	switch a {
+	case XLeft:
+		return 0
+	case XRight:
+		return -1
+	}
+	return -0.5
+}
+
+// x returns the x location at which to draw text
+// with this alignment in the given draw area.
+func (a XAlignment) x(da DrawArea) vg.Length {
+	return da.Min.X - da.Size.X*vg.Length(a.offset())
+}
+
 // New returns a new plot with some reasonable
 // default settings.
 func New() (*Plot, error) {
@@ -91,6 +229,10 @@ func New() (*Plot, error) {
 	if err != nil {
 		return nil, err
 	}
+	subtitleFont, err := vg.MakeFont(DefaultFont, 10)
+	if err != nil {
+		return nil, err
+	}
 	x, err := makeAxis()
 	if err != nil {
 		return nil, err
@@ -99,6 +241,10 @@ func New() (*Plot, error) {
 	if err != nil {
 		return nil, err
 	}
+	y2, err := makeAxis()
+	if err != nil {
+		return nil, err
+	}
 	legend, err := makeLegend()
 	if err != nil {
 		return nil, err
@@ -107,12 +253,17 @@ func New() (*Plot, error) {
 		BackgroundColor: color.White,
 		X:               x,
 		Y:               y,
+		Y2:              y2,
 		Legend:          legend,
 	}
 	p.Title.TextStyle = TextStyle{
 		Color: color.Black,
 		Font:  titleFont,
 	}
+	p.Title.Subtitle.TextStyle = TextStyle{
+		Color: color.Black,
+		Font:  subtitleFont,
+	}
 	return p, nil
 }
 
@@ -121,73 +272,522 @@ func New() (*Plot, error) {
 // If the plotters implements DataRanger then the
 // minimum and maximum values of the X and Y
 // axes are changed if necessary to fit the range of
-// the data.
+// the data.  If the plot has a ColorCycle then
+// plotters that implement AutoColorer are given
+// the next color of the cycle.
 //
 // When drawing the plot, Plotters are drawn in the
-// order in which they were added to the plot.
+// order in which they were added to the plot.  Plotters
+// added with Add are in layer 0; see AddAt.
 func (p *Plot) Add(ps ...Plotter) {
+	p.AddAt(0, ps...)
+}
+
+// AddAt is like Add, but it adds the Plotters to the
+// given layer of the plot.
+//
+// When drawing the plot, layers are drawn in
+// increasing order, so Plotters in a lower layer
+// are drawn beneath those in higher layers regardless
+// of the order in which they were added.  Plotters
+// within the same layer are drawn in the order in
+// which they were added.  For example, a grid added
+// at layer -1 is drawn behind data added with Add.
+func (p *Plot) AddAt(layer int, ps ...Plotter) {
 	for _, d := range ps {
+		if a, ok := d.(AutoColorer); ok && len(p.ColorCycle) > 0 {
+			if a.AutoColor(p.ColorCycle[p.nextColor%len(p.ColorCycle)]) {
+				p.nextColor++
+			}
+		}
 		if x, ok := d.(DataRanger); ok {
 			xmin, xmax, ymin, ymax := x.DataRange()
+			y := &p.Y
+			if yAxisOf(d) == SecondaryAxis {
+				y = &p.Y2
+			}
 			p.X.Min = math.Min(p.X.Min, xmin)
 			p.X.Max = math.Max(p.X.Max, xmax)
-			p.Y.Min = math.Min(p.Y.Min, ymin)
-			p.Y.Max = math.Max(p.Y.Max, ymax)
+			y.Min = math.Min(y.Min, ymin)
+			y.Max = math.Max(y.Max, ymax)
 		}
 	}
 
 	p.plotters = append(p.plotters, ps...)
+	for range ps {
+		p.layers = append(p.layers, layer)
+	}
+}
+
+// AutoColorer wraps the AutoColor method, which
+// is used to give plotters colors from the
+// ColorCycle of the plot to which they are added.
+type AutoColorer interface {
+	// AutoColor sets the color of the plotter to
+	// c, unless its color has been set explicitly,
+	// and returns true if the color was used.
+	AutoColor(c color.Color) bool
+}
+
+// An AxisID identifies one of the vertical axes
+// of a plot.
+type AxisID int
+
+const (
+	// PrimaryAxis is the Y axis, on the left
+	// side of the plot.
+	PrimaryAxis AxisID = iota
+
+	// SecondaryAxis is the Y2 axis, on the
+	// right side of the plot.
+	SecondaryAxis
+)
+
+// AxisBinder wraps the YAxis method, which is used
+// by plotters that are drawn through the secondary
+// Y axis of a plot.  Plotters that do not implement
+// AxisBinder use the primary axis.
+//
+// The data range of a plotter bound to the secondary
+// axis extends the Y2 axis instead of the Y axis, and
+// its Plot and GlyphBoxes methods are called with a
+// view of the plot whose Y axis is the Y2 axis, so
+// the plotter need not know which axis it is drawn
+// through.
+type AxisBinder interface {
+	// YAxis returns the vertical axis through
+	// which the plotter is drawn.
+	YAxis() AxisID
+}
+
+// yAxisOf returns the vertical axis to which
+// a plotter is bound.
+func yAxisOf(d Plotter) AxisID {
+	if b, ok := d.(AxisBinder); ok {
+		return b.YAxis()
+	}
+	return PrimaryAxis
+}
+
+// bound returns the plot as seen by the given
+// plotter: the plot itself, or a copy of it whose
+// Y axis is the Y2 axis if the plotter is bound
+// to the secondary axis.
+func (p *Plot) bound(d Plotter) *Plot {
+	if yAxisOf(d) != SecondaryAxis {
+		return p
+	}
+	c := *p
+	c.Y = p.Y2
+	return &c
+}
+
+// hasY2 returns true if any of the plotters
+// is bound to the secondary axis.
+func (p *Plot) hasY2() bool {
+	for _, d := range p.plotters {
+		if yAxisOf(d) == SecondaryAxis {
+			return true
+		}
+	}
+	return false
+}
+
+// y2Width returns the width of the Y2 axis,
+// or zero if it is not drawn.  Its range must
+// have been sanitized.
+func (p *Plot) y2Width() vg.Length {
+	if !p.hasY2() {
+		return 0
+	}
+	y2 := verticalAxis{p.Y2}
+	return y2.size()
+}
+
+// Cloner wraps the Clone method, which is used by
+// Plot.Clone to copy plotters.
+type Cloner interface {
+	// Clone returns a copy of the plotter that
+	// can be changed without changing the
+	// original.
+	Clone() Plotter
+}
+
+// Clone returns a copy of the plot that can be changed,
+// for example to draw it with a different title or axis
+// range, without changing the original.  The title,
+// axes, frame and legend are copied.  Plotters that implement
+// Cloner are copied with their Clone method, and the
+// legend entries of the copy refer to the copies.  Other
+// plotters are shared by the two plots.
+func (p *Plot) Clone() *Plot {
+	c := *p
+	c.ColorCycle = append([]color.Color(nil), p.ColorCycle...)
+	c.X = p.X.clone()
+	c.Y = p.Y.clone()
+	c.Y2 = p.Y2.clone()
+	if p.Frame != nil {
+		f := *p.Frame
+		f.Dashes = append([]vg.Length(nil), p.Frame.Dashes...)
+		c.Frame = &f
+	}
+	c.layers = append([]int(nil), p.layers...)
+
+	c.plotters = make([]Plotter, len(p.plotters))
+	for i, d := range p.plotters {
+		c.plotters[i] = d
+		if cl, ok := d.(Cloner); ok {
+			c.plotters[i] = cl.Clone()
+		}
+	}
+
+	c.Legend.Border.Dashes = append([]vg.Length(nil), p.Legend.Border.Dashes...)
+	c.Legend.entries = make([]legendEntry, len(p.Legend.entries))
+	for i, e := range p.Legend.entries {
+		thumbs := make([]Thumbnailer, len(e.thumbs))
+		for j, t := range e.thumbs {
+			thumbs[j] = t
+			for k, d := range p.plotters {
+				if samePlotter(t, d) {
+					if th, ok := c.plotters[k].(Thumbnailer); ok {
+						thumbs[j] = th
+					}
+					break
+				}
+			}
+		}
+		c.Legend.entries[i] = legendEntry{text: e.text, thumbs: thumbs}
+	}
+	return &c
+}
+
+// samePlotter returns true if the thumbnailer t is
+// the plotter d.  Values of types that cannot be
+// compared, such as ThumbnailFunc, are never the
+// same as a plotter.
+func samePlotter(t Thumbnailer, d Plotter) bool {
+	tt, dt := reflect.TypeOf(t), reflect.TypeOf(d)
+	return tt != nil && tt == dt && tt.Comparable() && interface{}(t) == interface{}(d)
+}
+
+// drawOrder returns the plotters in the order
+// in which they are drawn.
+func (p *Plot) drawOrder() []Plotter {
+	order := make([]Plotter, len(p.plotters))
+	for i, j := range p.drawIndices() {
+		order[i] = p.plotters[j]
+	}
+	return order
+}
+
+// drawIndices returns the indices of the plotters
+// in the order in which they are drawn.
+func (p *Plot) drawIndices() []int {
+	order := byLayer{
+		indices: make([]int, len(p.plotters)),
+		layers:  make([]int, len(p.layers)),
+	}
+	for i := range order.indices {
+		order.indices[i] = i
+	}
+	copy(order.layers, p.layers)
+	sort.Stable(order)
+	return order.indices
+}
+
+// byLayer sorts plotter indices by their layer.
+type byLayer struct {
+	indices []int
+	layers  []int
+}
+
+func (b byLayer) Len() int           { return len(b.indices) }
+func (b byLayer) Less(i, j int) bool { return b.layers[i] < b.layers[j] }
+func (b byLayer) Swap(i, j int) {
+	b.indices[i], b.indices[j] = b.indices[j], b.indices[i]
+	b.layers[i], b.layers[j] = b.layers[j], b.layers[i]
+}
+
+// beginClass starts a group of drawing operations
+// with the given class if the canvas is a vg.Annotator,
+// and returns a function that ends the group.  An
+// Annotator that does not write classes, such as an
+// SVG canvas without Classes set, writes nothing for
+// the group.
+func beginClass(c vg.Canvas, class string) (end func()) {
+	a, ok := c.(vg.Annotator)
+	if !ok {
+		return func() {}
+	}
+	a.BeginAnnotation(vg.Annotation{Class: class})
+	return a.EndAnnotation
+}
+
+// seriesClass returns the class of the drawing of
+// the ith plotter.
+func seriesClass(i int) string {
+	return "series series-" + strconv.Itoa(i)
+}
+
+// thumbnailClass returns the class of the drawing of
+// a legend thumbnail, which is the class of the
+// plotter that it is, if any.
+func (p *Plot) thumbnailClass(t Thumbnailer) string {
+	for i, d := range p.plotters {
+		if samePlotter(t, d) {
+			return seriesClass(i)
+		}
+	}
+	return ""
 }
 
 // Draw draws a plot to a DrawArea.
 //
-// Plotters are drawn in the order in which they were
-// added to the plot.  Plotters that  implement the
+// Plotters are drawn in order of their layer and then
+// in the order in which they were added to the plot.
+// Plotters that  implement the
 // GlyphBoxer interface will have their GlyphBoxes
 // taken into account when padding the plot so that
 // none of their glyphs are clipped.
+//
+// On canvases that implement vg.Annotator, such as
+// the SVG canvas, each element of the plot is drawn in
+// a group with a class naming its role, for theming by
+// a stylesheet: "title"; "axis x-axis", "axis y-axis"
+// and "axis y2-axis"; "frame"; "legend"; and "series
+// series-i" for the ith plotter added to the plot,
+// starting at zero, and for its legend thumbnails.
 func (p *Plot) Draw(da DrawArea) {
+	p.draw(da, false)
+}
+
+// DrawCropped draws the plot to a DrawArea, as in Draw,
+// showing only the given ranges of the X and primary Y
+// axes, such as to zoom in on part of the data.  The
+// ranges of the axes are restored after the plot is
+// drawn, so the plot itself is unchanged.  The range
+// of the secondary Y axis is not changed.
+//
+// Most plotters clip what they draw to the data area.
+// On canvases that implement vg.Clipper, as all of the
+// canvases in the vg packages do, the plotters are also
+// clipped by the canvas, so that glyphs and text that
+// straddle the edge of the data area are cut off at it.
+func (p *Plot) DrawCropped(da DrawArea, xmin, xmax, ymin, ymax float64) {
+	x, y := p.X, p.Y
+	defer func() {
+		p.X.Min, p.X.Max = x.Min, x.Max
+		p.Y.Min, p.Y.Max = y.Min, y.Max
+	}()
+	p.X.Min, p.X.Max = xmin, xmax
+	p.Y.Min, p.Y.Max = ymin, ymax
+	p.draw(da, true)
+}
+
+// draw draws the plot, clipping the plotters to the
+// data area on vg.Clipper canvases if clip is true.
+func (p *Plot) draw(da DrawArea, clip bool) {
 	if p.BackgroundColor != nil {
 		da.SetColor(p.BackgroundColor)
 		da.Fill(rectPath(da.Rect))
 	}
-	if p.Title.Text != "" {
-		da.FillText(p.Title.TextStyle, da.Center().X, da.Max().Y, -0.5, -1, p.Title.Text)
-		da.Size.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
-		da.Size.Y -= p.Title.Padding
+	if p.Background != nil {
+		p.Background.DrawBackground(&da)
 	}
+	end := beginClass(da.Canvas, "title")
+	p.drawTitle(da)
+	end()
 
 	p.X.sanitizeRange()
 	x := horizontalAxis{p.X}
 	p.Y.sanitizeRange()
 	y := verticalAxis{p.Y}
+	p.Y2.sanitizeRange()
+	y2 := verticalAxis{p.Y2}
 
-	ywidth := y.size()
-	x.draw(padX(p, da.crop(ywidth, 0, 0, 0)))
+	// Each axis is drawn against the edge of the
+	// data area, so a fixed margin that is wider
+	// than the axis leaves space outside of it.
+	m := p.margins()
+	da.Size.Y -= m.Top
 	xheight := x.size()
-	y.draw(padY(p, da.crop(0, xheight, 0, 0)))
+	end = beginClass(da.Canvas, "axis x-axis")
+	x.draw(padX(p, da.crop(m.Left, m.Bottom-xheight, -m.Right, 0)))
+	end()
+	end = beginClass(da.Canvas, "axis y-axis")
+	y.draw(padY(p, da.crop(m.Left-y.size(), m.Bottom, 0, 0)))
+	end()
+	if p.hasY2() {
+		end = beginClass(da.Canvas, "axis y2-axis")
+		y2.drawRight(padY(p, da.crop(0, m.Bottom, -(m.Right-p.y2Width()), 0)))
+		end()
+	}
+
+	frameDa := da.crop(m.Left, m.Bottom, -m.Right, 0)
+	clipped := p.Frame != nil && p.Frame.drawBackground(frameDa)
+	dataDa := padY(p, padX(p, frameDa))
+	cl, cropped := da.Canvas.(vg.Clipper)
+	cropped = cropped && clip
+	if cropped {
+		da.Push()
+		cl.Clip(rectPath(dataDa.Rect))
+	}
+	for _, i := range p.drawIndices() {
+		data := p.plotters[i]
+		end = beginClass(da.Canvas, seriesClass(i))
+		data.Plot(dataDa, p.bound(data))
+		end()
+	}
+	if cropped {
+		da.Pop()
+	}
+	if clipped {
+		da.Pop()
+	}
+	if p.Frame != nil {
+		end = beginClass(da.Canvas, "frame")
+		p.Frame.drawBorder(frameDa)
+		end()
+	}
+	if p.X.Tick.Mirror {
+		end = beginClass(da.Canvas, "axis x-axis")
+		x.drawMirror(padX(p, frameDa))
+		end()
+	}
+	if p.Y.Tick.Mirror && !p.hasY2() {
+		end = beginClass(da.Canvas, "axis y-axis")
+		y.drawMirror(padY(p, frameDa))
+		end()
+	}
+
+	end = beginClass(da.Canvas, "legend")
+	p.Legend.draw(frameDa, p.thumbnailClass)
+	end()
+}
 
-	dataDa := padY(p, padX(p, da.crop(ywidth, xheight, 0, 0)))
-	for _, data := range p.plotters {
-		data.Plot(dataDa, p)
+// drawTitle draws the title and the subtitle
+// below it at the top of the draw area.
+func (p *Plot) drawTitle(da DrawArea) {
+	t, sub := &p.Title, &p.Title.Subtitle
+	top := da.Max().Y
+	if t.Text != "" {
+		da.FillText(t.TextStyle, t.XAlign.x(da), top, t.XAlign.offset(), -1, t.Text)
+		// Leave room for the descenders of the
+		// last line of the title, whose Descent
+		// is negative.
+		top -= t.Height(t.Text)
+		top += t.Font.Extents().Descent
+	}
+	if sub.Text != "" {
+		da.FillText(sub.TextStyle, sub.XAlign.x(da), top, sub.XAlign.offset(), -1, sub.Text)
 	}
+}
 
-	p.Legend.draw(da.crop(ywidth, 0, 0, 0).crop(0, xheight, 0, 0))
+// titleHeight returns the height of the space
+// used by the title and subtitle, including the
+// padding between them and the plot.
+func (p *Plot) titleHeight() vg.Length {
+	t, sub := &p.Title, &p.Title.Subtitle
+	if t.Text == "" && sub.Text == "" {
+		return 0
+	}
+	// Each includes the descenders of its last
+	// line, as drawTitle leaves room for them.
+	var h vg.Length
+	if t.Text != "" {
+		h += t.Height(t.Text) - t.Font.Extents().Descent
+	}
+	if sub.Text != "" {
+		h += sub.Height(sub.Text) - sub.Font.Extents().Descent
+	}
+	return h + t.Padding
+}
+
+// MinSize returns a lower bound on the size of a
+// canvas on which the plot can be drawn without its
+// text overlapping.  The width is the width of the
+// Y axes plus the widest of the X axis tick labels
+// placed side by side, the X axis label, the legend
+// and the glyphs, or the width of the title if that
+// is wider.  The height is the height of the title
+// and the X axis plus the tallest of the tick labels
+// of either Y axis stacked one above another, its
+// label, the legend and the glyphs.
+//
+// Like Draw, MinSize sanitizes the ranges of the
+// axes, so it should be called after all of the
+// plotters have been added to the plot.
+func (p *Plot) MinSize() (w, h vg.Length) {
+	p.X.sanitizeRange()
+	x := horizontalAxis{p.X}
+	p.Y.sanitizeRange()
+	y := verticalAxis{p.Y}
+	p.Y2.sanitizeRange()
+
+	data := Point{X: x.minLength(), Y: y.minLength()}
+	if p.hasY2() {
+		y2 := verticalAxis{p.Y2}
+		data.Y = vg.Length(math.Max(float64(data.Y), float64(y2.minLength())))
+	}
+	for _, b := range p.GlyphBoxes(p) {
+		data.X = vg.Length(math.Max(float64(data.X), float64(b.Size.X)))
+		data.Y = vg.Length(math.Max(float64(data.Y), float64(b.Size.Y)))
+	}
+	leg := p.Legend.size()
+	if p.Legend.XOffs < 0 {
+		leg.X -= p.Legend.XOffs
+	} else {
+		leg.X += p.Legend.XOffs
+	}
+	if p.Legend.YOffs < 0 {
+		leg.Y -= p.Legend.YOffs
+	} else {
+		leg.Y += p.Legend.YOffs
+	}
+
+	m := p.margins()
+	w = m.Left + m.Right + vg.Length(math.Max(float64(data.X), float64(leg.X)))
+	h = m.Bottom + vg.Length(math.Max(float64(data.Y), float64(leg.Y)))
+	h += m.Top
+	for _, t := range []struct {
+		text string
+		sty  TextStyle
+	}{
+		{p.Title.Text, p.Title.TextStyle},
+		{p.Title.Subtitle.Text, p.Title.Subtitle.TextStyle},
+	} {
+		w = vg.Length(math.Max(float64(w), float64(t.sty.Width(t.text))))
+	}
+	return w, h
 }
 
 // DataDrawArea returns a new DrawArea that
 // is the subset of the given draw area into which
 // the plot data will be drawn.
 func (p *Plot) DataDrawArea(da DrawArea) DrawArea {
-	if p.Title.Text != "" {
-		da.Size.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
-		da.Size.Y -= p.Title.Padding
-	}
 	p.X.sanitizeRange()
-	x := horizontalAxis{p.X}
 	p.Y.sanitizeRange()
-	y := verticalAxis{p.Y}
-	return padY(p, padX(p, da.crop(y.size(), x.size(), 0, 0)))
+	p.Y2.sanitizeRange()
+	m := p.margins()
+	da.Size.Y -= m.Top
+	return padY(p, padX(p, da.crop(m.Left, m.Bottom, -m.Right, 0)))
+}
+
+// DataAt returns the data coordinates of a point in
+// the given draw area, which is the area to which the
+// plot is, or would be, drawn.  It is the inverse of
+// the transforms used by the plotters, and honors the
+// axes' scales and breaks.  If the point is outside of
+// the data area, the corresponding coordinate is NaN.
+//
+// DataAt can be used to find the data under a pointer,
+// for example to show the value under the mouse in an
+// interactive viewer.
+func (p *Plot) DataAt(da DrawArea, pt Point) (x, y float64) {
+	data := p.DataDrawArea(da)
+	x = p.X.Unnorm(float64((pt.X - data.Min.X) / data.Size.X))
+	y = p.Y.Unnorm(float64((pt.Y - data.Min.Y) / data.Size.Y))
+	return x, y
 }
 
 // DrawGlyphBoxes draws red outlines around the plot's
@@ -205,19 +805,12 @@ func (p *Plot) DrawGlyphBoxes(da *DrawArea) {
 // so that glyphs will no be clipped.
 func padX(p *Plot, da DrawArea) DrawArea {
 	glyphs := p.GlyphBoxes(p)
-	l := leftMost(&da, glyphs)
+	left := xSpans(glyphs)
 	xAxis := horizontalAxis{p.X}
-	glyphs = append(glyphs, xAxis.GlyphBoxes(p)...)
-	r := rightMost(&da, glyphs)
-
-	minx := da.Min.X - l.Min.X
-	maxx := da.Max().X - (r.Min.X + r.Size.X)
-	lx := vg.Length(l.X)
-	rx := vg.Length(r.X)
-	n := (lx*maxx - rx*minx) / (lx - rx)
-	m := ((lx-1)*maxx - rx*minx + minx) / (lx - rx)
+	right := append(left, xSpans(xAxis.GlyphBoxes(p))...)
+	n, m := fitSpans(da.Min.X, da.Max().X, left, right)
 	return DrawArea{
-		Canvas: vg.Canvas(da),
+		Canvas: da.Canvas,
 		Rect: Rect{
 			Min:  Point{X: n, Y: da.Min.Y},
 			Size: Point{X: m - n, Y: da.Size.Y},
@@ -225,55 +818,20 @@ func padX(p *Plot, da DrawArea) DrawArea {
 	}
 }
 
-// rightMost returns the right-most GlyphBox.
-func rightMost(da *DrawArea, boxes []GlyphBox) GlyphBox {
-	maxx := da.Max().X
-	r := GlyphBox{X: 1}
-	for _, b := range boxes {
-		if b.Size.X <= 0 {
-			continue
-		}
-		if x := da.X(b.X) + b.Min.X + b.Size.X; x > maxx && b.X <= 1 {
-			maxx = x
-			r = b
-		}
-	}
-	return r
-}
-
-// leftMost returns the left-most GlyphBox.
-func leftMost(da *DrawArea, boxes []GlyphBox) GlyphBox {
-	minx := da.Min.X
-	l := GlyphBox{}
-	for _, b := range boxes {
-		if b.Size.X <= 0 {
-			continue
-		}
-		if x := da.X(b.X) + b.Min.X; x < minx && b.X >= 0 {
-			minx = x
-			l = b
-		}
-	}
-	return l
-}
-
 // padY returns a DrawArea that is padded vertically
 // so that glyphs will no be clipped.
 func padY(p *Plot, da DrawArea) DrawArea {
 	glyphs := p.GlyphBoxes(p)
-	b := bottomMost(&da, glyphs)
+	bottom := ySpans(glyphs)
 	yAxis := verticalAxis{p.Y}
-	glyphs = append(glyphs, yAxis.GlyphBoxes(p)...)
-	t := topMost(&da, glyphs)
-
-	miny := da.Min.Y - b.Min.Y
-	maxy := da.Max().Y - (t.Min.Y + t.Size.Y)
-	by := vg.Length(b.Y)
-	ty := vg.Length(t.Y)
-	n := (by*maxy - ty*miny) / (by - ty)
-	m := ((by-1)*maxy - ty*miny + miny) / (by - ty)
+	top := append(bottom, ySpans(yAxis.GlyphBoxes(p))...)
+	if p.hasY2() {
+		y2Axis := verticalAxis{p.Y2}
+		top = append(top, ySpans(y2Axis.GlyphBoxes(p))...)
+	}
+	n, m := fitSpans(da.Min.Y, da.Max().Y, bottom, top)
 	return DrawArea{
-		Canvas: vg.Canvas(da),
+		Canvas: da.Canvas,
 		Rect: Rect{
 			Min:  Point{Y: n, X: da.Min.X},
 			Size: Point{Y: m - n, X: da.Size.X},
@@ -281,36 +839,76 @@ func padY(p *Plot, da DrawArea) DrawArea {
 	}
 }
 
-// topMost returns the top-most GlyphBox.
-func topMost(da *DrawArea, boxes []GlyphBox) GlyphBox {
-	maxy := da.Max().Y
-	t := GlyphBox{Y: 1}
+// A span is the extent of a glyph along one
+// dimension: the glyph is at the normalized
+// location x and extends from lo to hi relative
+// to its location.
+type span struct {
+	x      float64
+	lo, hi vg.Length
+}
+
+// xSpans returns the horizontal spans of the
+// glyph boxes with a positive width.
+func xSpans(boxes []GlyphBox) []span {
+	var s []span
 	for _, b := range boxes {
-		if b.Size.Y <= 0 {
-			continue
-		}
-		if y := da.Y(b.Y) + b.Min.Y + b.Size.Y; y > maxy && b.Y <= 1 {
-			maxy = y
-			t = b
+		if b.Size.X > 0 {
+			s = append(s, span{b.X, b.Min.X, b.Min.X + b.Size.X})
 		}
 	}
-	return t
+	return s
 }
 
-// bottomMost returns the bottom-most GlyphBox.
-func bottomMost(da *DrawArea, boxes []GlyphBox) GlyphBox {
-	miny := da.Min.Y
-	l := GlyphBox{}
+// ySpans returns the vertical spans of the
+// glyph boxes with a positive height.
+func ySpans(boxes []GlyphBox) []span {
+	var s []span
 	for _, b := range boxes {
-		if b.Size.Y <= 0 {
-			continue
+		if b.Size.Y > 0 {
+			s = append(s, span{b.Y, b.Min.Y, b.Min.Y + b.Size.Y})
 		}
-		if y := da.Y(b.Y) + b.Min.Y; y < miny && b.Y >= 0 {
-			miny = y
-			l = b
+	}
+	return s
+}
+
+// fitSpans returns the largest range [n, m] within
+// [min, max] for which every span of lows with a
+// location in [0, 1] starts at or after min, and every
+// span of highs with a location in [0, 1] ends at or
+// before max, when the normalized locations are mapped
+// linearly from [0, 1] to [n, m].
+//
+// The range is found by repeatedly fitting it to the
+// two spans that overhang the edges the most, until
+// no span overhangs an edge.
+func fitSpans(min, max vg.Length, lows, highs []span) (n, m vg.Length) {
+	// The edges of the range itself are included
+	// as spans of zero size.
+	l, r := span{x: 0}, span{x: 1}
+	n, m = min, max
+	for i := 0; i <= len(lows)+len(highs); i++ {
+		at := func(s span) vg.Length { return n + vg.Length(s.x)*(m-n) }
+		nl, nr := l, r
+		for _, s := range lows {
+			if s.x >= 0 && s.x <= 1 && at(s)+s.lo < at(nl)+nl.lo {
+				nl = s
+			}
+		}
+		for _, s := range highs {
+			if s.x >= 0 && s.x <= 1 && at(s)+s.hi > at(nr)+nr.hi {
+				nr = s
+			}
+		}
+		if i > 0 && nl == l && nr == r || nl.x == nr.x {
+			break
 		}
+		l, r = nl, nr
+		w := ((max - r.hi) - (min - l.lo)) / vg.Length(r.x-l.x)
+		n = min - l.lo - vg.Length(l.x)*w
+		m = n + w
 	}
-	return l
+	return n, m
 }
 
 // Transforms returns functions to transfrom
@@ -373,7 +971,7 @@ func (p *Plot) GlyphBoxes(*Plot) (boxes []GlyphBox) {
 		if !ok {
 			continue
 		}
-		for _, b := range gb.GlyphBoxes(p) {
+		for _, b := range gb.GlyphBoxes(p.bound(d)) {
 			if b.Size.X > 0 && (b.X < 0 || b.X > 1) {
 				continue
 			}
@@ -386,6 +984,37 @@ func (p *Plot) GlyphBoxes(*Plot) (boxes []GlyphBox) {
 	return
 }
 
+// AlignRanges sets the range of the given axis, 'x'
+// or 'y', of each of the plots to the union of their
+// ranges, so that the plots can be compared when they
+// are drawn side by side.  It should be called after
+// all of the plotters have been added to the plots,
+// because adding a plotter may extend an axis.  To
+// share the range along only one row or column of a
+// grid of plots, call AlignRanges with the plots of
+// that row or column.
+func AlignRanges(axis rune, plots ...*Plot) {
+	axes := make([]*Axis, len(plots))
+	for i, p := range plots {
+		switch axis {
+		case 'x', 'X':
+			axes[i] = &p.X
+		case 'y', 'Y':
+			axes[i] = &p.Y
+		default:
+			panic("Axis must be 'x' or 'y'")
+		}
+	}
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, a := range axes {
+		min = math.Min(min, a.Min)
+		max = math.Max(max, a.Max)
+	}
+	for _, a := range axes {
+		a.Min, a.Max = min, max
+	}
+}
+
 // NominalX configures the plot to have a nominal X
 // axis—an X axis with names instead of numbers.  The
 // X location corresponding to each name are the integers,
@@ -441,45 +1070,182 @@ func (p *Plot) NominalY(names ...string) {
 // Save saves the plot to an image file.  Width and height
 // are specified in inches, and the file format is determined
 // by the extension.  Supported extensions are
-// .eps, .jpg, .jpeg, .pdf, .png, .svg, and .tiff.
+// .eps, .jpg, .jpeg, .pdf, .png, .svg, .tif, and .tiff.
 func (p *Plot) Save(width, height float64, file string) (err error) {
-	w, h := vg.Inches(width), vg.Inches(height)
-	var c interface {
-		vg.Canvas
-		Size() (w, h vg.Length)
-		io.WriterTo
+	return p.SaveSize(vg.Inches(width), vg.Inches(height), vgimg.DefaultDPI, file)
+}
+
+// SaveSize is like Save, but the width and height are
+// physical lengths, and raster images are drawn with the
+// given number of dots per inch.  For example, a plot
+// saved with
+//
+//	p.SaveSize(vg.Centimeters(10), vg.Centimeters(8), 300, "plot.png")
+//
+// is a 1181×945 pixel image that prints at 10cm×8cm
+// at 300 DPI.  Vector formats are always drawn at their
+// physical size, and the DPI is ignored.
+func (p *Plot) SaveSize(width, height vg.Length, dpi int, file string) (err error) {
+	ext := strings.ToLower(filepath.Ext(file))
+	c := newCanvas(width, height, dpi, ext, file)
+	if c == nil {
+		return fmt.Errorf("Unsupported file extension: %s", ext)
+	}
+	p.Draw(MakeDrawArea(c))
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if _, err = c.WriteTo(f); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// SaveScaled saves the plot as raster images of the given
+// physical size at each of the given scale factors, such
+// as 1 and 2 for standard and high density displays.  An
+// image at scale s is drawn at s times vgimg.DefaultDPI,
+// rounded to a whole number of dots per inch, so it has
+// s times as many pixels in each direction and its lines
+// and text are drawn with s times as many pixels, rather
+// than being an enlargement of the image at scale 1.
+//
+// The format is given by the extension of basePath, which
+// is PNG if there is no extension.  The scale of each image
+// is inserted into the file name before the extension,
+// except for scale 1: for example, "plot.png" at scales 1
+// and 2 writes "plot.png" and "plot@2x.png".  If no scales
+// are given then the plot is saved at scale 1.
+func (p *Plot) SaveScaled(basePath string, width, height vg.Length, scales ...float64) error {
+	ext := filepath.Ext(basePath)
+	base := strings.TrimSuffix(basePath, ext)
+	if ext == "" {
+		ext = ".png"
+	}
+	switch strings.ToLower(ext) {
+	case ".jpg", ".jpeg", ".png", ".tif", ".tiff":
+	default:
+		return fmt.Errorf("Unsupported raster format: %s", ext)
+	}
+	if len(scales) == 0 {
+		scales = []float64{1}
+	}
+	for _, s := range scales {
+		if !(s > 0) || math.IsInf(s, 1) {
+			return fmt.Errorf("Invalid scale: %g", s)
+		}
+	}
+
+	for _, s := range scales {
+		name := base + ext
+		if s != 1 {
+			name = base + "@" + strconv.FormatFloat(s, 'g', -1, 64) + "x" + ext
+		}
+		dpi := int(math.Max(1, math.Floor(vgimg.DefaultDPI*s+0.5)))
+		if err := p.SaveSize(width, height, dpi, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriterTo returns an io.WriterTo that writes the plot,
+// drawn with the given size, in the given format.  The
+// supported formats are those of Save, without the
+// leading dot: eps, jpg, jpeg, pdf, png, svg, tif, and
+// tiff.  The plot is drawn when WriterTo is called, so
+// later changes to the plot do not affect what is
+// written.
+//
+// It is not a WriteTo method taking the size and format
+// because go vet's stdmethods check reports any method
+// named WriteTo whose signature is not that of
+// io.WriterTo.
+//
+// For example, a PNG image of a plot can be written
+// to an http.ResponseWriter without a temporary file:
+//
+//	wt, err := p.WriterTo(vg.Inches(4), vg.Inches(4), "png")
+//	if err != nil {
+//		http.Error(w, err.Error(), http.StatusInternalServerError)
+//		return
+//	}
+//	w.Header().Set("Content-Type", "image/png")
+//	wt.WriteTo(w)
+func (p *Plot) WriterTo(width, height vg.Length, format string) (io.WriterTo, error) {
+	c := newCanvas(width, height, vgimg.DefaultDPI, format, "")
+	if c == nil {
+		return nil, fmt.Errorf("Unsupported format: %s", format)
 	}
-	switch ext := strings.ToLower(filepath.Ext(file)); ext {
+	p.Draw(MakeDrawArea(c))
+	return c, nil
+}
 
-	case ".eps":
-		c = vgeps.NewTitle(w, h, file)
+// newCanvas returns a canvas of the given size for
+// the given format, which is a file extension with
+// or without the leading dot.  The DPI is used by
+// raster formats, and the title by formats that have
+// one.  It returns nil if the format is not supported.
+func newCanvas(w, h vg.Length, dpi int, format, title string) interface {
+	vg.Canvas
+	Size() (w, h vg.Length)
+	io.WriterTo
+} {
+	switch f := strings.ToLower(strings.TrimPrefix(format, ".")); f {
+	case "eps":
+		return vgeps.NewTitle(w, h, title)
 
-	case ".jpg", ".jpeg":
-		c = vgimg.JpegCanvas{Canvas: vgimg.New(w, h)}
+	case "jpg", "jpeg":
+		return vgimg.JpegCanvas{Canvas: vgimg.NewDPI(w, h, dpi)}
 
-	case ".pdf":
-		c = vgpdf.New(w, h)
+	case "pdf":
+		return vgpdf.New(w, h)
 
-	case ".png":
-		c = vgimg.PngCanvas{Canvas: vgimg.New(w, h)}
+	case "png":
+		return vgimg.PngCanvas{Canvas: vgimg.NewDPI(w, h, dpi)}
 
-	case ".svg":
-		c = vgsvg.New(w, h)
+	case "svg":
+		return vgsvg.New(w, h)
 
-	case ".tiff":
-		c = vgimg.TiffCanvas{Canvas: vgimg.New(w, h)}
+	case "tif", "tiff":
+		return vgimg.TiffCanvas{Canvas: vgimg.NewDPI(w, h, dpi)}
 
 	default:
-		return fmt.Errorf("Unsupported file extension: %s", ext)
+		return nil
 	}
+}
+
+// WriteHTML writes the plot to an io.Writer as an HTML
+// fragment: an inline SVG element of the given size
+// wrapped in a div.  Plotters that annotate what they
+// draw, such as a Scatter with Tooltips, have titles
+// that most browsers show as tooltips.
+func (p *Plot) WriteHTML(w io.Writer, width, height vg.Length) error {
+	c := vgsvg.New(width, height)
 	p.Draw(MakeDrawArea(c))
 
-	f, err := os.Create(file)
-	if err != nil {
+	if _, err := io.WriteString(w, "<div class=\"plot\">\n"); err != nil {
 		return err
 	}
-	if _, err = c.WriteTo(f); err != nil {
+	if _, err := c.WriteInline(w); err != nil {
 		return err
 	}
-	return f.Close()
+	_, err := io.WriteString(w, "</div>\n")
+	return err
+}
+
+// WriteGIF writes an animated GIF to an io.Writer with
+// each of the plots as a frame of the given size, and
+// with delay hundredths of a second between frames.
+// See vgimg.WriteGIF for how the frames' palettes are
+// chosen.
+func WriteGIF(w io.Writer, frames []*Plot, width, height vg.Length, delay int) error {
+	cs := make([]*vgimg.Canvas, len(frames))
+	for i, p := range frames {
+		cs[i] = vgimg.New(width, height)
+		p.Draw(MakeDrawArea(cs[i]))
+	}
+	return vgimg.WriteGIF(w, cs, delay)
 }
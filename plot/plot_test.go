@@ -0,0 +1,382 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/vgsvg"
+)
+
+type namedPlotter string
+
+func (namedPlotter) Plot(DrawArea, *Plot) {}
+
+func TestDrawOrder(t *testing.T) {
+	var p Plot
+	p.Add(namedPlotter("data0"))
+	p.AddAt(1, namedPlotter("annotation"))
+	p.Add(namedPlotter("data1"))
+	p.AddAt(-1, namedPlotter("grid0"), namedPlotter("grid1"))
+
+	want := []string{"grid0", "grid1", "data0", "data1", "annotation"}
+	got := p.drawOrder()
+	if len(got) != len(want) {
+		t.Fatalf("got %d plotters, want %d", len(got), len(want))
+	}
+	for i, pl := range got {
+		if name := string(pl.(namedPlotter)); name != want[i] {
+			t.Errorf("plotter %d is %s, want %s", i, name, want[i])
+		}
+	}
+	if name := string(p.plotters[0].(namedPlotter)); name != "data0" {
+		t.Errorf("drawOrder modified the plot's plotters")
+	}
+}
+
+func TestFitSpans(t *testing.T) {
+	// A glyph at the left edge overhangs by 10,
+	// one near the right edge overhangs by 5, and
+	// one in the middle must not affect the fit.
+	lows := []span{{x: 0, lo: -10, hi: 10}, {x: 0.5, lo: -1, hi: 1}}
+	highs := append(lows, span{x: 0.9, lo: -5, hi: 15})
+	n, m := fitSpans(0, 100, lows, highs)
+	if n != 10 {
+		t.Errorf("n = %v, want 10", n)
+	}
+	if x := n + 0.9*(m-n) + 15; x < 100-1e-9 || x > 100+1e-9 {
+		t.Errorf("right-most glyph ends at %v, want 100", x)
+	}
+	for _, s := range highs {
+		x := n + vg.Length(s.x)*(m-n)
+		if x+s.lo < -1e-9 || x+s.hi > 100+1e-9 {
+			t.Errorf("span %+v at %v is outside of [0, 100]", s, x)
+		}
+	}
+}
+
+func TestAlignRanges(t *testing.T) {
+	a, b := &Plot{}, &Plot{}
+	a.X.Min, a.X.Max, a.Y.Min, a.Y.Max = 0, 1, -2, 3
+	b.X.Min, b.X.Max, b.Y.Min, b.Y.Max = 5, 6, 1, 7
+	AlignRanges('y', a, b)
+	for _, p := range []*Plot{a, b} {
+		if p.Y.Min != -2 || p.Y.Max != 7 {
+			t.Errorf("got Y range %v..%v, want -2..7", p.Y.Min, p.Y.Max)
+		}
+	}
+	if a.X.Min != 0 || a.X.Max != 1 || b.X.Min != 5 || b.X.Max != 6 {
+		t.Errorf("X ranges changed when aligning Y")
+	}
+}
+
+func TestWithAlpha(t *testing.T) {
+	c := color.RGBA{R: 200, G: 100, A: 255}
+	if got := withAlpha(c, 0); got != color.Color(c) {
+		t.Errorf("withAlpha(c, 0) = %v, want %v", got, c)
+	}
+	r, g, b, a := withAlpha(c, 0.5).RGBA()
+	wr, wg, wb, wa := c.RGBA()
+	for _, v := range [][2]uint32{{r, wr / 2}, {g, wg / 2}, {b, wb / 2}, {a, wa / 2}} {
+		if d := int64(v[0]) - int64(v[1]); d < -1 || d > 1 {
+			t.Errorf("withAlpha(c, 0.5) = %v, want half of %v", withAlpha(c, 0.5), c)
+			break
+		}
+	}
+}
+
+func TestLerpColor(t *testing.T) {
+	from, to := color.Gray16{Y: 0}, color.Gray16{Y: 0xfffe}
+	for _, test := range []struct {
+		f    float64
+		want color.Color
+	}{
+		{0, color.RGBA64{A: 0xffff}},
+		{0.5, color.RGBA64{R: 0x7fff, G: 0x7fff, B: 0x7fff, A: 0xffff}},
+		{1, color.RGBA64{R: 0xfffe, G: 0xfffe, B: 0xfffe, A: 0xffff}},
+	} {
+		if got := lerpColor(from, to, test.f); got != test.want {
+			t.Errorf("lerpColor(%v, %v, %g) = %v, want %v", from, to, test.f, got, test.want)
+		}
+	}
+}
+
+func TestMinSize(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 10
+	w0, h0 := p.MinSize()
+
+	p.Title.Text = "A rather long title for a small plot"
+	p.X.Label.Text = "X"
+	w, h := p.MinSize()
+	if tw := p.Title.Width(p.Title.Text); w < tw {
+		t.Errorf("width %v is less than the title width %v", w, tw)
+	}
+	if h <= h0 || w < w0 {
+		t.Errorf("adding text shrank the size from %v×%v to %v×%v", w0, h0, w, h)
+	}
+}
+
+// clonedPlotter is a Cloner and a Thumbnailer.
+type clonedPlotter struct{ copies int }
+
+func (*clonedPlotter) Plot(DrawArea, *Plot) {}
+func (*clonedPlotter) Thumbnail(*DrawArea)  {}
+func (c *clonedPlotter) Clone() Plotter     { return &clonedPlotter{copies: c.copies + 1} }
+
+func TestClone(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Title.Text = "original"
+	p.Y.Min, p.Y.Max = 0, 10
+	p.Y.Breaks = []Break{{Min: 2, Max: 3}}
+	cl := &clonedPlotter{}
+	p.Add(namedPlotter("shared"), cl)
+	p.Legend.Add("cloned", cl)
+	p.Legend.Add("func", ThumbnailFunc(func(*DrawArea) {}))
+
+	c := p.Clone()
+	c.Title.Text = "clone"
+	c.Y.Min, c.Y.Max = -5, 5
+	c.Y.Breaks[0].Min = 1
+
+	if p.Title.Text != "original" || p.Y.Min != 0 || p.Y.Max != 10 || p.Y.Breaks[0].Min != 2 {
+		t.Errorf("changing the clone changed the original")
+	}
+	if c.plotters[0] != p.plotters[0] {
+		t.Errorf("plotter that is not a Cloner was not shared")
+	}
+	if got := c.plotters[1].(*clonedPlotter); got == cl || got.copies != 1 {
+		t.Errorf("Cloner was not cloned")
+	}
+	if c.Legend.entries[0].thumbs[0] != c.plotters[1].(Thumbnailer) {
+		t.Errorf("legend of the clone does not refer to the cloned plotter")
+	}
+}
+
+// rangedPlotter has a fixed data range and may be
+// bound to the secondary axis.
+type rangedPlotter struct {
+	ymin, ymax float64
+	axis       AxisID
+	drawnY     Axis
+}
+
+func (r *rangedPlotter) Plot(_ DrawArea, p *Plot) { r.drawnY = p.Y }
+
+func (r *rangedPlotter) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, 1, r.ymin, r.ymax
+}
+
+func (r *rangedPlotter) YAxis() AxisID { return r.axis }
+
+func TestSecondaryAxis(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	price := &rangedPlotter{ymin: 10, ymax: 20}
+	volume := &rangedPlotter{ymin: 0, ymax: 5000, axis: SecondaryAxis}
+	p.Add(price, volume)
+	if p.Y.Min != 10 || p.Y.Max != 20 {
+		t.Errorf("Y range is [%g, %g], want [10, 20]", p.Y.Min, p.Y.Max)
+	}
+	if p.Y2.Min != 0 || p.Y2.Max != 5000 {
+		t.Errorf("Y2 range is [%g, %g], want [0, 5000]", p.Y2.Min, p.Y2.Max)
+	}
+
+	p.Draw(MakeDrawArea(vgsvg.New(vg.Inches(4), vg.Inches(3))))
+	if price.drawnY.Max != 20 || volume.drawnY.Max != 5000 {
+		t.Errorf("plotters drawn through Y axes with maxima %g and %g, want 20 and 5000",
+			price.drawnY.Max, volume.drawnY.Max)
+	}
+}
+
+func TestSaveScaled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := filepath.Join(dir, "plot")
+	if err := p.SaveScaled(base, vg.Inches(1), vg.Inches(1), 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]int{"plot.png": 96, "plot@2x.png": 192} {
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := png.DecodeConfig(f)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Width != want || cfg.Height != want {
+			t.Errorf("%s is %d×%d, want %d×%d", name, cfg.Width, cfg.Height, want, want)
+		}
+	}
+	if err := p.SaveScaled(base+".svg", vg.Inches(1), vg.Inches(1), 2); err == nil {
+		t.Errorf("expected an error for a vector format")
+	}
+}
+
+func TestWriterTo(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		format string
+		prefix string
+	}{
+		{format: "png", prefix: "\x89PNG"},
+		{format: "svg", prefix: "<?xml"},
+	} {
+		wt, err := p.WriterTo(vg.Inches(1), vg.Inches(1), test.format)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.format, err)
+			continue
+		}
+		var buf bytes.Buffer
+		n, err := wt.WriteTo(&buf)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.format, err)
+			continue
+		}
+		if n != int64(buf.Len()) {
+			t.Errorf("%s: WriteTo returned %d, but wrote %d bytes", test.format, n, buf.Len())
+		}
+		if !strings.HasPrefix(buf.String(), test.prefix) {
+			t.Errorf("%s: output does not start with %q", test.format, test.prefix)
+		}
+	}
+
+	if _, err := p.WriterTo(vg.Inches(1), vg.Inches(1), "gif"); err == nil {
+		t.Errorf("expected an error for an unsupported format")
+	} else if want := "Unsupported format: gif"; err.Error() != want {
+		t.Errorf("unexpected error %q, want %q", err, want)
+	}
+	err = p.Save(1, 1, filepath.Join(os.TempDir(), "plot.gif"))
+	if want := "Unsupported file extension: .gif"; err == nil || err.Error() != want {
+		t.Errorf("Save returned error %v, want %q", err, want)
+	}
+}
+
+func TestFrameBorderPath(t *testing.T) {
+	f := &Frame{HideTop: true, HideRight: true}
+	path := f.borderPath(Rect{Size: Point{10, 10}})
+	want := []vg.PathComp{
+		{Type: vg.MoveComp, X: 0, Y: 10},
+		{Type: vg.LineComp, X: 0, Y: 0},
+		{Type: vg.LineComp, X: 10, Y: 0},
+	}
+	if len(path) != len(want) {
+		t.Fatalf("border path has %d components, want %d", len(path), len(want))
+	}
+	for i, c := range path {
+		if c != want[i] {
+			t.Errorf("component %d is %+v, want %+v", i, c, want[i])
+		}
+	}
+}
+
+func TestDrawCropped(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 100
+	p.Y.Min, p.Y.Max = -1, 1
+	c := vgsvg.New(vg.Inches(3), vg.Inches(3))
+	p.DrawCropped(MakeDrawArea(c), 40, 60, 0, 0.5)
+	if p.X.Min != 0 || p.X.Max != 100 || p.Y.Min != -1 || p.Y.Max != 1 {
+		t.Errorf("axis ranges not restored: X [%v, %v], Y [%v, %v]", p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+}
+
+func TestMargins(t *testing.T) {
+	var areas []DrawArea
+	for _, max := range []float64{1, 1e6} {
+		p, err := New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Y.Max = max
+		p.Margins = Margins{Left: vg.Inches(1), Top: vg.Inches(0.25)}
+		c := vgsvg.New(vg.Inches(4), vg.Inches(3))
+		areas = append(areas, p.DataDrawArea(MakeDrawArea(c)))
+	}
+	if areas[0].Min.X != areas[1].Min.X || areas[0].Max().Y != areas[1].Max().Y {
+		t.Errorf("data areas %v and %v are not aligned", areas[0].Rect, areas[1].Rect)
+	}
+	if want := vg.Inches(1); areas[0].Min.X < want {
+		t.Errorf("data area starts at %v, want at least %v", areas[0].Min.X, want)
+	}
+}
+
+func TestMirrorTicks(t *testing.T) {
+	paths := func(mirror bool) int {
+		p, err := New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 10
+		p.Y.Min, p.Y.Max = 0, 10
+		p.X.Tick.Mirror, p.Y.Tick.Mirror = mirror, mirror
+		c := vgsvg.New(vg.Inches(4), vg.Inches(3))
+		p.Draw(MakeDrawArea(c))
+		var buf bytes.Buffer
+		if _, err := c.WriteTo(&buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return strings.Count(buf.String(), "<path")
+	}
+	want := 2 * len(DefaultTicks(0, 10))
+	if got := paths(true) - paths(false); got != want {
+		t.Errorf("mirroring drew %d more paths, want %d", got, want)
+	}
+}
+
+func TestTitleHeight(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Title"
+	p.Title.Subtitle.Text = "Subtitle"
+	title, sub := p.Title.TextStyle, p.Title.Subtitle.TextStyle
+	// Both descents are negative, and the space for
+	// the descenders of each is included.
+	want := title.Height("Title") - title.Font.Extents().Descent +
+		sub.Height("Subtitle") - sub.Font.Extents().Descent + p.Title.Padding
+	if h := p.titleHeight(); h != want {
+		t.Errorf("title height = %v, want %v", h, want)
+	}
+
+	var align XAlignment
+	if align != XCenter || align.offset() != -0.5 {
+		t.Errorf("zero alignment has offset %v, want centered", align.offset())
+	}
+}
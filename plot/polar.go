@@ -0,0 +1,219 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/vg"
+)
+
+// Polar is a polar coordinate system that is drawn in
+// a plot, implementing the Plotter interface by drawing
+// its grid: a circle at each major tick mark of the
+// radial axis and evenly spaced spokes labeled with
+// their angles in degrees.
+//
+// A point (θ, r) is at the angle θ, in radians, from
+// the direction Zero, and at a distance from the center
+// given by the radial axis R: R.Min is at the center and
+// R.Max is on the outer circle, with R.Scale as the
+// radial transform.  The polar coordinates are drawn
+// through the X and Y axes of the plot, which span the
+// outer circle, so plotters such as lines and scatters
+// are drawn in polar coordinates when they are given
+// points converted by the XY method.  The X and Y axes
+// should be hidden with HideAxes, and the plot should be
+// drawn on a square canvas so that the circles are round.
+type Polar struct {
+	// R is the radial axis.  Its range must be
+	// set before points are converted by XY,
+	// and is from 0 to 1 by default.  Its line
+	// and label are not drawn.
+	R Axis
+
+	// Zero is the direction of the angle zero, in
+	// radians counter-clockwise from the right.
+	Zero float64
+
+	// Clockwise, if true, measures angles
+	// clockwise from Zero.
+	Clockwise bool
+
+	// Spokes is the number of spokes, evenly
+	// spaced around the circle from Zero.
+	Spokes int
+
+	// GridStyle is the style of the circles
+	// and the spokes.
+	GridStyle LineStyle
+
+	// Label is the style of the angle labels
+	// at the ends of the spokes.  The circles
+	// are labeled in the style of R.Tick.Label.
+	Label TextStyle
+}
+
+// polarLabelGap is the distance between the outer
+// circle of a Polar and the angle labels.
+var polarLabelGap = vg.Points(4)
+
+// polarArcStep is the greatest angle, in radians,
+// between the points of the circles of a Polar.
+const polarArcStep = math.Pi / 90
+
+// NewPolar returns a Polar with a radial axis from 0 to
+// 1, and twelve spokes, at every 30°, counter-clockwise
+// from the right.
+func NewPolar() (*Polar, error) {
+	r, err := makeAxis()
+	if err != nil {
+		return nil, err
+	}
+	r.Min, r.Max = 0, 1
+	fnt, err := vg.MakeFont(DefaultFont, vg.Points(10))
+	if err != nil {
+		return nil, err
+	}
+	return &Polar{
+		R:      r,
+		Spokes: 12,
+		GridStyle: LineStyle{
+			Color: color.Gray{128},
+			Width: vg.Points(0.25),
+		},
+		Label: TextStyle{
+			Color: color.Black,
+			Font:  fnt,
+		},
+	}, nil
+}
+
+// XY returns the X and Y data coordinates of the
+// point (theta, r).  Points with a radius that is less
+// than R.Min are at the center, and points with a radius
+// greater than R.Max are outside of the outer circle.
+func (pl *Polar) XY(theta, r float64) (x, y float64) {
+	n := math.Max(0, pl.R.Norm(r))
+	a := pl.angle(theta)
+	return n * math.Cos(a), n * math.Sin(a)
+}
+
+// angle returns the direction, in radians
+// counter-clockwise from the right, of theta.
+func (pl *Polar) angle(theta float64) float64 {
+	if pl.Clockwise {
+		theta = -theta
+	}
+	return pl.Zero + theta
+}
+
+// spoke returns the angle of the ith spoke.
+func (pl *Polar) spoke(i int) float64 {
+	return 2 * math.Pi * float64(i) / float64(pl.Spokes)
+}
+
+// spokeLabel returns the label of the ith spoke and its
+// offset from the end of the spoke, so that the label
+// extends away from the center.
+func (pl *Polar) spokeLabel(i int) (string, Rect) {
+	theta := pl.spoke(i)
+	txt := FormatFloat(theta*180/math.Pi, 'g', 4, 64) + "°"
+	a := pl.angle(theta)
+	dx, dy := math.Cos(a), math.Sin(a)
+	w, h := pl.Label.Width(txt), pl.Label.Height(txt)
+	return txt, Rect{
+		Min: Point{
+			X: polarLabelGap*vg.Length(dx) + w*vg.Length(polarAlign(dx)),
+			Y: polarLabelGap*vg.Length(dy) + h*vg.Length(polarAlign(dy)),
+		},
+		Size: Point{X: w, Y: h},
+	}
+}
+
+// polarAlign returns the alignment along one dimension
+// of a label at the end of a spoke whose unit vector has
+// the given component.
+func polarAlign(d float64) float64 {
+	switch {
+	case d > 0.1:
+		return 0
+	case d < -0.1:
+		return -1
+	}
+	return -0.5
+}
+
+// Plot draws the grid of the polar coordinate
+// system, implementing the Plotter interface.
+func (pl *Polar) Plot(da DrawArea, plt *Plot) {
+	pl.R.sanitizeRange()
+	trX, trY := plt.Transforms(&da)
+	at := func(n, a float64) Point {
+		return Pt(trX(n*math.Cos(a)), trY(n*math.Sin(a)))
+	}
+	circle := func(n float64) []Point {
+		k := int(math.Ceil(2 * math.Pi / polarArcStep))
+		pts := make([]Point, k+1)
+		for i := range pts {
+			pts[i] = at(n, 2*math.Pi*float64(i)/float64(k))
+		}
+		return pts
+	}
+
+	// The circles are labeled between the first
+	// two spokes, where the labels do not cross
+	// a spoke.
+	lblAngle := pl.Zero
+	if pl.Spokes > 0 {
+		lblAngle = pl.angle(pl.spoke(1) / 2)
+	}
+	outer := false
+	for _, t := range pl.R.Ticks() {
+		n := pl.R.Norm(t.Value)
+		if t.IsMinor() || n <= 0 || n > 1 {
+			continue
+		}
+		outer = outer || n == 1
+		da.StrokeLines(pl.GridStyle, circle(n))
+		pt := at(n, lblAngle)
+		da.FillText(pl.R.Tick.Label, pt.X, pt.Y, -0.5, -0.5, t.Label)
+	}
+	if !outer {
+		da.StrokeLines(pl.GridStyle, circle(1))
+	}
+
+	c := at(0, 0)
+	for i := 0; i < pl.Spokes; i++ {
+		end := at(1, pl.angle(pl.spoke(i)))
+		da.StrokeLine2(pl.GridStyle, c.X, c.Y, end.X, end.Y)
+		txt, r := pl.spokeLabel(i)
+		da.FillText(pl.Label, end.X+r.Min.X, end.Y+r.Min.Y, 0, 0, txt)
+	}
+}
+
+// DataRange returns the square around the outer
+// circle, implementing the DataRanger interface.
+func (pl *Polar) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return -1, 1, -1, 1
+}
+
+// GlyphBoxes returns a GlyphBox for the label of each
+// spoke, so that the plot is padded to fit the labels,
+// implementing the GlyphBoxer interface.
+func (pl *Polar) GlyphBoxes(plt *Plot) []GlyphBox {
+	boxes := make([]GlyphBox, pl.Spokes)
+	for i := range boxes {
+		a := pl.angle(pl.spoke(i))
+		_, r := pl.spokeLabel(i)
+		boxes[i] = GlyphBox{
+			X:    plt.X.Norm(math.Cos(a)),
+			Y:    plt.Y.Norm(math.Sin(a)),
+			Rect: r,
+		}
+	}
+	return boxes
+}
@@ -0,0 +1,57 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/vgsvg"
+)
+
+func TestPolar(t *testing.T) {
+	pl, err := NewPolar()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pl.R.Max = 2
+	pl.Zero = math.Pi / 2
+	pl.Clockwise = true
+	for _, test := range []struct {
+		theta, r float64
+		x, y     float64
+	}{
+		{0, 2, 0, 1},
+		{math.Pi / 2, 1, 0.5, 0},
+		{math.Pi, 2, 0, -1},
+		{0, -1, 0, 0},
+	} {
+		x, y := pl.XY(test.theta, test.r)
+		if math.Abs(x-test.x) > 1e-9 || math.Abs(y-test.y) > 1e-9 {
+			t.Errorf("XY(%v, %v) = %v, %v, want %v, %v", test.theta, test.r, x, y, test.x, test.y)
+		}
+	}
+
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(pl)
+	p.HideAxes()
+	c := vgsvg.New(vg.Inches(4), vg.Inches(4))
+	p.Draw(MakeDrawArea(c))
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, label := range []string{">0°<", ">90°<", ">330°<"} {
+		if !strings.Contains(buf.String(), label) {
+			t.Errorf("polar grid has no label %q", label)
+		}
+	}
+}
@@ -0,0 +1,550 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/gonum/plot/vg"
+)
+
+// A Spec is a description of the configuration of a
+// plot, its title, axes, legend and plotters, that can
+// be written as JSON and read back to make the same
+// plot.  A Plot is converted to and from JSON by way
+// of a Spec by its MarshalJSON and UnmarshalJSON
+// methods.
+type Spec struct {
+	// Title is the text of the plot title.
+	Title string `json:"title,omitempty"`
+
+	// X and Y are the horizontal and vertical axes.
+	X AxisSpec `json:"x"`
+	Y AxisSpec `json:"y"`
+
+	// Legend is the placement of the legend.
+	Legend LegendSpec `json:"legend"`
+
+	// Plotters are the plotters of the plot, in
+	// the order in which they are added to it.
+	Plotters []PlotterSpec `json:"plotters,omitempty"`
+}
+
+// An AxisSpec describes an axis of a Spec.
+type AxisSpec struct {
+	// Label is the text of the axis label.
+	Label string `json:"label,omitempty"`
+
+	// Min and Max, if non-nil, are the range of
+	// the axis.  If they are nil then the range
+	// fits the data of the plotters.
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+
+	// Scale is the name with which the scale of the
+	// axis is registered by RegisterScale, such as
+	// "log" or "symlog".  An empty Scale is linear.
+	Scale string `json:"scale,omitempty"`
+
+	// ScaleParam is the parameter of the scale,
+	// such as the threshold of a "symlog" scale.
+	ScaleParam float64 `json:"scaleParam,omitempty"`
+}
+
+// An AxisScale is a kind of axis scale that can be
+// named in an AxisSpec.
+type AxisScale struct {
+	// New returns the Scale function and the tick
+	// Marker of an axis with the scale and the given
+	// parameter, or an error if the parameter is not
+	// valid for the scale.
+	New func(param float64) (scale func(min, max, x float64) float64, ticks func(min, max float64) []Tick, err error)
+
+	// Parameterized is true if the scale depends on
+	// its parameter.  The Scale of an axis is only
+	// recognized as a parameterized scale if it was
+	// set by the axis's SetScale method, because the
+	// parameter cannot be recovered from the Scale
+	// function.
+	Parameterized bool
+}
+
+// axisScales are the registered scales, by name.
+var axisScales = map[string]AxisScale{
+	"linear": {New: func(float64) (func(min, max, x float64) float64, func(min, max float64) []Tick, error) {
+		return LinearScale, DefaultTicks, nil
+	}},
+	"log": {New: func(float64) (func(min, max, x float64) float64, func(min, max float64) []Tick, error) {
+		return LogScale, LogTicks, nil
+	}},
+	"symlog": {
+		New: func(threshold float64) (func(min, max, x float64) float64, func(min, max float64) []Tick, error) {
+			if !(threshold > 0) || math.IsInf(threshold, 0) {
+				return nil, nil, errors.New("Symmetric log threshold is not positive")
+			}
+			return SymLogScale(threshold), SymLogTicks(threshold), nil
+		},
+		Parameterized: true,
+	},
+}
+
+// RegisterScale registers a kind of axis scale under
+// a name, which is the Scale of an AxisSpec.  This
+// package registers "linear", "log" and "symlog".
+func RegisterScale(name string, s AxisScale) {
+	axisScales[name] = s
+}
+
+// SetScale sets the Scale and the tick Marker of the
+// axis to those of the scale registered with the
+// given name, with the given parameter, and records
+// the name and parameter so that the axis can be
+// written in a Spec.  If the Scale is later set to
+// the function of another kind of scale then the
+// record is ignored, but a parameterized scale must
+// be changed by SetScale, since the functions of its
+// different parameters cannot be told apart.
+func (a *Axis) SetScale(name string, param float64) error {
+	s, ok := axisScales[name]
+	if !ok {
+		return fmt.Errorf("Unknown axis scale %q", name)
+	}
+	scale, ticks, err := s.New(param)
+	if err != nil {
+		return err
+	}
+	a.Scale, a.Tick.Marker = scale, ticks
+	a.scale = namedScale{
+		name:  name,
+		param: param,
+		ptr:   reflect.ValueOf(scale).Pointer(),
+	}
+	return nil
+}
+
+// A namedScale records the scale set by SetScale.
+type namedScale struct {
+	name  string
+	param float64
+
+	// ptr is the code pointer of the Scale
+	// function, which shows whether the Scale
+	// has been changed since.
+	ptr uintptr
+}
+
+// scaleName returns the registered name and the
+// parameter of the scale of the axis.  A Scale that
+// was not set by SetScale is recognized if it is the
+// Scale function of a registered scale that has no
+// parameter, such as LogScale.
+func (a *Axis) scaleName() (string, float64, error) {
+	ptr := reflect.ValueOf(a.Scale).Pointer()
+	if a.scale.name != "" && a.scale.ptr == ptr {
+		return a.scale.name, a.scale.param, nil
+	}
+	names := make([]string, 0, len(axisScales))
+	for name := range axisScales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s := axisScales[name]
+		if s.Parameterized {
+			continue
+		}
+		if scale, _, err := s.New(0); err == nil && reflect.ValueOf(scale).Pointer() == ptr {
+			return name, 0, nil
+		}
+	}
+	return "", 0, errors.New("Axis scale is not a registered scale set by SetScale")
+}
+
+// A LegendSpec describes the legend of a Spec.
+// The entries of the legend are given by the
+// Legend fields of the plotters.
+type LegendSpec struct {
+	// Top and Left are as for the Top and Left
+	// fields of Legend.
+	Top  bool `json:"top,omitempty"`
+	Left bool `json:"left,omitempty"`
+}
+
+// A PlotterSpec describes a plotter of a Spec.
+type PlotterSpec struct {
+	// Type is the name with which the plotter's
+	// type is registered by RegisterPlotter.
+	Type string `json:"type"`
+
+	// Legend, if non-empty, is the text of a
+	// legend entry for the plotter.
+	Legend string `json:"legend,omitempty"`
+
+	// Layer is the layer of the plotter, as
+	// for AddAt.
+	Layer int `json:"layer,omitempty"`
+
+	// Data is the data and style of the plotter,
+	// in the form of its PlotterCodec.
+	Data json.RawMessage `json:"data"`
+}
+
+// A PlotterCodec converts the plotters of one type
+// to and from the Data of a PlotterSpec.
+type PlotterCodec struct {
+	// Encode returns a value that is marshaled as the
+	// Data of the plotter, and true, if the plotter is
+	// of the codec's type.  If it is not then Encode
+	// returns false.
+	Encode func(Plotter) (interface{}, bool)
+
+	// Decode returns a plotter made from the Data
+	// of a PlotterSpec.
+	Decode func(data json.RawMessage) (Plotter, error)
+}
+
+// plotterCodecs are the registered codecs, by name.
+var plotterCodecs = map[string]PlotterCodec{}
+
+// RegisterPlotter registers the codec of a type of
+// plotter under a name, which is the Type of the
+// plotter in a Spec.  The plotter package registers
+// its plotters in this way, so that plots made of
+// them can be written as JSON.
+func RegisterPlotter(name string, c PlotterCodec) {
+	plotterCodecs[name] = c
+}
+
+// encodePlotter returns the name and data of the
+// plotter under the first registered codec, in order
+// of name, that encodes it.
+func encodePlotter(d Plotter) (string, interface{}, error) {
+	names := make([]string, 0, len(plotterCodecs))
+	for name := range plotterCodecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if data, ok := plotterCodecs[name].Encode(d); ok {
+			return name, data, nil
+		}
+	}
+	return "", nil, fmt.Errorf("Plotter of type %T cannot be encoded by a registered codec", d)
+}
+
+// Spec returns the Spec of the plot.  It returns an
+// error if a plotter is not of a registered type, or if
+// a legend entry does not belong to exactly one plotter.
+// The ranges of the axes are given by the Spec, so that
+// the plot made from it has the same ranges.
+func (p *Plot) Spec() (*Spec, error) {
+	s := &Spec{
+		Title:  p.Title.Text,
+		Legend: LegendSpec{Top: p.Legend.Top, Left: p.Legend.Left},
+	}
+	var err error
+	if s.X, err = p.X.spec(); err != nil {
+		return nil, err
+	}
+	if s.Y, err = p.Y.spec(); err != nil {
+		return nil, err
+	}
+
+	s.Plotters = make([]PlotterSpec, len(p.plotters))
+	for i, d := range p.plotters {
+		name, data, err := encodePlotter(d)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		s.Plotters[i] = PlotterSpec{Type: name, Layer: p.layers[i], Data: raw}
+	}
+	for _, e := range p.Legend.entries {
+		i := -1
+		if len(e.thumbs) == 1 {
+			for j, d := range p.plotters {
+				if samePlotter(e.thumbs[0], d) && s.Plotters[j].Legend == "" {
+					i = j
+					break
+				}
+			}
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("Legend entry %q does not belong to one plotter", e.text)
+		}
+		s.Plotters[i].Legend = e.text
+	}
+	return s, nil
+}
+
+// spec returns the AxisSpec of the axis.  Bounds
+// that are not finite, such as those of an axis of a
+// plot with no data, are left out.
+func (a *Axis) spec() (AxisSpec, error) {
+	s := AxisSpec{Label: a.Label.Text}
+	if min := a.Min; !math.IsInf(min, 0) && !math.IsNaN(min) {
+		s.Min = &min
+	}
+	if max := a.Max; !math.IsInf(max, 0) && !math.IsNaN(max) {
+		s.Max = &max
+	}
+	name, param, err := a.scaleName()
+	if err != nil {
+		return AxisSpec{}, err
+	}
+	if name != "linear" {
+		s.Scale, s.ScaleParam = name, param
+	}
+	return s, nil
+}
+
+// apply sets the label and scale of the axis
+// from the spec.
+func (s AxisSpec) apply(a *Axis) error {
+	a.Label.Text = s.Label
+	name := s.Scale
+	if name == "" {
+		name = "linear"
+	}
+	return a.SetScale(name, s.ScaleParam)
+}
+
+// applyRange sets the range of the axis from the
+// spec where the spec gives it.
+func (s AxisSpec) applyRange(a *Axis) {
+	if s.Min != nil {
+		a.Min = *s.Min
+	}
+	if s.Max != nil {
+		a.Max = *s.Max
+	}
+}
+
+// Plot returns a new plot with the default settings
+// of New configured by the Spec.  It returns an error
+// if a plotter's Type is not registered or its Data
+// cannot be decoded.
+func (s *Spec) Plot() (*Plot, error) {
+	p, err := New()
+	if err != nil {
+		return nil, err
+	}
+	p.Title.Text = s.Title
+	p.Legend.Top, p.Legend.Left = s.Legend.Top, s.Legend.Left
+	if err := s.X.apply(&p.X); err != nil {
+		return nil, err
+	}
+	if err := s.Y.apply(&p.Y); err != nil {
+		return nil, err
+	}
+	for _, ps := range s.Plotters {
+		c, ok := plotterCodecs[ps.Type]
+		if !ok {
+			return nil, fmt.Errorf("Plotter type %q is not registered", ps.Type)
+		}
+		d, err := c.Decode(ps.Data)
+		if err != nil {
+			return nil, fmt.Errorf("Plotter of type %q: %v", ps.Type, err)
+		}
+		p.AddAt(ps.Layer, d)
+		if ps.Legend == "" {
+			continue
+		}
+		t, ok := d.(Thumbnailer)
+		if !ok {
+			return nil, fmt.Errorf("Plotter of type %q cannot have a legend entry", ps.Type)
+		}
+		p.Legend.Add(ps.Legend, t)
+	}
+	s.X.applyRange(&p.X)
+	s.Y.applyRange(&p.Y)
+	return p, nil
+}
+
+// MarshalJSON returns the Spec of the plot
+// written as JSON, implementing the
+// json.Marshaler interface.
+func (p *Plot) MarshalJSON() ([]byte, error) {
+	s, err := p.Spec()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON replaces the plot with the plot
+// made from a Spec written as JSON, implementing
+// the json.Unmarshaler interface.
+func (p *Plot) UnmarshalJSON(data []byte) error {
+	var s Spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	np, err := s.Plot()
+	if err != nil {
+		return err
+	}
+	*p = *np
+	return nil
+}
+
+// A LineStyleSpec describes a LineStyle in the
+// Data of a PlotterSpec.  Lengths are in points.
+type LineStyleSpec struct {
+	Color  string    `json:"color,omitempty"`
+	Width  float64   `json:"width,omitempty"`
+	Dashes []float64 `json:"dashes,omitempty"`
+	Alpha  float64   `json:"alpha,omitempty"`
+}
+
+// SpecOfLineStyle returns the LineStyleSpec
+// of a LineStyle.
+func SpecOfLineStyle(l LineStyle) LineStyleSpec {
+	s := LineStyleSpec{
+		Color: FormatColor(l.Color),
+		Width: float64(l.Width),
+		Alpha: l.Alpha,
+	}
+	for _, d := range l.Dashes {
+		s.Dashes = append(s.Dashes, float64(d))
+	}
+	return s
+}
+
+// Apply sets the fields of the LineStyle that
+// are given by the spec.  Empty fields of the
+// spec leave the LineStyle unchanged.
+func (s LineStyleSpec) Apply(l *LineStyle) error {
+	if s.Color != "" {
+		c, err := ParseColor(s.Color)
+		if err != nil {
+			return err
+		}
+		l.Color = c
+	}
+	if s.Width != 0 {
+		l.Width = vg.Length(s.Width)
+	}
+	if s.Dashes != nil {
+		l.Dashes = make([]vg.Length, len(s.Dashes))
+		for i, d := range s.Dashes {
+			l.Dashes[i] = vg.Length(d)
+		}
+	}
+	if s.Alpha != 0 {
+		l.Alpha = s.Alpha
+	}
+	return nil
+}
+
+// glyphShapes are the glyph shapes that can be
+// named in a GlyphStyleSpec.
+var glyphShapes = map[string]GlyphDrawer{
+	"circle":   CircleGlyph{},
+	"ring":     RingGlyph{},
+	"square":   SquareGlyph{},
+	"box":      BoxGlyph{},
+	"triangle": TriangleGlyph{},
+	"pyramid":  PyramidGlyph{},
+	"plus":     PlusGlyph{},
+	"cross":    CrossGlyph{},
+}
+
+// A GlyphStyleSpec describes a GlyphStyle in the
+// Data of a PlotterSpec.  The Radius is in points,
+// and the Shape is the name of one of the glyphs of
+// this package, such as "ring" for RingGlyph.
+type GlyphStyleSpec struct {
+	Color  string  `json:"color,omitempty"`
+	Radius float64 `json:"radius,omitempty"`
+	Shape  string  `json:"shape,omitempty"`
+	Alpha  float64 `json:"alpha,omitempty"`
+}
+
+// SpecOfGlyphStyle returns the GlyphStyleSpec of
+// a GlyphStyle.  It returns an error if the shape
+// is not one of the glyphs of this package.
+func SpecOfGlyphStyle(g GlyphStyle) (GlyphStyleSpec, error) {
+	s := GlyphStyleSpec{
+		Color:  FormatColor(g.Color),
+		Radius: float64(g.Radius),
+		Alpha:  g.Alpha,
+	}
+	for name, shape := range glyphShapes {
+		if reflect.TypeOf(g.Shape) == reflect.TypeOf(shape) {
+			s.Shape = name
+			return s, nil
+		}
+	}
+	return GlyphStyleSpec{}, fmt.Errorf("Glyph shape of type %T has no name", g.Shape)
+}
+
+// Apply sets the fields of the GlyphStyle that
+// are given by the spec.  Empty fields of the
+// spec leave the GlyphStyle unchanged.
+func (s GlyphStyleSpec) Apply(g *GlyphStyle) error {
+	if s.Color != "" {
+		c, err := ParseColor(s.Color)
+		if err != nil {
+			return err
+		}
+		g.Color = c
+	}
+	if s.Radius != 0 {
+		g.Radius = vg.Length(s.Radius)
+	}
+	if s.Shape != "" {
+		shape, ok := glyphShapes[s.Shape]
+		if !ok {
+			return fmt.Errorf("Unknown glyph shape %q", s.Shape)
+		}
+		g.Shape = shape
+	}
+	if s.Alpha != 0 {
+		g.Alpha = s.Alpha
+	}
+	return nil
+}
+
+// FormatColor returns the color written in hexadecimal
+// as #rrggbb, or as #rrggbbaa if it is not opaque.  The
+// components are not premultiplied by alpha.  A nil
+// color is written as the empty string.
+func FormatColor(c color.Color) string {
+	if c == nil {
+		return ""
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if n.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", n.R, n.G, n.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", n.R, n.G, n.B, n.A)
+}
+
+// ParseColor returns the color written as
+// by FormatColor.
+func ParseColor(s string) (color.Color, error) {
+	if len(s) != 7 && len(s) != 9 || s[0] != '#' {
+		return nil, fmt.Errorf("Color %q is not of the form #rrggbb or #rrggbbaa", s)
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("Color %q is not of the form #rrggbb or #rrggbbaa", s)
+	}
+	if len(s) == 7 {
+		v = v<<8 | 0xff
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
@@ -0,0 +1,318 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/vg"
+)
+
+// A TraceKind is the kind of a TracedPrimitive.
+type TraceKind int
+
+const (
+	// TracedLine is a stroked line, such as a
+	// Line or the outline of a bar.
+	TracedLine TraceKind = iota
+
+	// TracedFill is a filled region, such as
+	// a bar or a polygon.
+	TracedFill
+
+	// TracedGlyph is a glyph, such as a point
+	// of a Scatter.
+	TracedGlyph
+
+	// TracedText is a string of text, such as
+	// a label.
+	TracedText
+)
+
+// A TracedPrimitive is something that a plotter drew,
+// in the data coordinates of the plot.
+type TracedPrimitive struct {
+	// Kind is the kind of the primitive.
+	Kind TraceKind
+
+	// Plotter is the plotter that drew the
+	// primitive.
+	Plotter Plotter
+
+	// X and Y are the data coordinates of the
+	// vertices of a line or filled region, of the
+	// center of a glyph, or of the location at which
+	// text was drawn.  Coordinates beyond the ends
+	// of an axis, such as those of the bars drawn in
+	// the padding at the ends of a bar chart, are
+	// extrapolated.  A coordinate is NaN if it has no
+	// data value, such as one below zero on a log
+	// scale.
+	X, Y []float64
+
+	// Color is the color with which the primitive
+	// was drawn.
+	Color color.Color
+
+	// Text is the text of a TracedText.
+	Text string
+}
+
+// Trace draws the plotters of the plot, as if the plot
+// were drawn with the given size, and returns what they
+// drew in data coordinates.  The title, axes and legend
+// are not traced.
+//
+// Trace is intended for testing: it shows where the data
+// of a plot ends up without depending on the output of any
+// particular canvas.  Each subpath of a stroked or filled
+// path is a separate primitive, the paths that make up a
+// glyph are traced as the single point at its center, and
+// arcs are traced as their end points.
+func (p *Plot) Trace(width, height vg.Length) ([]TracedPrimitive, error) {
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("Trace size must be positive")
+	}
+	c := newTraceCanvas()
+	data := p.DataDrawArea(MakeDrawAreaSize(c, width, height))
+	if data.Size.X <= 0 || data.Size.Y <= 0 {
+		return nil, errors.New("Trace size is too small for the data area")
+	}
+	var plt *Plot
+	c.toData = func(x, y vg.Length) (float64, float64) {
+		return traceUnnorm(&plt.X, float64((x-data.Min.X)/data.Size.X)),
+			traceUnnorm(&plt.Y, float64((y-data.Min.Y)/data.Size.Y))
+	}
+	for _, d := range p.drawOrder() {
+		// Primitives of plotters bound to the
+		// secondary axis are in its coordinates.
+		plt = p.bound(d)
+		c.plotter = d
+		d.Plot(data, plt)
+	}
+	return c.prims, nil
+}
+
+// traceUnnorm returns the data value that is normalized
+// to n on the axis, like Unnorm, but also extrapolates
+// the axis beyond its ends for n outside of [0, 1].  It
+// returns NaN if there is no such value.
+func traceUnnorm(a *Axis, n float64) float64 {
+	if n >= 0 && n <= 1 || math.IsNaN(n) {
+		return a.Unnorm(n)
+	}
+	// Step away from the end of the axis nearer to n,
+	// doubling the step until n is passed, and halving
+	// it where the scale has no value, such as below
+	// zero on a log scale.
+	from, dir := a.Min, -1.0
+	if n > 1 {
+		from, dir = a.Max, 1
+	}
+	d := a.Max - a.Min
+	for i := 0; i < 200; i++ {
+		to := from + dir*d
+		m, ok := tryNorm(a, to)
+		switch {
+		case !ok:
+			d /= 2
+		case (n > 1 && m >= n) || (n < 0 && m <= n):
+			return a.bisect(from, to, n)
+		default:
+			from, d = to, 2*d
+		}
+	}
+	return math.NaN()
+}
+
+// tryNorm returns the normalized value of x on the
+// axis, and whether it has one: a scale may panic or
+// return a value that is not finite for values beyond
+// the ends of the axis.
+func tryNorm(a *Axis, x float64) (n float64, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	n = a.Norm(x)
+	return n, !math.IsNaN(n) && !math.IsInf(n, 0)
+}
+
+// affine is an affine transform, mapping x, y to
+// a*x + c*y + e, b*x + d*y + f.
+type affine struct {
+	a, b, c, d, e, f float64
+}
+
+// apply returns the transformed point.
+func (m affine) apply(x, y vg.Length) (vg.Length, vg.Length) {
+	fx, fy := float64(x), float64(y)
+	return vg.Length(m.a*fx + m.c*fy + m.e), vg.Length(m.b*fx + m.d*fy + m.f)
+}
+
+// traceState is the state of a traceCanvas that
+// is saved by Push.
+type traceState struct {
+	m     affine
+	color color.Color
+}
+
+// traceCanvas is a vg.Canvas that records what is
+// drawn to it as TracedPrimitives.
+type traceCanvas struct {
+	traceState
+	stack []traceState
+
+	// toData maps device coordinates to data
+	// coordinates.
+	toData func(x, y vg.Length) (float64, float64)
+
+	// plotter is the plotter that is drawing.
+	plotter Plotter
+
+	// glyphs is the depth of nested glyph
+	// drawing, during which paths are not
+	// traced.
+	glyphs int
+
+	prims []TracedPrimitive
+}
+
+func newTraceCanvas() *traceCanvas {
+	return &traceCanvas{
+		traceState: traceState{
+			m:     affine{a: 1, d: 1},
+			color: color.Black,
+		},
+	}
+}
+
+func (c *traceCanvas) SetLineWidth(vg.Length) {}
+
+func (c *traceCanvas) SetLineDash([]vg.Length, vg.Length) {}
+
+func (c *traceCanvas) SetColor(clr color.Color) {
+	if clr == nil {
+		clr = color.Black
+	}
+	c.color = clr
+}
+
+func (c *traceCanvas) Rotate(t float64) {
+	sin, cos := math.Sincos(t)
+	m := c.m
+	c.m.a, c.m.b = m.a*cos+m.c*sin, m.b*cos+m.d*sin
+	c.m.c, c.m.d = m.c*cos-m.a*sin, m.d*cos-m.b*sin
+}
+
+func (c *traceCanvas) Translate(x, y vg.Length) {
+	e, f := c.m.apply(x, y)
+	c.m.e, c.m.f = float64(e), float64(f)
+}
+
+func (c *traceCanvas) Scale(x, y float64) {
+	c.m.a, c.m.b = c.m.a*x, c.m.b*x
+	c.m.c, c.m.d = c.m.c*y, c.m.d*y
+}
+
+func (c *traceCanvas) Push() {
+	c.stack = append(c.stack, c.traceState)
+}
+
+func (c *traceCanvas) Pop() {
+	c.traceState = c.stack[len(c.stack)-1]
+	c.stack = c.stack[:len(c.stack)-1]
+}
+
+func (c *traceCanvas) Stroke(p vg.Path) {
+	c.tracePath(TracedLine, p)
+}
+
+func (c *traceCanvas) Fill(p vg.Path) {
+	c.tracePath(TracedFill, p)
+}
+
+func (c *traceCanvas) FillString(_ vg.Font, x, y vg.Length, txt string) {
+	if c.glyphs > 0 {
+		return
+	}
+	prim := c.prim(TracedText)
+	prim.Text = txt
+	c.add(&prim, x, y)
+	c.prims = append(c.prims, prim)
+}
+
+func (c *traceCanvas) DPI() float64 {
+	return 72
+}
+
+// beginGlyph traces a glyph centered at the given
+// point and stops tracing paths until the
+// corresponding call to endGlyph.
+func (c *traceCanvas) beginGlyph(pt Point) {
+	if c.glyphs == 0 {
+		prim := c.prim(TracedGlyph)
+		c.add(&prim, pt.X, pt.Y)
+		c.prims = append(c.prims, prim)
+	}
+	c.glyphs++
+}
+
+// endGlyph ends the glyph started by beginGlyph.
+func (c *traceCanvas) endGlyph() {
+	c.glyphs--
+}
+
+// prim returns a new primitive of the given kind,
+// drawn by the current plotter in the current color.
+func (c *traceCanvas) prim(k TraceKind) TracedPrimitive {
+	return TracedPrimitive{Kind: k, Plotter: c.plotter, Color: c.color}
+}
+
+// add adds a point, in the current user space,
+// to the primitive.
+func (c *traceCanvas) add(prim *TracedPrimitive, x, y vg.Length) {
+	dx, dy := c.toData(c.m.apply(x, y))
+	prim.X = append(prim.X, dx)
+	prim.Y = append(prim.Y, dy)
+}
+
+// tracePath traces each subpath of a path as a
+// separate primitive of the given kind.
+func (c *traceCanvas) tracePath(k TraceKind, p vg.Path) {
+	if c.glyphs > 0 {
+		return
+	}
+	var cur *TracedPrimitive
+	var start Point
+	for _, comp := range p {
+		if cur == nil || comp.Type == vg.MoveComp {
+			if cur != nil && len(cur.X) > 0 {
+				c.prims = append(c.prims, *cur)
+			}
+			prim := c.prim(k)
+			cur = &prim
+		}
+		switch comp.Type {
+		case vg.MoveComp:
+			start = Pt(comp.X, comp.Y)
+			c.add(cur, comp.X, comp.Y)
+		case vg.LineComp:
+			c.add(cur, comp.X, comp.Y)
+		case vg.ArcComp:
+			s, e := comp.Start, comp.Start+comp.Angle
+			c.add(cur, comp.X+comp.Radius*vg.Length(math.Cos(s)), comp.Y+comp.Radius*vg.Length(math.Sin(s)))
+			c.add(cur, comp.X+comp.Radius*vg.Length(math.Cos(e)), comp.Y+comp.Radius*vg.Length(math.Sin(e)))
+		case vg.CloseComp:
+			c.add(cur, start.X, start.Y)
+		}
+	}
+	if cur != nil && len(cur.X) > 0 {
+		c.prims = append(c.prims, *cur)
+	}
+}
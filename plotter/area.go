@@ -0,0 +1,146 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// Area implements the Plotter interface, filling the
+// area between a line and a horizontal baseline, or
+// between two lines, such as the bounds of a confidence
+// region, and optionally drawing the lines that bound
+// it.
+type Area struct {
+	// XYs is a copy of the points of the line
+	// that bounds the area.
+	XYs
+
+	// Lower, if non-nil, is a copy of the points
+	// of the other line that bounds the area.  If
+	// Lower is nil then the area is bounded by
+	// the Baseline.
+	Lower XYs
+
+	// Baseline is the Y value of the horizontal
+	// line that bounds the area if Lower is nil.
+	Baseline float64
+
+	// FillColor is the color of the area.  A
+	// translucent color keeps what is beneath
+	// the area visible.  If FillColor is nil then
+	// the area is not filled.
+	FillColor color.Color
+
+	// LineStyle is the style of the lines that
+	// bound the area.  If its width is zero then
+	// the lines are not drawn.  The Baseline is
+	// never drawn.
+	plot.LineStyle
+}
+
+// NewArea returns an Area between the line through
+// the points and the baseline, filled with translucent
+// gray and with no bounding lines.
+func NewArea(xys XYer, baseline float64) (*Area, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckFloats(baseline); err != nil {
+		return nil, err
+	}
+	return &Area{
+		XYs:       data,
+		Baseline:  baseline,
+		FillColor: color.NRGBA{R: 128, G: 128, B: 128, A: 96},
+	}, nil
+}
+
+// NewAreaBetween returns an Area between the lines
+// through the two sets of points, filled with
+// translucent gray and with no bounding lines.  The
+// points of each line should be in increasing order
+// of X, but the lines need not have the same X values.
+func NewAreaBetween(upper, lower XYer) (*Area, error) {
+	a, err := NewArea(upper, 0)
+	if err != nil {
+		return nil, err
+	}
+	if a.Lower, err = CopyXYs(lower); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// lower returns the points of the other bound of the
+// area: the Lower line, or the Baseline beneath the
+// ends of the line.
+func (a *Area) lower() XYs {
+	if a.Lower != nil {
+		return a.Lower
+	}
+	if len(a.XYs) == 0 {
+		return nil
+	}
+	return XYs{
+		{a.XYs[0].X, a.Baseline},
+		{a.XYs[len(a.XYs)-1].X, a.Baseline},
+	}
+}
+
+// Plot fills the area and draws its bounding lines,
+// implementing the plot.Plotter interface.
+func (a *Area) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	points := func(xys XYs) []plot.Point {
+		ps := make([]plot.Point, len(xys))
+		for i, p := range xys {
+			ps[i] = plot.Pt(trX(p.X), trY(p.Y))
+		}
+		return ps
+	}
+	upper, lower := points(a.XYs), points(a.lower())
+
+	if a.FillColor != nil {
+		// The area runs forward along the line
+		// and back along its other bound.
+		poly := append([]plot.Point(nil), upper...)
+		for i := len(lower) - 1; i >= 0; i-- {
+			poly = append(poly, lower[i])
+		}
+		da.FillPolygon(a.FillColor, da.ClipPolygonXY(poly))
+	}
+	if a.Width > 0 {
+		da.StrokeLines(a.LineStyle, da.ClipLinesXY(upper)...)
+		if a.Lower != nil {
+			da.StrokeLines(a.LineStyle, da.ClipLinesXY(lower)...)
+		}
+	}
+}
+
+// DataRange returns the minimum and maximum X and Y
+// values of both bounds of the area, implementing the
+// plot.DataRanger interface.
+func (a *Area) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(a)
+	lxmin, lxmax, lymin, lymax := XYRange(a.lower())
+	return math.Min(xmin, lxmin), math.Max(xmax, lxmax),
+		math.Min(ymin, lymin), math.Max(ymax, lymax)
+}
+
+// Thumbnail draws a filled rectangle with the line
+// through its center, implementing the
+// plot.Thumbnailer interface.
+func (a *Area) Thumbnail(da *plot.DrawArea) {
+	fillThumbnail(da, a.FillColor)
+	if a.Width > 0 {
+		y := da.Center().Y
+		da.StrokeLine2(a.LineStyle, da.Min.X, y, da.Max().X, y)
+	}
+}
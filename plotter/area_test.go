@@ -0,0 +1,65 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"testing"
+)
+
+func TestAreaDataRange(t *testing.T) {
+	a, err := NewArea(XYs{{1, 2}, {3, 4}}, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := a.DataRange()
+	if xmin != 1 || xmax != 3 || ymin != -1 || ymax != 4 {
+		t.Errorf("DataRange() = %v, %v, %v, %v, want 1, 3, -1, 4", xmin, xmax, ymin, ymax)
+	}
+
+	a, err = NewAreaBetween(XYs{{1, 2}, {3, 4}}, XYs{{0, 1}, {2, 0.5}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax = a.DataRange()
+	if xmin != 0 || xmax != 3 || ymin != 0.5 || ymax != 4 {
+		t.Errorf("DataRange() = %v, %v, %v, %v, want 0, 3, 0.5, 4", xmin, xmax, ymin, ymax)
+	}
+}
+
+func TestStackAreas(t *testing.T) {
+	a := XYs{{0, 1}, {1, 2}}
+	b := XYs{{0, 3}, {1, 2}}
+	if _, err := StackAreas(ZeroBaseline, a, XYs{{0, 1}, {2, 1}}); err == nil {
+		t.Errorf("no error for layers with different X values")
+	}
+
+	for _, test := range []struct {
+		baseline StackBaseline
+		bottom   []float64
+		top      []float64
+	}{
+		{ZeroBaseline, []float64{0, 0}, []float64{4, 4}},
+		{CenteredBaseline, []float64{-2, -2}, []float64{2, 2}},
+	} {
+		areas, err := StackAreas(test.baseline, a, b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(areas) != 2 {
+			t.Fatalf("got %d areas, want 2", len(areas))
+		}
+		for j := range test.bottom {
+			if y := areas[0].Lower[j].Y; y != test.bottom[j] {
+				t.Errorf("baseline %d: bottom[%d] = %v, want %v", test.baseline, j, y, test.bottom[j])
+			}
+			if y := areas[1].XYs[j].Y; y != test.top[j] {
+				t.Errorf("baseline %d: top[%d] = %v, want %v", test.baseline, j, y, test.top[j])
+			}
+			if areas[1].Lower[j].Y != areas[0].XYs[j].Y {
+				t.Errorf("baseline %d: second layer is not stacked on the first", test.baseline)
+			}
+		}
+	}
+}
@@ -25,6 +25,11 @@ type BarChart struct {
 	// LineStyle is the style of the outline of the bars.
 	plot.LineStyle
 
+	// Hatch is the hatching drawn over the fill
+	// of the bars.  It distinguishes bar charts
+	// without relying on their colors.
+	Hatch plot.HatchStyle
+
 	// Offset is added to the x location of each bar.
 	// When the Offset is zero, the bars are drawn
 	// centered at their x location.
@@ -36,9 +41,42 @@ type BarChart struct {
 	// bar charts.
 	XMin float64
 
+	// Horizontal, if true, draws the bars along the
+	// X axis instead, from the Y locations at which
+	// the bars would otherwise be placed on the X
+	// axis, starting with XMin, and the Offset is
+	// added to the y location of each bar.  The
+	// categories of a horizontal bar chart can be
+	// named with the plot's NominalY method.  Bar
+	// charts that are stacked or grouped together
+	// should all be horizontal or all be vertical.
+	Horizontal bool
+
+	// ShowValues, if true, labels each bar with its
+	// value.  The label is centered on the bar beyond
+	// its end: above a bar with a positive value and
+	// below a bar with a negative value.
+	ShowValues bool
+
+	// ValuesInside, if true, draws the value labels
+	// inside of the ends of the bars instead.
+	ValuesInside bool
+
+	// ValueFormat formats the value labels.  If it
+	// is nil then the shortest representation of
+	// each value is used, in the plot.Numbers format.
+	ValueFormat func(float64) string
+
+	// ValueStyle is the style of the value labels.
+	ValueStyle plot.TextStyle
+
 	// stackedOn is the bar chart upon which
 	// this bar chart is stacked.
 	stackedOn *BarChart
+
+	// signed is true if the bars are stacked
+	// by the signs of their values.
+	signed bool
 }
 
 // NewBarChart returns a new bar chart with a single bar for each value.
@@ -52,14 +90,23 @@ func NewBarChart(vs Valuer, width vg.Length) (*BarChart, error) {
 	if err != nil {
 		return nil, err
 	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
 	return &BarChart{
-		Values:    values,
-		Width:     width,
-		Color:     color.Black,
-		LineStyle: DefaultLineStyle,
+		Values:     values,
+		Width:      width,
+		Color:      defaultColor{color.Black},
+		LineStyle:  DefaultLineStyle,
+		ValueStyle: plot.TextStyle{Color: color.Black, Font: fnt},
 	}, nil
 }
 
+// barValueGap is the distance between the end
+// of a bar and its value label.
+var barValueGap = vg.Points(2)
+
 // BarHeight returns the maximum y value of the
 // ith bar, taking into account any bars upon
 // which it is stacked.
@@ -84,37 +131,171 @@ func (b *BarChart) StackOn(on *BarChart) {
 	b.XMin = on.XMin
 	b.Offset = on.Offset
 	b.stackedOn = on
+	b.signed = false
+}
+
+// StackOnSigned is like StackOn, but stacks the bars by
+// the signs of their values: a bar with a non-negative
+// value is stacked up from the top of the non-negative
+// bars beneath it, and a bar with a negative value is
+// stacked down from the bottom of the negative bars
+// beneath it.
+func (b *BarChart) StackOnSigned(on *BarChart) {
+	b.StackOn(on)
+	b.signed = true
+}
+
+// base returns the y value from which the ith
+// bar is drawn.
+func (b *BarChart) base(i int) float64 {
+	if !b.signed {
+		return b.stackedOn.BarHeight(i)
+	}
+	return b.stackedOn.signedHeight(i, i < len(b.Values) && b.Values[i] < 0)
+}
+
+// signedHeight returns the sum of the ith values
+// of the bar chart and of those upon which it is
+// stacked that are negative, if neg is true, or
+// that are non-negative otherwise.
+func (b *BarChart) signedHeight(i int, neg bool) float64 {
+	if b == nil {
+		return 0
+	}
+	ht := b.stackedOn.signedHeight(i, neg)
+	if i >= 0 && i < len(b.Values) && (b.Values[i] < 0) == neg {
+		ht += b.Values[i]
+	}
+	return ht
+}
+
+// GroupBars lays out the bar charts side by side in
+// groups, such as for a grouped bar chart with a bar for
+// each series in each category.  The groups are at the
+// XMin of the first bar chart and at each X location
+// after it, and each is the given width: the bars of each
+// chart are given an equal share of the width and are
+// offset so that the group is centered on its location.
+// The X locations of the groups, for the tick marks of a
+// nominal X axis, are returned.  The groups of horizontal
+// bar charts are laid out in the same way along the Y axis.
+//
+// Bar charts that are stacked on another are drawn with
+// it, so only the bottom chart of each stack should be
+// given, and the stacked charts laid out with StackOn
+// afterwards.
+func GroupBars(width vg.Length, bars ...*BarChart) []float64 {
+	if len(bars) == 0 {
+		return nil
+	}
+	n := 0
+	w := width / vg.Length(len(bars))
+	for i, b := range bars {
+		b.XMin = bars[0].XMin
+		b.Width = w
+		b.Offset = (vg.Length(i) - vg.Length(len(bars)-1)/2) * w
+		if len(b.Values) > n {
+			n = len(b.Values)
+		}
+	}
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = bars[0].XMin + float64(i)
+	}
+	return xs
 }
 
 // Plot implements the plot.Plotter interface.
 func (b *BarChart) Plot(da plot.DrawArea, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&da)
+	trCat, trVal := trX, trY
+	inCat, inVal := da.ContainsX, da.ContainsY
+	// The bars are clipped only along the value axis,
+	// so that the bars at the ends of the category
+	// axis are drawn whole, in the padding made for
+	// them by GlyphBoxes.
+	clipPoly, clipLines := da.ClipPolygonY, da.ClipLinesY
+	if b.Horizontal {
+		trCat, trVal = trY, trX
+		inCat, inVal = da.ContainsY, da.ContainsX
+		clipPoly, clipLines = da.ClipPolygonX, da.ClipLinesX
+	}
+	// pt returns the point at the given distances
+	// along the category and value axes.
+	pt := func(c, v vg.Length) plot.Point {
+		if b.Horizontal {
+			return plot.Pt(v, c)
+		}
+		return plot.Pt(c, v)
+	}
 
 	for i, ht := range b.Values {
-		x := b.XMin + float64(i)
-		xmin := trX(float64(x))
-		if !da.ContainsX(xmin) {
+		c := b.XMin + float64(i)
+		cmin := trCat(float64(c))
+		if !inCat(cmin) {
 			continue
 		}
-		xmin = xmin - b.Width/2 + b.Offset
-		xmax := xmin + b.Width
-		bottom := b.stackedOn.BarHeight(i)
-		ymin := trY(bottom)
-		ymax := trY(bottom + ht)
+		cmin = cmin - b.Width/2 + b.Offset
+		cmax := cmin + b.Width
+		bottom := b.base(i)
+		vmin := trVal(bottom)
+		vmax := trVal(bottom + ht)
 
 		pts := []plot.Point{
-			{xmin, ymin},
-			{xmin, ymax},
-			{xmax, ymax},
-			{xmax, ymin},
+			pt(cmin, vmin),
+			pt(cmin, vmax),
+			pt(cmax, vmax),
+			pt(cmax, vmin),
 		}
-		poly := da.ClipPolygonY(pts)
+		poly := clipPoly(pts)
 		da.FillPolygon(b.Color, poly)
+		da.FillHatch(b.Hatch, poly)
 
-		pts = append(pts, plot.Pt(xmin, ymin))
-		outline := da.ClipLinesY(pts)
+		pts = append(pts, pts[0])
+		outline := clipLines(pts)
 		da.StrokeLines(b.LineStyle, outline...)
+
+		if b.ShowValues && inVal(vmax) {
+			v, align := b.valueLabelPos(vmax, ht)
+			if b.Horizontal {
+				da.FillText(b.ValueStyle, v, cmin+b.Width/2, align, -0.5, b.valueLabel(ht))
+			} else {
+				da.FillText(b.ValueStyle, cmin+b.Width/2, v, -0.5, align, b.valueLabel(ht))
+			}
+		}
+	}
+}
+
+// valueLabel returns the label of a bar's value.
+func (b *BarChart) valueLabel(v float64) string {
+	if b.ValueFormat != nil {
+		return b.ValueFormat(v)
+	}
+	return plot.FormatFloat(v, 'g', -1, 64)
+}
+
+// valueLabelPos returns the location along the value
+// axis, and the alignment, of the value label of a bar
+// with the given value, whose end is at the location
+// end.  The label is placed on the side of the end away
+// from the base of the bar, or towards it if
+// ValuesInside is set.
+func (b *BarChart) valueLabelPos(end vg.Length, v float64) (y vg.Length, yalign float64) {
+	if (v >= 0) != b.ValuesInside {
+		return end + barValueGap, 0
+	}
+	return end - barValueGap, -1
+}
+
+// AutoColor sets the fill color of the bars if it
+// is the default color, implementing the
+// plot.AutoColorer interface.
+func (b *BarChart) AutoColor(c color.Color) bool {
+	if !unsetColor(b.Color) {
+		return false
 	}
+	b.Color = c
+	return true
 }
 
 // DataRange implements the plot.DataRanger interface.
@@ -125,24 +306,74 @@ func (b *BarChart) DataRange() (xmin, xmax, ymin, ymax float64) {
 	ymin = math.Inf(1)
 	ymax = math.Inf(-1)
 	for i, y := range b.Values {
-		ybot := b.stackedOn.BarHeight(i)
+		ybot := b.base(i)
 		ytop := ybot + y
 		ymin = math.Min(ymin, math.Min(ybot, ytop))
 		ymax = math.Max(ymax, math.Max(ybot, ytop))
 	}
+	if b.Horizontal {
+		return ymin, ymax, xmin, xmax
+	}
 	return
 }
 
 // GlyphBoxes implements the GlyphBoxer interface.
+// The boxes include the outlines of the bars.
 func (b *BarChart) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	boxes := make([]plot.GlyphBox, len(b.Values))
 	for i := range b.Values {
-		x := b.XMin + float64(i)
-		boxes[i].X = plt.X.Norm(x)
+		c := b.XMin + float64(i)
+		min, size := b.Offset-b.Width/2-b.LineStyle.Width/2, b.Width+b.LineStyle.Width
+		if b.Horizontal {
+			boxes[i].Y = plt.Y.Norm(c)
+			boxes[i].Rect = plot.Rect{
+				Min:  plot.Point{Y: min},
+				Size: plot.Point{Y: size},
+			}
+			continue
+		}
+		boxes[i].X = plt.X.Norm(c)
 		boxes[i].Rect = plot.Rect{
-			Min:  plot.Point{X: b.Offset - b.Width/2},
-			Size: plot.Point{X: b.Width},
+			Min:  plot.Point{X: min},
+			Size: plot.Point{X: size},
+		}
+	}
+	if !b.ShowValues || b.ValuesInside {
+		return boxes
+	}
+	// Make room for the labels beyond the
+	// ends of the bars.
+	for i, v := range b.Values {
+		label := b.valueLabel(v)
+		w, h := b.ValueStyle.Width(label), b.ValueStyle.Height(label)
+		c, end := b.XMin+float64(i), b.base(i)+v
+		if b.Horizontal {
+			box := plot.GlyphBox{
+				X: plt.X.Norm(end),
+				Y: plt.Y.Norm(c),
+				Rect: plot.Rect{
+					Min:  plot.Point{X: barValueGap, Y: b.Offset - h/2},
+					Size: plot.Point{X: w, Y: h},
+				},
+			}
+			if v < 0 {
+				box.Rect.Min.X = -barValueGap - w
+			}
+			boxes = append(boxes, box)
+			continue
+		}
+		box := plot.GlyphBox{
+			X: plt.X.Norm(c),
+			Y: plt.Y.Norm(end),
+			Rect: plot.Rect{
+				Min:  plot.Point{X: b.Offset - w/2, Y: barValueGap},
+				Size: plot.Point{X: w, Y: h},
+			},
+		}
+		if v < 0 {
+			box.Rect.Min.Y = -barValueGap - h
 		}
+		boxes = append(boxes, box)
 	}
 	return boxes
 }
@@ -156,6 +387,7 @@ func (b *BarChart) Thumbnail(da *plot.DrawArea) {
 	}
 	poly := da.ClipPolygonY(pts)
 	da.FillPolygon(b.Color, poly)
+	da.FillHatch(b.Hatch, poly)
 
 	pts = append(pts, plot.Pt(da.Min.X, da.Min.Y))
 	outline := da.ClipLinesY(pts)
@@ -0,0 +1,211 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+func TestStackOnSigned(t *testing.T) {
+	a, err := NewBarChart(Values{2, -1}, vg.Points(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := NewBarChart(Values{-3, -2}, vg.Points(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c, err := NewBarChart(Values{1, 4}, vg.Points(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b.StackOnSigned(a)
+	c.StackOnSigned(b)
+
+	for _, test := range []struct {
+		bars *BarChart
+		want []float64
+	}{
+		{a, []float64{0, 0}},
+		{b, []float64{0, -1}},
+		{c, []float64{2, 0}},
+	} {
+		for i, want := range test.want {
+			if got := test.bars.base(i); got != want {
+				t.Errorf("base(%d) = %v, want %v", i, got, want)
+			}
+		}
+	}
+	if _, _, ymin, ymax := c.DataRange(); ymin != 0 || ymax != 4 {
+		t.Errorf("DataRange() y = %v, %v, want 0, 4", ymin, ymax)
+	}
+	if _, _, ymin, _ := b.DataRange(); ymin != -3 {
+		t.Errorf("DataRange() ymin = %v, want -3", ymin)
+	}
+}
+
+func TestGroupBars(t *testing.T) {
+	var bars []*BarChart
+	for _, vs := range []Values{{1, 2}, {3, 4, 5}, {6}} {
+		b, err := NewBarChart(vs, vg.Points(1))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		bars = append(bars, b)
+	}
+	bars[0].XMin = 2
+	xs := GroupBars(vg.Points(30), bars...)
+	if len(xs) != 3 || xs[0] != 2 || xs[2] != 4 {
+		t.Errorf("GroupBars returned %v, want [2 3 4]", xs)
+	}
+	for i, b := range bars {
+		if b.Width != vg.Points(10) {
+			t.Errorf("bar chart %d has width %v, want %v", i, b.Width, vg.Points(10))
+		}
+		if want := vg.Points(float64(10 * (i - 1))); b.Offset != want {
+			t.Errorf("bar chart %d has offset %v, want %v", i, b.Offset, want)
+		}
+		if b.XMin != 2 {
+			t.Errorf("bar chart %d has XMin %v, want 2", i, b.XMin)
+		}
+	}
+}
+
+func TestHorizontalBarChart(t *testing.T) {
+	b, err := NewBarChart(Values{2, -1, 4}, vg.Points(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b.XMin = 1
+	b.Horizontal = true
+	xmin, xmax, ymin, ymax := b.DataRange()
+	if xmin != -1 || xmax != 4 || ymin != 1 || ymax != 3 {
+		t.Errorf("DataRange() = %v, %v, %v, %v, want -1, 4, 1, 3", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(b)
+	for _, box := range b.GlyphBoxes(p) {
+		if box.Size.X != 0 || box.Size.Y != b.Width+b.LineStyle.Width {
+			t.Errorf("glyph box size = %v, want the bar width along Y", box.Size)
+		}
+	}
+}
+
+// traceBarSpans traces the plot and returns the least
+// and greatest coordinates of each bar that the bar
+// chart fills, along the Y axis if horizontal is true
+// and along the X axis otherwise.
+func traceBarSpans(t *testing.T, p *plot.Plot, b *BarChart, horizontal bool) [][2]float64 {
+	prims, err := p.Trace(vg.Inches(4), vg.Inches(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var spans [][2]float64
+	for _, prim := range prims {
+		if prim.Plotter != plot.Plotter(b) || prim.Kind != plot.TracedFill {
+			continue
+		}
+		cs := prim.X
+		if horizontal {
+			cs = prim.Y
+		}
+		s := [2]float64{math.Inf(1), math.Inf(-1)}
+		for _, c := range cs {
+			s[0], s[1] = math.Min(s[0], c), math.Max(s[1], c)
+		}
+		spans = append(spans, s)
+	}
+	return spans
+}
+
+func TestBarChartEnds(t *testing.T) {
+	b, err := NewBarChart(Values{1, 2, 3}, vg.Points(20))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(b)
+	checkBarEnds(t, traceBarSpans(t, p, b, false))
+
+	b.Horizontal = true
+	p, err = plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(b)
+	checkBarEnds(t, traceBarSpans(t, p, b, true))
+}
+
+// checkBarEnds checks that the bars at 0, 1 and 2 are
+// centered on their locations and that the bars at the
+// ends of the axis, half of which are in the padding
+// beyond the axis, are as wide as the bar in the middle.
+func checkBarEnds(t *testing.T, spans [][2]float64) {
+	if len(spans) != 3 {
+		t.Fatalf("traced %d bars, want 3", len(spans))
+	}
+	const tol = 1e-9
+	w := spans[1][1] - spans[1][0]
+	for i, s := range spans {
+		if math.Abs(s[1]-s[0]-w) > tol || math.Abs(s[0]+s[1]-2*float64(i)) > tol {
+			t.Errorf("bar %d spans [%g, %g], want it %g wide centered on %d", i, s[0], s[1], w, i)
+		}
+	}
+}
+
+func TestGroupedBarChart(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var bars []*BarChart
+	for _, vs := range []Values{{1, 2, 3}, {2, 3, 1}, {3, 1, 2}} {
+		b, err := NewBarChart(vs, vg.Points(1))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		bars = append(bars, b)
+		p.Add(b)
+	}
+	GroupBars(vg.Points(45), bars...)
+
+	// Each bar is a third of the width of its group,
+	// and offset from the center of the group by that
+	// width times its place in the group.  The outer
+	// bars of the first and last groups are entirely
+	// beyond the ends of the axis.  The width is
+	// that of the bar in the middle of the chart.
+	mid := traceBarSpans(t, p, bars[1], false)
+	if len(mid) != 3 {
+		t.Fatalf("middle bar chart drew %d bars, want 3", len(mid))
+	}
+	w := mid[1][1] - mid[1][0]
+	const tol = 1e-9
+	for i, b := range bars {
+		spans := traceBarSpans(t, p, b, false)
+		if len(spans) != len(b.Values) {
+			t.Errorf("bar chart %d drew %d bars, want %d", i, len(spans), len(b.Values))
+			continue
+		}
+		for j, s := range spans {
+			center := float64(j) + float64(i-1)*w
+			if math.Abs(s[1]-s[0]-w) > tol || math.Abs((s[0]+s[1])/2-center) > tol {
+				t.Errorf("bar %d of chart %d spans [%g, %g], want it %g wide centered on %g",
+					j, i, s[0], s[1], w, center)
+			}
+		}
+	}
+}
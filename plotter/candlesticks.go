@@ -0,0 +1,199 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// OHLCer wraps the Len and OHLC methods.
+type OHLCer interface {
+	// Len returns the number of periods.
+	Len() int
+
+	// OHLC returns the open, high, low and
+	// close values of a period.
+	OHLC(int) (open, high, low, close float64)
+}
+
+// OHLCs implements the OHLCer interface using a slice.
+type OHLCs []struct{ Open, High, Low, Close float64 }
+
+// Len implements the Len method of the OHLCer interface.
+func (o OHLCs) Len() int {
+	return len(o)
+}
+
+// OHLC implements the OHLC method of the OHLCer interface.
+func (o OHLCs) OHLC(i int) (open, high, low, close float64) {
+	return o[i].Open, o[i].High, o[i].Low, o[i].Close
+}
+
+// Candlesticks implements the Plotter interface, drawing
+// a candlestick chart: for each period, a wick from its
+// low to its high value and a body between its open and
+// close values.  The body of a period that closes at or
+// above its open value is filled with UpColor, and that
+// of a period that closes below it with DownColor.
+type Candlesticks struct {
+	// OHLCs is a copy of the values of the periods.
+	OHLCs
+
+	// Times are the X values of the periods.
+	// If Times is nil then the ith period is
+	// at the X value i.
+	Times Values
+
+	// Width is the width of the bodies.
+	Width vg.Length
+
+	// UpColor and DownColor are the colors of
+	// the bodies of rising and falling periods.
+	UpColor, DownColor color.Color
+
+	// WickStyle is the style of the wicks and of
+	// the outlines of the bodies.  If its color is
+	// nil then each wick has the color of its body.
+	WickStyle plot.LineStyle
+}
+
+// NewCandlesticks returns Candlesticks of the periods,
+// with green rising and red falling bodies.  If times is
+// nil then the periods are at the X values 0, 1, 2 and
+// so on.  An error is returned if the number of times
+// differs from the number of periods, or if the low or
+// the high value of a period is not the lowest or the
+// highest of its values.
+func NewCandlesticks(data OHLCer, times Valuer) (*Candlesticks, error) {
+	ohlcs := make(OHLCs, data.Len())
+	for i := range ohlcs {
+		o, h, l, c := data.OHLC(i)
+		if err := CheckFloats(o, h, l, c); err != nil {
+			return nil, err
+		}
+		if l > math.Min(o, c) || h < math.Max(o, c) {
+			return nil, errors.New("Candlestick low or high is not the extreme value")
+		}
+		ohlcs[i].Open, ohlcs[i].High, ohlcs[i].Low, ohlcs[i].Close = o, h, l, c
+	}
+	var ts Values
+	if times != nil {
+		if times.Len() != len(ohlcs) {
+			return nil, errors.New("Number of times does not match number of periods")
+		}
+		var err error
+		if ts, err = CopyValues(times); err != nil {
+			return nil, err
+		}
+	}
+	return &Candlesticks{
+		OHLCs:     ohlcs,
+		Times:     ts,
+		Width:     vg.Points(6),
+		UpColor:   color.RGBA{G: 160, A: 255},
+		DownColor: color.RGBA{R: 200, A: 255},
+		WickStyle: plot.LineStyle{Width: vg.Points(1)},
+	}, nil
+}
+
+// time returns the X value of the ith period.
+func (c *Candlesticks) time(i int) float64 {
+	if c.Times == nil {
+		return float64(i)
+	}
+	return c.Times[i]
+}
+
+// bodyColor returns the color of the body of the ith period.
+func (c *Candlesticks) bodyColor(i int) color.Color {
+	if c.OHLCs[i].Close < c.OHLCs[i].Open {
+		return c.DownColor
+	}
+	return c.UpColor
+}
+
+// Plot draws the candlesticks, implementing the
+// plot.Plotter interface.
+func (c *Candlesticks) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	for i, p := range c.OHLCs {
+		x := trX(c.time(i))
+		if !da.ContainsX(x) {
+			continue
+		}
+		sty := c.WickStyle
+		if sty.Color == nil {
+			sty.Color = c.bodyColor(i)
+		}
+		lo, hi := trY(p.Low), trY(p.High)
+		da.StrokeLines(sty, da.ClipLinesY([]plot.Point{{x, lo}, {x, hi}})...)
+
+		bot, top := trY(math.Min(p.Open, p.Close)), trY(math.Max(p.Open, p.Close))
+		l, r := x-c.Width/2, x+c.Width/2
+		if bot == top {
+			// A period that closes at its open
+			// value is drawn as a line across
+			// the wick.
+			da.StrokeLines(sty, da.ClipLinesY([]plot.Point{{l, bot}, {r, bot}})...)
+			continue
+		}
+		body := []plot.Point{{l, bot}, {r, bot}, {r, top}, {l, top}}
+		da.FillPolygon(c.bodyColor(i), da.ClipPolygonY(body))
+		da.StrokeLines(sty, da.ClipLinesY(append(body, body[0]))...)
+	}
+}
+
+// DataRange returns the range of the times and the
+// lowest low and highest high values, implementing
+// the plot.DataRanger interface.
+func (c *Candlesticks) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for i, p := range c.OHLCs {
+		xmin = math.Min(xmin, c.time(i))
+		xmax = math.Max(xmax, c.time(i))
+		ymin = math.Min(ymin, p.Low)
+		ymax = math.Max(ymax, p.High)
+	}
+	return
+}
+
+// GlyphBoxes returns a GlyphBox for the body of each
+// period, so that the bodies at the ends of the X axis
+// are not clipped, implementing the plot.GlyphBoxer
+// interface.
+func (c *Candlesticks) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(c.OHLCs))
+	for i, p := range c.OHLCs {
+		bs[i].X = plt.X.Norm(c.time(i))
+		bs[i].Y = plt.Y.Norm((p.Open + p.Close) / 2)
+		bs[i].Rect = plot.Rect{
+			Min:  plot.Point{X: -(c.Width/2 + c.WickStyle.Width/2)},
+			Size: plot.Point{X: c.Width + c.WickStyle.Width},
+		}
+	}
+	return bs
+}
+
+// Thumbnail draws a rising candlestick, implementing
+// the plot.Thumbnailer interface.
+func (c *Candlesticks) Thumbnail(da *plot.DrawArea) {
+	x := da.Center().X
+	sty := c.WickStyle
+	if sty.Color == nil {
+		sty.Color = c.UpColor
+	}
+	da.StrokeLine2(sty, x, da.Min.Y, x, da.Max().Y)
+	bot, top := da.Min.Y+da.Size.Y/4, da.Max().Y-da.Size.Y/4
+	l, r := x-c.Width/2, x+c.Width/2
+	body := []plot.Point{{l, bot}, {r, bot}, {r, top}, {l, top}}
+	da.FillPolygon(c.UpColor, body)
+	da.StrokeLines(sty, append(body, body[0]))
+}
@@ -0,0 +1,43 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"testing"
+)
+
+func TestCandlesticks(t *testing.T) {
+	data := OHLCs{
+		{Open: 10, High: 12, Low: 9, Close: 11},
+		{Open: 11, High: 11.5, Low: 7, Close: 8},
+		{Open: 8, High: 13, Low: 8, Close: 8},
+	}
+	if _, err := NewCandlesticks(OHLCs{{Open: 10, High: 9, Low: 8, Close: 8.5}}, nil); err == nil {
+		t.Errorf("no error for a high below the open value")
+	}
+	if _, err := NewCandlesticks(data, Values{1, 2}); err == nil {
+		t.Errorf("no error for too few times")
+	}
+
+	c, err := NewCandlesticks(data, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := c.DataRange()
+	if xmin != 0 || xmax != 2 || ymin != 7 || ymax != 13 {
+		t.Errorf("DataRange() = %v, %v, %v, %v, want 0, 2, 7, 13", xmin, xmax, ymin, ymax)
+	}
+	if c.bodyColor(0) != c.UpColor || c.bodyColor(1) != c.DownColor || c.bodyColor(2) != c.UpColor {
+		t.Errorf("wrong body colors")
+	}
+
+	c, err = NewCandlesticks(data, Values{100, 101, 103})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if xmin, xmax, _, _ := c.DataRange(); xmin != 100 || xmax != 103 {
+		t.Errorf("X range = %v, %v, want 100, 103", xmin, xmax)
+	}
+}
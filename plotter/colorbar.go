@@ -0,0 +1,313 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ColorBar implements the Plotter interface, drawing
+// a strip showing the mapping from values to the
+// colors of a palette.
+//
+// A ColorBar fills the data area of its plot, and
+// the axis along which it is drawn gives its scale.
+// It is intended to be added to its own plot, which
+// is drawn beside the plot whose colors it describes.
+// For example, a vertical ColorBar may be drawn to a
+// narrow DrawArea along the right side of a canvas
+// with the X axis of its plot hidden.
+//
+// A ColorBar with a Position of ColorBarRight or
+// ColorBarBottom is instead added to the plot whose
+// colors it describes, such as with a HeatMap.  It is
+// drawn beside the data area, with its own tick marks
+// and labels, and its GlyphBoxes reserve space for it
+// as for an axis.
+type ColorBar struct {
+	// Colors is the palette.  The range of values
+	// is divided into len(Colors) equal parts, the
+	// first color is used for the lowest part and
+	// the last color for the highest.
+	Colors []color.Color
+
+	// Boundaries, if non-nil, are the values that
+	// separate the colors, as in a DiscretePalette,
+	// instead of equal parts of the range: Colors[i]
+	// is drawn from Boundaries[i] to Boundaries[i+1].
+	Boundaries []float64
+
+	// Min and Max are the range of values
+	// described by the color bar.
+	Min, Max float64
+
+	// Vertical specifies that the values
+	// increase up the Y axis.  Otherwise they
+	// increase along the X axis.  It is used
+	// only for a ColorBar that fills the data
+	// area.
+	Vertical bool
+
+	// Position is where the color bar is drawn.
+	Position ColorBarPosition
+
+	// Width is the width across a color bar
+	// that is drawn beside the data area, and
+	// Padding is the distance between it and
+	// the data area.
+	Width, Padding vg.Length
+
+	// Ticks returns the tick marks of a color bar
+	// that is drawn beside the data area.  If
+	// Ticks is nil then plot.DefaultTicks is used.
+	// The tick marks and labels are drawn in the
+	// style of the Y axis tick marks for a bar on
+	// the right, and of the X axis for a bar at
+	// the bottom.
+	Ticks func(min, max float64) []plot.Tick
+}
+
+// A ColorBarPosition is where a ColorBar is drawn.
+type ColorBarPosition int
+
+const (
+	// ColorBarFill fills the data area of the plot,
+	// along the axis selected by Vertical.
+	ColorBarFill ColorBarPosition = iota
+
+	// ColorBarRight draws a vertical color bar to
+	// the right of the data area.
+	ColorBarRight
+
+	// ColorBarBottom draws a horizontal color bar
+	// below the data area.
+	ColorBarBottom
+)
+
+// NewColorBar returns a new horizontal ColorBar for
+// the given palette and range of values.
+func NewColorBar(colors []color.Color, min, max float64) (*ColorBar, error) {
+	if len(colors) == 0 {
+		return nil, errors.New("No colors in the palette")
+	}
+	if err := CheckFloats(min, max); err != nil {
+		return nil, err
+	}
+	if min > max {
+		return nil, errors.New("Color bar minimum is greater than the maximum")
+	}
+	return newColorBar(colors, min, max), nil
+}
+
+// newColorBar returns a new horizontal ColorBar
+// without checking its arguments.
+func newColorBar(colors []color.Color, min, max float64) *ColorBar {
+	return &ColorBar{
+		Colors:  colors,
+		Min:     min,
+		Max:     max,
+		Width:   vg.Points(10),
+		Padding: vg.Points(10),
+	}
+}
+
+// band returns the range of values of the
+// ith color.
+func (c *ColorBar) band(i int) (lo, hi float64) {
+	if c.Boundaries != nil {
+		return c.Boundaries[i], c.Boundaries[i+1]
+	}
+	step := (c.Max - c.Min) / float64(len(c.Colors))
+	lo = c.Min + float64(i)*step
+	return lo, lo + step
+}
+
+// norm returns the location of a value along a
+// color bar that is drawn beside the data area,
+// from 0 at Min to 1 at Max.
+func (c *ColorBar) norm(v float64) float64 {
+	if c.Max == c.Min {
+		return 0
+	}
+	return (v - c.Min) / (c.Max - c.Min)
+}
+
+// ticks returns the tick marks of a color bar
+// that is drawn beside the data area.
+func (c *ColorBar) ticks() []plot.Tick {
+	marker := c.Ticks
+	if marker == nil {
+		marker = plot.DefaultTicks
+	}
+	var ticks []plot.Tick
+	for _, t := range marker(c.Min, c.Max) {
+		if t.Value >= c.Min && t.Value <= c.Max {
+			ticks = append(ticks, t)
+		}
+	}
+	return ticks
+}
+
+// tickStyle returns the style of the axis whose
+// tick marks are used by a color bar that is
+// drawn beside the data area.
+func (c *ColorBar) tickStyle(plt *plot.Plot) (label plot.TextStyle, line plot.LineStyle, length vg.Length) {
+	a := plt.Y
+	if c.Position == ColorBarBottom {
+		a = plt.X
+	}
+	return a.Tick.Label, a.Tick.LineStyle, a.Tick.Length
+}
+
+// tickLength returns the length of a tick mark.
+// Minor tick marks are half as long as major ones.
+func tickLength(t plot.Tick, length vg.Length) vg.Length {
+	if t.IsMinor() {
+		return length / 2
+	}
+	return length
+}
+
+// Plot implements the plot.Plotter interface.
+func (c *ColorBar) Plot(da plot.DrawArea, plt *plot.Plot) {
+	switch c.Position {
+	case ColorBarRight:
+		c.plotRight(da, plt)
+		return
+	case ColorBarBottom:
+		c.plotBottom(da, plt)
+		return
+	}
+	trX, trY := plt.Transforms(&da)
+	for i, clr := range c.Colors {
+		lo, hi := c.band(i)
+		var pts []plot.Point
+		if c.Vertical {
+			pts = []plot.Point{
+				{da.Min.X, trY(lo)},
+				{da.Max().X, trY(lo)},
+				{da.Max().X, trY(hi)},
+				{da.Min.X, trY(hi)},
+			}
+		} else {
+			pts = []plot.Point{
+				{trX(lo), da.Min.Y},
+				{trX(hi), da.Min.Y},
+				{trX(hi), da.Max().Y},
+				{trX(lo), da.Max().Y},
+			}
+		}
+		da.FillPolygon(clr, da.ClipPolygonXY(pts))
+	}
+}
+
+// plotRight draws the color bar to the right of the
+// data area, with its tick marks and labels to the
+// right of the bar.
+func (c *ColorBar) plotRight(da plot.DrawArea, plt *plot.Plot) {
+	x0 := da.Max().X + c.Padding
+	x1 := x0 + c.Width
+	y := func(v float64) vg.Length { return da.Y(c.norm(v)) }
+	for i, clr := range c.Colors {
+		lo, hi := c.band(i)
+		da.FillPolygon(clr, []plot.Point{{x0, y(lo)}, {x1, y(lo)}, {x1, y(hi)}, {x0, y(hi)}})
+	}
+	label, line, length := c.tickStyle(plt)
+	for _, t := range c.ticks() {
+		ty := y(t.Value)
+		da.StrokeLine2(line, x1, ty, x1+tickLength(t, length), ty)
+		if !t.IsMinor() {
+			da.FillText(label, x1+length+label.Width(" "), ty, 0, -0.5, t.Label)
+		}
+	}
+}
+
+// plotBottom draws the color bar below the data
+// area, with its tick marks and labels below the
+// bar.
+func (c *ColorBar) plotBottom(da plot.DrawArea, plt *plot.Plot) {
+	y1 := da.Min.Y - c.Padding
+	y0 := y1 - c.Width
+	x := func(v float64) vg.Length { return da.X(c.norm(v)) }
+	for i, clr := range c.Colors {
+		lo, hi := c.band(i)
+		da.FillPolygon(clr, []plot.Point{{x(lo), y0}, {x(hi), y0}, {x(hi), y1}, {x(lo), y1}})
+	}
+	label, line, length := c.tickStyle(plt)
+	for _, t := range c.ticks() {
+		tx := x(t.Value)
+		da.StrokeLine2(line, tx, y0, tx, y0-tickLength(t, length))
+		if !t.IsMinor() {
+			da.FillText(label, tx, y0-length, -0.5, -1, t.Label)
+		}
+	}
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+// A color bar that is drawn beside the data area
+// returns a box that extends from the edge of the data
+// area past its tick labels, so that the plot reserves
+// space for it, and boxes for the labels that overhang
+// the ends of the bar.
+func (c *ColorBar) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if c.Position == ColorBarFill {
+		return nil
+	}
+	label, _, length := c.tickStyle(plt)
+	var boxes []plot.GlyphBox
+	var size vg.Length
+	for _, t := range c.ticks() {
+		if t.IsMinor() {
+			continue
+		}
+		if c.Position == ColorBarRight {
+			w := label.Width(" ") + label.Width(t.Label)
+			size = vg.Length(math.Max(float64(size), float64(w)))
+			h := label.Height(t.Label)
+			boxes = append(boxes, plot.GlyphBox{
+				Y:    c.norm(t.Value),
+				Rect: plot.Rect{Min: plot.Point{Y: -h / 2}, Size: plot.Point{Y: h}},
+			})
+			continue
+		}
+		size = vg.Length(math.Max(float64(size), float64(label.Height(t.Label))))
+		w := label.Width(t.Label)
+		boxes = append(boxes, plot.GlyphBox{
+			X:    c.norm(t.Value),
+			Rect: plot.Rect{Min: plot.Point{X: -w / 2}, Size: plot.Point{X: w}},
+		})
+	}
+	size += c.Padding + c.Width + length
+	if c.Position == ColorBarRight {
+		return append(boxes, plot.GlyphBox{
+			X:    1,
+			Rect: plot.Rect{Size: plot.Point{X: size}},
+		})
+	}
+	return append(boxes, plot.GlyphBox{
+		Rect: plot.Rect{Min: plot.Point{Y: -size}, Size: plot.Point{Y: size}},
+	})
+}
+
+// DataRange implements the plot.DataRanger interface.
+// The range along a color bar that fills the data area
+// is the range of its values, and the range across it
+// is [0, 1].  A color bar that is drawn beside the data
+// area does not change the ranges of the axes.
+func (c *ColorBar) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if c.Position != ColorBarFill {
+		inf := math.Inf(1)
+		return inf, -inf, inf, -inf
+	}
+	if c.Vertical {
+		return 0, 1, c.Min, c.Max
+	}
+	return c.Min, c.Max, 0, 1
+}
@@ -0,0 +1,55 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+)
+
+func TestColorBarPosition(t *testing.T) {
+	colors := []color.Color{color.White, color.Black}
+	for _, pos := range []ColorBarPosition{ColorBarRight, ColorBarBottom} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		s, err := NewScatter(XYs{{0, 0}, {10, 5}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(s)
+		c, err := NewColorBar(colors, -100, 100)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		c.Position = pos
+		p.Add(c)
+
+		if p.X.Min != 0 || p.X.Max != 10 || p.Y.Min != 0 || p.Y.Max != 5 {
+			t.Errorf("position %d: axis ranges changed to [%g, %g] by [%g, %g]",
+				pos, p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+		}
+
+		boxes := c.GlyphBoxes(p)
+		if len(boxes) == 0 {
+			t.Fatalf("position %d: no glyph boxes", pos)
+		}
+		b := boxes[len(boxes)-1]
+		min := c.Padding + c.Width
+		switch pos {
+		case ColorBarRight:
+			if b.X != 1 || b.Min.X != 0 || b.Size.X <= min || b.Size.Y != 0 {
+				t.Errorf("right color bar box is %+v, want one wider than %v at the right edge", b, min)
+			}
+		case ColorBarBottom:
+			if b.Y != 0 || b.Min.Y != -b.Size.Y || b.Size.Y <= min || b.Size.X != 0 {
+				t.Errorf("bottom color bar box is %+v, want one taller than %v below the bottom edge", b, min)
+			}
+		}
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// Contour implements the Plotter interface, drawing the
+// contour lines, or iso-lines, of gridded data: the lines
+// along which the data, interpolated linearly between
+// the grid points, equals each of a set of levels.
+type Contour struct {
+	// GridXYZ is the data of the contours.
+	GridXYZ
+
+	// Levels are the values at which the contour
+	// lines are drawn.
+	Levels []float64
+
+	// LineStyles are the styles of the lines of
+	// each level, in turn.  If there are fewer
+	// styles than levels then the styles are
+	// cycled.  If there are no styles then the
+	// lines are drawn in DefaultLineStyle.
+	LineStyles []plot.LineStyle
+
+	// Labels, if true, labels each contour line that
+	// is long enough with its level, written in its
+	// middle in a gap in the line.
+	Labels bool
+
+	// TextStyle is the style of the labels.
+	TextStyle plot.TextStyle
+
+	// lines are the contour lines of each level,
+	// computed when the Contour is made.
+	lines [][]XYs
+}
+
+// contourLabelGap is the space left between a label
+// and the ends of the gap in its line.
+var contourLabelGap = vg.Points(2)
+
+// NewContour returns a Contour of the grid at the given
+// levels, drawn with the default line style.  If levels
+// is nil then the levels are the labeled tick values of
+// plot.DefaultTicks within the range of the data.  The
+// contour lines are computed by NewContour, so the
+// grid's values should not be changed afterward.  Grid
+// cells with a NaN corner have no contour lines.
+func NewContour(g GridXYZ, levels []float64) (*Contour, error) {
+	c, r := g.Dims()
+	if c < 2 || r < 2 {
+		return nil, errors.New("Contour grid needs at least two columns and rows")
+	}
+	if levels == nil {
+		levels = autoLevels(g)
+	}
+	if err := CheckFloats(levels...); err != nil {
+		return nil, err
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	ct := &Contour{
+		GridXYZ:    g,
+		Levels:     append([]float64(nil), levels...),
+		LineStyles: []plot.LineStyle{DefaultLineStyle},
+		TextStyle:  plot.TextStyle{Color: color.Black, Font: fnt},
+	}
+	ct.lines = make([][]XYs, len(levels))
+	for i, l := range levels {
+		ct.lines[i] = contourLines(g, l)
+	}
+	return ct, nil
+}
+
+// autoLevels returns the labeled values of the
+// default tick marks that are strictly within the
+// range of the finite values of the grid.
+func autoLevels(g GridXYZ) []float64 {
+	c, r := g.Dims()
+	min, max := math.Inf(1), math.Inf(-1)
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			if v := g.Z(i, j); !math.IsNaN(v) && !math.IsInf(v, 0) {
+				min, max = math.Min(min, v), math.Max(max, v)
+			}
+		}
+	}
+	if !(min < max) {
+		return []float64{}
+	}
+	var levels []float64
+	for _, t := range plot.DefaultTicks(min, max) {
+		if !t.IsMinor() && t.Value > min && t.Value < max {
+			levels = append(levels, t.Value)
+		}
+	}
+	return levels
+}
+
+// A gridEdge is an edge between two neighboring grid
+// points: from column c, row r to the next column if
+// vertical is false, or to the next row if it is true.
+type gridEdge struct {
+	c, r     int
+	vertical bool
+}
+
+// contourLines returns the contour lines of the grid at
+// the given level.  The grid is traced by marching
+// squares: the line crosses each edge of a cell whose
+// ends are on opposite sides of the level, at the place
+// where linear interpolation gives the level, and the
+// crossings of each cell are joined by segments.  The
+// segments are then joined into lines at the edges
+// that they share.
+func contourLines(g GridXYZ, level float64) []XYs {
+	c, r := g.Dims()
+	var segs [][2]gridEdge
+	for i := 0; i+1 < c; i++ {
+		for j := 0; j+1 < r; j++ {
+			segs = append(segs, cellSegments(g, i, j, level)...)
+		}
+	}
+
+	// ends maps each crossed edge to the
+	// segments that end at it.
+	ends := make(map[gridEdge][]int)
+	for k, s := range segs {
+		ends[s[0]] = append(ends[s[0]], k)
+		ends[s[1]] = append(ends[s[1]], k)
+	}
+	used := make([]bool, len(segs))
+	next := func(e gridEdge) (gridEdge, bool) {
+		for _, k := range ends[e] {
+			if used[k] {
+				continue
+			}
+			used[k] = true
+			if segs[k][0] == e {
+				return segs[k][1], true
+			}
+			return segs[k][0], true
+		}
+		return gridEdge{}, false
+	}
+
+	var lines []XYs
+	for k, s := range segs {
+		if used[k] {
+			continue
+		}
+		used[k] = true
+		path := []gridEdge{s[0], s[1]}
+		for e, ok := next(s[1]); ok; e, ok = next(e) {
+			path = append(path, e)
+		}
+		var back []gridEdge
+		for e, ok := next(s[0]); ok; e, ok = next(e) {
+			back = append(back, e)
+		}
+		for i, j := 0, len(back)-1; i < j; i, j = i+1, j-1 {
+			back[i], back[j] = back[j], back[i]
+		}
+		path = append(back, path...)
+
+		line := make(XYs, len(path))
+		for i, e := range path {
+			line[i].X, line[i].Y = crossing(g, e, level)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// cellSegments returns the segments of the contour at
+// the level within the cell whose lower left corner is
+// at column c, row r, as the pairs of edges that they
+// join.
+func cellSegments(g GridXYZ, c, r int, level float64) [][2]gridEdge {
+	z := [4]float64{g.Z(c, r), g.Z(c+1, r), g.Z(c+1, r+1), g.Z(c, r+1)}
+	var code int
+	for i, v := range z {
+		if math.IsNaN(v) {
+			return nil
+		}
+		if v > level {
+			code |= 1 << uint(i)
+		}
+	}
+	bottom := gridEdge{c, r, false}
+	right := gridEdge{c + 1, r, true}
+	top := gridEdge{c, r + 1, false}
+	left := gridEdge{c, r, true}
+
+	switch code {
+	case 0, 15:
+		return nil
+	case 1, 14:
+		return [][2]gridEdge{{left, bottom}}
+	case 2, 13:
+		return [][2]gridEdge{{bottom, right}}
+	case 3, 12:
+		return [][2]gridEdge{{left, right}}
+	case 4, 11:
+		return [][2]gridEdge{{right, top}}
+	case 6, 9:
+		return [][2]gridEdge{{bottom, top}}
+	case 7, 8:
+		return [][2]gridEdge{{left, top}}
+	}
+
+	// The saddles, in which opposite corners are on the
+	// same side of the level, are resolved by the mean
+	// of the corners, which is taken as the value at the
+	// center of the cell.
+	center := (z[0]+z[1]+z[2]+z[3])/4 > level
+	if (code == 5) == center {
+		return [][2]gridEdge{{left, top}, {bottom, right}}
+	}
+	return [][2]gridEdge{{left, bottom}, {right, top}}
+}
+
+// crossing returns the place on the edge at which the
+// linear interpolation of the grid equals the level.
+func crossing(g GridXYZ, e gridEdge, level float64) (x, y float64) {
+	c1, r1 := e.c+1, e.r
+	if e.vertical {
+		c1, r1 = e.c, e.r+1
+	}
+	z0, z1 := g.Z(e.c, e.r), g.Z(c1, r1)
+	t := (level - z0) / (z1 - z0)
+	x0, y0 := g.X(e.c), g.Y(e.r)
+	return x0 + t*(g.X(c1)-x0), y0 + t*(g.Y(r1)-y0)
+}
+
+// Lines returns the contour lines of the ith level, in
+// data coordinates.  A closed line ends at its first
+// point.
+func (ct *Contour) Lines(i int) []XYs {
+	return ct.lines[i]
+}
+
+// Plot draws the contour lines, implementing the
+// plot.Plotter interface.
+func (ct *Contour) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	for i, lines := range ct.lines {
+		sty := ct.lineStyle(i)
+		label := plot.FormatFloat(ct.Levels[i], 'g', -1, 32)
+		for _, line := range lines {
+			ps := make([]plot.Point, len(line))
+			for k, p := range line {
+				ps[k] = plot.Pt(trX(p.X), trY(p.Y))
+			}
+			if !ct.Labels {
+				da.StrokeLines(sty, da.ClipLinesXY(ps)...)
+				continue
+			}
+			at, ok := labelPlace(ps, 3*ct.TextStyle.Width(label))
+			if !ok || !da.Contains(at) {
+				da.StrokeLines(sty, da.ClipLinesXY(ps)...)
+				continue
+			}
+			gap := ct.TextStyle.Width(label)/2 + contourLabelGap
+			da.StrokeLines(sty, da.ClipLinesXY(outsideCircle(ps, at, gap)...)...)
+			da.FillText(ct.TextStyle, at.X, at.Y, -0.5, -0.5, label)
+		}
+	}
+}
+
+// lineStyle returns the style of the lines of the
+// ith level.
+func (ct *Contour) lineStyle(i int) plot.LineStyle {
+	if len(ct.LineStyles) == 0 {
+		return DefaultLineStyle
+	}
+	return ct.LineStyles[i%len(ct.LineStyles)]
+}
+
+// labelPlace returns the point halfway along the line,
+// and true, if the line is at least min long.
+func labelPlace(ps []plot.Point, min vg.Length) (plot.Point, bool) {
+	lengths := make([]vg.Length, len(ps))
+	var total vg.Length
+	for i := 1; i < len(ps); i++ {
+		total += vg.Length(math.Hypot(float64(ps[i].X-ps[i-1].X), float64(ps[i].Y-ps[i-1].Y)))
+		lengths[i] = total
+	}
+	if total < min || total == 0 {
+		return plot.Point{}, false
+	}
+	half := total / 2
+	for i := 1; i < len(ps); i++ {
+		if lengths[i] < half {
+			continue
+		}
+		t := (half - lengths[i-1]) / (lengths[i] - lengths[i-1])
+		return plot.Pt(ps[i-1].X+t*(ps[i].X-ps[i-1].X), ps[i-1].Y+t*(ps[i].Y-ps[i-1].Y)), true
+	}
+	return ps[len(ps)-1], true
+}
+
+// outsideCircle returns the parts of the line that
+// are outside of the circle of the given center and
+// radius.
+func outsideCircle(ps []plot.Point, c plot.Point, rad vg.Length) [][]plot.Point {
+	var lines [][]plot.Point
+	var cur []plot.Point
+	r2 := float64(rad * rad)
+	for i := 1; i < len(ps); i++ {
+		p, q := ps[i-1], ps[i]
+		dx, dy := float64(q.X-p.X), float64(q.Y-p.Y)
+		fx, fy := float64(p.X-c.X), float64(p.Y-c.Y)
+
+		// The segment is inside of the circle for t
+		// between the roots of |p + t(q-p) - c|² = r².
+		a, b, cc := dx*dx+dy*dy, 2*(fx*dx+fy*dy), fx*fx+fy*fy-r2
+		t0, t1 := 1.0, 1.0
+		if disc := b*b - 4*a*cc; a > 0 && disc > 0 {
+			s := math.Sqrt(disc)
+			t0, t1 = (-b-s)/(2*a), (-b+s)/(2*a)
+		}
+		at := func(t float64) plot.Point {
+			return plot.Pt(p.X+vg.Length(t)*(q.X-p.X), p.Y+vg.Length(t)*(q.Y-p.Y))
+		}
+		if t1 <= 0 || t0 >= 1 {
+			// The segment is outside of the circle.
+			if cur == nil {
+				cur = []plot.Point{p}
+			}
+			cur = append(cur, q)
+			continue
+		}
+		if t0 > 0 {
+			if cur == nil {
+				cur = []plot.Point{p}
+			}
+			lines = append(lines, append(cur, at(t0)))
+		} else if cur != nil {
+			lines = append(lines, cur)
+		}
+		cur = nil
+		if t1 < 1 {
+			cur = []plot.Point{at(t1), q}
+		}
+	}
+	if cur != nil {
+		lines = append(lines, cur)
+	}
+	return lines
+}
+
+// DataRange returns the range of the grid points,
+// implementing the plot.DataRanger interface.
+func (ct *Contour) DataRange() (xmin, xmax, ymin, ymax float64) {
+	c, r := ct.Dims()
+	return ct.X(0), ct.X(c - 1), ct.Y(0), ct.Y(r - 1)
+}
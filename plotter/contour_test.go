@@ -0,0 +1,82 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// coneGrid is a GridXYZ of the distance from the
+// origin, sampled from -2 to 2 in steps of 0.25.
+type coneGrid struct{}
+
+func (coneGrid) Dims() (c, r int)     { return 17, 17 }
+func (coneGrid) X(c int) float64      { return -2 + float64(c)/4 }
+func (coneGrid) Y(r int) float64      { return -2 + float64(r)/4 }
+func (g coneGrid) Z(c, r int) float64 { return math.Hypot(g.X(c), g.Y(r)) }
+
+func TestContour(t *testing.T) {
+	ct, err := NewContour(coneGrid{}, []float64{1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := ct.Lines(0)
+	if len(lines) != 1 {
+		t.Fatalf("contour has %d lines, want 1", len(lines))
+	}
+	line := lines[0]
+	if line[0] != line[len(line)-1] {
+		t.Errorf("contour around the origin is not closed")
+	}
+	for _, p := range line {
+		if d := math.Hypot(p.X, p.Y); math.Abs(d-1) > 0.05 {
+			t.Errorf("contour point %v is %g from the origin, want 1", p, d)
+		}
+	}
+
+	// Without any styles, the lines are drawn
+	// in the default style.
+	ct.LineStyles = nil
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(ct)
+	prims, err := p.Trace(vg.Inches(4), vg.Inches(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prims) != 1 || prims[0].Color != DefaultLineStyle.Color {
+		t.Errorf("traced %d primitives, want 1 in the default color", len(prims))
+	}
+
+	auto, err := NewContour(coneGrid{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, l := range auto.Levels {
+		if l <= 0 || l >= 2*math.Sqrt2 {
+			t.Errorf("automatic level %g is outside of the data", l)
+		}
+	}
+}
+
+func TestOutsideCircle(t *testing.T) {
+	line := []plot.Point{{-10, 0}, {0, 0}, {10, 0}}
+	parts := outsideCircle(line, plot.Pt(0, 0), 2)
+	if len(parts) != 2 {
+		t.Fatalf("line is cut into %d parts, want 2", len(parts))
+	}
+	if end := parts[0][len(parts[0])-1]; math.Abs(float64(end.X+2)) > 1e-9 {
+		t.Errorf("first part ends at %v, want {-2 0}", end)
+	}
+	if start := parts[1][0]; math.Abs(float64(start.X-2)) > 1e-9 {
+		t.Errorf("second part starts at %v, want {2 0}", start)
+	}
+}
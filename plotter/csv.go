@@ -0,0 +1,103 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// XYsFromCSV reads CSV data and returns the points whose
+// X and Y values are in the given columns, numbered from
+// zero.  The first record of the data is a header, which
+// is skipped.  An error is returned, giving the number of
+// the record, counting the header as record 1, if a record
+// is too short or if a value is not a finite number.
+func XYsFromCSV(r io.Reader, xCol, yCol int) (XYs, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	if _, err := cr.Read(); err != nil {
+		if err == io.EOF {
+			return nil, ErrNoData
+		}
+		return nil, err
+	}
+	return readCSVXYs(cr, xCol, yCol, 2)
+}
+
+// XYsFromCSVNamed reads CSV data, as XYsFromCSV does,
+// and returns the points whose X and Y values are in the
+// columns with the given names in the header.
+func XYsFromCSVNamed(r io.Reader, xName, yName string) (XYs, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, ErrNoData
+		}
+		return nil, err
+	}
+	cols := make([]int, 2)
+	for i, name := range []string{xName, yName} {
+		cols[i] = -1
+		for j, h := range header {
+			if strings.TrimSpace(h) == name {
+				cols[i] = j
+				break
+			}
+		}
+		if cols[i] < 0 {
+			return nil, fmt.Errorf("No column named %q in the CSV header", name)
+		}
+	}
+	return readCSVXYs(cr, cols[0], cols[1], 2)
+}
+
+// readCSVXYs reads the remaining records of CSV data,
+// the first of which has the given number, and returns
+// the points in the given columns.
+func readCSVXYs(cr *csv.Reader, xCol, yCol, n int) (XYs, error) {
+	if xCol < 0 || yCol < 0 {
+		return nil, errors.New("Negative CSV column")
+	}
+	var xys XYs
+	for ; ; n++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if xCol >= len(rec) || yCol >= len(rec) {
+			return nil, fmt.Errorf("CSV record %d has %d fields", n, len(rec))
+		}
+		var p struct{ X, Y float64 }
+		for _, f := range []struct {
+			col int
+			v   *float64
+		}{{xCol, &p.X}, {yCol, &p.Y}} {
+			s := strings.TrimSpace(rec[f.col])
+			v, err := strconv.ParseFloat(s, 64)
+			if err == nil {
+				err = CheckFloats(v)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("CSV record %d, column %d: %q is not a finite number", n, f.col, s)
+			}
+			*f.v = v
+		}
+		xys = append(xys, p)
+	}
+	if len(xys) == 0 {
+		return nil, ErrNoData
+	}
+	return xys, nil
+}
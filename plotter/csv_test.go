@@ -0,0 +1,51 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"strings"
+	"testing"
+)
+
+const testCSV = `time,depth,temperature
+0,1.5,12.25
+1, 2.5 ,11.5
+2,3.5,10
+`
+
+func TestXYsFromCSV(t *testing.T) {
+	want := XYs{{0, 12.25}, {1, 11.5}, {2, 10}}
+	xys, err := XYsFromCSV(strings.NewReader(testCSV), 0, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	named, err := XYsFromCSVNamed(strings.NewReader(testCSV), "time", "temperature")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, got := range []XYs{xys, named} {
+		if len(got) != len(want) {
+			t.Fatalf("read %d points, want %d", len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("point %d is %v, want %v", i, got[i], want[i])
+			}
+		}
+	}
+
+	for _, bad := range []string{
+		"x,y\n1,2\n3\n",
+		"x,y\n1,abc\n",
+		"x,y\n",
+	} {
+		if _, err := XYsFromCSV(strings.NewReader(bad), 0, 1); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+	if _, err := XYsFromCSVNamed(strings.NewReader(testCSV), "time", "salinity"); err == nil {
+		t.Errorf("expected an error for a missing column")
+	}
+}
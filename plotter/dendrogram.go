@@ -0,0 +1,183 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// A Merge is a step of a hierarchical clustering, in
+// which two clusters are merged into a new cluster.
+//
+// The clusters of n leaves are numbered as in the
+// linkage matrices of SciPy and R: the leaves are the
+// clusters 0 to n-1, and the cluster made by the kth
+// merge is cluster n+k.
+type Merge struct {
+	// A and B are the clusters that are merged.
+	A, B int
+
+	// Height is the distance between A and B
+	// at which they are merged.
+	Height float64
+}
+
+// Dendrogram implements the Plotter interface, drawing
+// the tree of a hierarchical clustering.  The leaves are
+// at Y zero, one X unit apart, in an order in which the
+// branches do not cross, and each merge is drawn as a
+// bracket at the height of the merge joining the two
+// clusters that it merges.
+type Dendrogram struct {
+	// Merges are the merges of the clustering,
+	// in the order in which they were made.
+	Merges []Merge
+
+	// Labels, if non-nil, are the names of the
+	// leaves, indexed by leaf number.
+	Labels []string
+
+	// LineStyle is the style of the brackets.
+	plot.LineStyle
+
+	// order is the leaf at each X position.
+	order []int
+}
+
+// NewDendrogram returns a Dendrogram of the clustering
+// of len(merges)+1 leaves by the given merges, in which
+// each cluster other than the last must be merged
+// exactly once, after it is made.
+func NewDendrogram(merges []Merge) (*Dendrogram, error) {
+	if len(merges) == 0 {
+		return nil, ErrNoData
+	}
+	n := len(merges) + 1
+	merged := make([]bool, 2*n-1)
+	for k, m := range merges {
+		if err := CheckFloats(m.Height); err != nil {
+			return nil, err
+		}
+		for _, c := range []int{m.A, m.B} {
+			if c < 0 || c >= n+k {
+				return nil, errors.New("Merge of a cluster that does not exist yet")
+			}
+			if merged[c] {
+				return nil, errors.New("Cluster is merged more than once")
+			}
+			merged[c] = true
+		}
+	}
+	d := &Dendrogram{
+		Merges:    append([]Merge(nil), merges...),
+		LineStyle: DefaultLineStyle,
+	}
+	d.order = d.leafOrder()
+	return d, nil
+}
+
+// leafOrder returns the leaves in the order in which
+// they are reached by a depth-first walk of the tree
+// from its root, visiting A before B at each merge.
+func (d *Dendrogram) leafOrder() []int {
+	n := len(d.Merges) + 1
+	order := make([]int, 0, n)
+	stack := []int{2*n - 2}
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if c < n {
+			order = append(order, c)
+			continue
+		}
+		m := d.Merges[c-n]
+		stack = append(stack, m.B, m.A)
+	}
+	return order
+}
+
+// LeafOrder returns the leaf at each X position,
+// starting at zero.
+func (d *Dendrogram) LeafOrder() []int {
+	return append([]int(nil), d.order...)
+}
+
+// OrderedLabels returns the labels of the leaves in
+// the order of their X positions.  It is suitable for
+// the Categories of the X axis.  OrderedLabels returns
+// nil if the Dendrogram has no Labels, and an error if
+// there is not one label for each leaf.
+func (d *Dendrogram) OrderedLabels() ([]string, error) {
+	if d.Labels == nil {
+		return nil, nil
+	}
+	if len(d.Labels) != len(d.order) {
+		return nil, errors.New("Number of labels does not match the number of leaves")
+	}
+	labels := make([]string, len(d.order))
+	for i, leaf := range d.order {
+		labels[i] = d.Labels[leaf]
+	}
+	return labels, nil
+}
+
+// positions returns the X and Y data coordinates of
+// the top of each cluster.
+func (d *Dendrogram) positions() XYs {
+	n := len(d.Merges) + 1
+	pos := make(XYs, 2*n-1)
+	for i, leaf := range d.order {
+		pos[leaf].X = float64(i)
+	}
+	for k, m := range d.Merges {
+		pos[n+k].X = (pos[m.A].X + pos[m.B].X) / 2
+		pos[n+k].Y = m.Height
+	}
+	return pos
+}
+
+// Plot draws the Dendrogram, implementing the
+// plot.Plotter interface.
+func (d *Dendrogram) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	pos := d.positions()
+	for _, m := range d.Merges {
+		a, b := pos[m.A], pos[m.B]
+		bracket := []plot.Point{
+			{trX(a.X), trY(a.Y)},
+			{trX(a.X), trY(m.Height)},
+			{trX(b.X), trY(m.Height)},
+			{trX(b.X), trY(b.Y)},
+		}
+		da.StrokeLines(d.LineStyle, da.ClipLinesXY(bracket)...)
+	}
+}
+
+// DataRange returns the minimum and maximum X and Y
+// values of the tree, implementing the plot.DataRanger
+// interface.
+func (d *Dendrogram) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmax = float64(len(d.Merges))
+	for _, m := range d.Merges {
+		ymin = math.Min(ymin, m.Height)
+		ymax = math.Max(ymax, m.Height)
+	}
+	return
+}
+
+// AutoColor sets the color of the brackets if it is
+// the default color, implementing the
+// plot.AutoColorer interface.
+func (d *Dendrogram) AutoColor(c color.Color) bool {
+	if !unsetColor(d.LineStyle.Color) {
+		return false
+	}
+	d.LineStyle.Color = c
+	return true
+}
@@ -0,0 +1,50 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDendrogramLayout(t *testing.T) {
+	// Leaves 0 and 2 merge into cluster 4, leaves
+	// 1 and 3 into cluster 5, and then 4 and 5.
+	d, err := NewDendrogram([]Merge{
+		{A: 0, B: 2, Height: 1},
+		{A: 1, B: 3, Height: 2},
+		{A: 4, B: 5, Height: 5},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order, want := d.LeafOrder(), []int{0, 2, 1, 3}; !reflect.DeepEqual(order, want) {
+		t.Errorf("leaf order is %v, want %v", order, want)
+	}
+	pos := d.positions()
+	if root := pos[6]; root.X != 1.5 || root.Y != 5 {
+		t.Errorf("root at (%v, %v), want (1.5, 5)", root.X, root.Y)
+	}
+
+	d.Labels = []string{"a", "b", "c", "d"}
+	labels, err := d.OrderedLabels()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"a", "c", "b", "d"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("ordered labels are %v, want %v", labels, want)
+	}
+	d.Labels = d.Labels[:3]
+	if _, err := d.OrderedLabels(); err == nil {
+		t.Errorf("expected an error for too few labels")
+	}
+
+	if _, err := NewDendrogram([]Merge{{A: 0, B: 2}}); err == nil {
+		t.Errorf("expected an error for a merge of a future cluster")
+	}
+	if _, err := NewDendrogram([]Merge{{A: 0, B: 1}, {A: 0, B: 2}}); err == nil {
+		t.Errorf("expected an error for a cluster merged twice")
+	}
+}
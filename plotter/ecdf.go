@@ -0,0 +1,121 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"sort"
+
+	"github.com/gonum/plot/plot"
+)
+
+// ECDF implements the Plotter interface, drawing the
+// empirical cumulative distribution function of a set
+// of values: the fraction of the values that are less
+// than or equal to each X.  The function is drawn as a
+// step from 0 at the smallest value up to 1 at the
+// largest, rising at each distinct value by the fraction
+// of the values that are equal to it, so tied values
+// make a single, taller step.
+type ECDF struct {
+	// Values is a sorted copy of the values.
+	Values
+
+	// Smooth, if true, draws the function as a line
+	// through the middle of each step instead of as
+	// steps.  The line runs from 1/2n to 1-1/2n for n
+	// values, as the mid-distribution function.
+	Smooth bool
+
+	// LineStyle is the style of the line.
+	plot.LineStyle
+}
+
+// NewECDF returns an ECDF of the values with the
+// default line style.
+func NewECDF(vs Valuer) (*ECDF, error) {
+	values, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	sort.Float64s(values)
+	return &ECDF{
+		Values:    values,
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// Steps returns the distinct values, in increasing
+// order, and the fraction of the values that are less
+// than or equal to each of them.
+func (e *ECDF) Steps() XYs {
+	n := float64(len(e.Values))
+	var steps XYs
+	for i, v := range e.Values {
+		if i+1 < len(e.Values) && e.Values[i+1] == v {
+			continue
+		}
+		steps = append(steps, struct{ X, Y float64 }{v, float64(i+1) / n})
+	}
+	return steps
+}
+
+// points returns the data coordinates of the line,
+// which, for steps, rises vertically at each distinct
+// value from the step below it.
+func (e *ECDF) points() XYs {
+	steps := e.Steps()
+	var pts XYs
+	below := 0.0
+	for _, s := range steps {
+		if e.Smooth {
+			pts = append(pts, struct{ X, Y float64 }{s.X, (below + s.Y) / 2})
+		} else {
+			pts = append(pts,
+				struct{ X, Y float64 }{s.X, below},
+				struct{ X, Y float64 }{s.X, s.Y})
+		}
+		below = s.Y
+	}
+	return pts
+}
+
+// Plot draws the ECDF, implementing the plot.Plotter
+// interface.
+func (e *ECDF) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	pts := e.points()
+	ps := make([]plot.Point, len(pts))
+	for i, p := range pts {
+		ps[i] = plot.Pt(trX(p.X), trY(p.Y))
+	}
+	da.StrokeLines(e.LineStyle, da.ClipLinesXY(ps)...)
+}
+
+// DataRange returns the range of the values and
+// the range of fractions from 0 to 1, implementing
+// the plot.DataRanger interface.
+func (e *ECDF) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return e.Values[0], e.Values[len(e.Values)-1], 0, 1
+}
+
+// AutoColor sets the color of the line if it is
+// the default color, implementing the
+// plot.AutoColorer interface.
+func (e *ECDF) AutoColor(c color.Color) bool {
+	if !unsetColor(e.LineStyle.Color) {
+		return false
+	}
+	e.LineStyle.Color = c
+	return true
+}
+
+// Thumbnail draws a line through the center of the
+// draw area, implementing the plot.Thumbnailer
+// interface.
+func (e *ECDF) Thumbnail(da *plot.DrawArea) {
+	y := da.Center().Y
+	da.StrokeLine2(e.LineStyle, da.Min.X, y, da.Max().X, y)
+}
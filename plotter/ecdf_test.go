@@ -0,0 +1,29 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestECDF(t *testing.T) {
+	e, err := NewECDF(Values{3, 1, 2, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (XYs{{1, 0.25}, {2, 0.75}, {3, 1}}); !reflect.DeepEqual(e.Steps(), want) {
+		t.Errorf("steps are %v, want %v", e.Steps(), want)
+	}
+	want := XYs{{1, 0}, {1, 0.25}, {2, 0.25}, {2, 0.75}, {3, 0.75}, {3, 1}}
+	if got := e.points(); !reflect.DeepEqual(got, want) {
+		t.Errorf("step points are %v, want %v", got, want)
+	}
+	e.Smooth = true
+	want = XYs{{1, 0.125}, {2, 0.5}, {3, 0.875}}
+	if got := e.points(); !reflect.DeepEqual(got, want) {
+		t.Errorf("smooth points are %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// ellipseSteps is the number of line segments
+// used to draw a confidence ellipse.
+const ellipseSteps = 128
+
+// ConfidenceEllipse implements the Plotter interface,
+// drawing the ellipse that contains a given fraction
+// of a bivariate normal distribution.
+type ConfidenceEllipse struct {
+	// X and Y are the mean of the distribution,
+	// which is the center of the ellipse.
+	X, Y float64
+
+	// VarX and VarY are the variances of the
+	// distribution in X and Y, and CovXY is
+	// the covariance between X and Y.
+	VarX, VarY, CovXY float64
+
+	// Confidence is the fraction of the
+	// distribution within the ellipse,
+	// e.g. 0.95.
+	Confidence float64
+
+	// LineStyle is the style of the outline
+	// of the ellipse.
+	plot.LineStyle
+
+	// FillColor is the color used to fill the
+	// ellipse.  If FillColor is nil then the
+	// ellipse is not filled.
+	FillColor color.Color
+}
+
+// NewConfidenceEllipse returns a ConfidenceEllipse
+// containing the given fraction of the bivariate
+// normal distribution with the sample mean and
+// sample covariance of the given points.
+func NewConfidenceEllipse(xys XYer, confidence float64) (*ConfidenceEllipse, error) {
+	if confidence <= 0 || confidence >= 1 {
+		return nil, errors.New("Confidence must be between 0 and 1")
+	}
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 {
+		return nil, errors.New("Too few points to compute a covariance")
+	}
+
+	e := &ConfidenceEllipse{
+		Confidence: confidence,
+		LineStyle:  DefaultLineStyle,
+	}
+	for _, p := range data {
+		e.X += p.X
+		e.Y += p.Y
+	}
+	n := float64(len(data))
+	e.X /= n
+	e.Y /= n
+	for _, p := range data {
+		dx, dy := p.X-e.X, p.Y-e.Y
+		e.VarX += dx * dx
+		e.VarY += dy * dy
+		e.CovXY += dx * dy
+	}
+	e.VarX /= n - 1
+	e.VarY /= n - 1
+	e.CovXY /= n - 1
+	return e, nil
+}
+
+// scale returns the squared Mahalanobis distance of
+// the ellipse from its center.  This is the quantile
+// of the chi-square distribution with two degrees of
+// freedom at the confidence level, which has a closed
+// form.
+func (e *ConfidenceEllipse) scale() float64 {
+	return -2 * math.Log(1-e.Confidence)
+}
+
+// Axes returns the lengths of the semi-major and
+// semi-minor axes of the ellipse in data units, and
+// the angle of the major axis in radians counter
+// clockwise from the X axis.  The axes lie along the
+// eigenvectors of the covariance matrix, and their
+// squared lengths are its eigenvalues scaled by the
+// chi-square quantile of the confidence level.
+func (e *ConfidenceEllipse) Axes() (major, minor, angle float64) {
+	mid := (e.VarX + e.VarY) / 2
+	d := math.Hypot((e.VarX-e.VarY)/2, e.CovXY)
+	s := e.scale()
+	// Rounding can make the smaller eigenvalue
+	// of a degenerate distribution negative.
+	major = math.Sqrt(s * (mid + d))
+	minor = math.Sqrt(s * math.Max(0, mid-d))
+	angle = math.Atan2(2*e.CovXY, e.VarX-e.VarY) / 2
+	return major, minor, angle
+}
+
+// Plot implements the Plotter interface, drawing the
+// ellipse.  The ellipse is traced in data coordinates
+// so that it is correctly warped by non-linear axis
+// scales.
+func (e *ConfidenceEllipse) Plot(da plot.DrawArea, p *plot.Plot) {
+	trX, trY := p.Transforms(&da)
+	major, minor, angle := e.Axes()
+	sin, cos := math.Sincos(angle)
+	pts := make([]plot.Point, ellipseSteps+1)
+	for i := range pts {
+		st, ct := math.Sincos(2 * math.Pi * float64(i) / ellipseSteps)
+		u, v := major*ct, minor*st
+		pts[i].X = trX(e.X + u*cos - v*sin)
+		pts[i].Y = trY(e.Y + u*sin + v*cos)
+	}
+
+	if e.FillColor != nil {
+		da.FillPolygon(e.FillColor, da.ClipPolygonXY(pts[:ellipseSteps]))
+	}
+	da.StrokeLines(e.LineStyle, da.ClipLinesXY(pts)...)
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.  The range
+// is the bounding box of the ellipse.
+func (e *ConfidenceEllipse) DataRange() (xmin, xmax, ymin, ymax float64) {
+	s := e.scale()
+	w, h := math.Sqrt(s*e.VarX), math.Sqrt(s*e.VarY)
+	return e.X - w, e.X + w, e.Y - h, e.Y + h
+}
+
+// Thumbnail implements the Thumbnail method
+// of the plot.Thumbnailer interface.
+func (e *ConfidenceEllipse) Thumbnail(da *plot.DrawArea) {
+	fillThumbnail(da, e.FillColor)
+	y := da.Center().Y
+	da.StrokeLine2(e.LineStyle, da.Min.X, y, da.Max().X, y)
+}
@@ -0,0 +1,34 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConfidenceEllipseAxes(t *testing.T) {
+	// Points along the diagonals with variances of
+	// 8 along y=x and 2 along y=-x.
+	xys := XYs{{2, 2}, {-2, -2}, {1, -1}, {-1, 1}}
+	e, err := NewConfidenceEllipse(xys, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := -2 * math.Log(0.5)
+	major, minor, angle := e.Axes()
+	for _, test := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"major", major, math.Sqrt(s * 8 * 2 / 3)},
+		{"minor", minor, math.Sqrt(s * 2 * 2 / 3)},
+		{"angle", angle, math.Pi / 4},
+	} {
+		if math.Abs(test.got-test.want) > 1e-12 {
+			t.Errorf("%s: got %g, want %g", test.name, test.got, test.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// ErrorBand implements the plot.Plotter and
+// plot.DataRanger interfaces, drawing a shaded band
+// between the lower and upper error of each point,
+// such as a confidence band, and optionally a line
+// through the points.
+type ErrorBand struct {
+	// XYs is a copy of the points at the center
+	// of the band.  The X values of the points
+	// should be in increasing order.
+	XYs
+
+	// YErrors is a copy of the Y errors for each
+	// point.  The band extends from the Y value
+	// minus the absolute value of the low error to
+	// the Y value plus the absolute value of the
+	// high error.
+	YErrors
+
+	// FillColor is the color of the band.  If
+	// FillColor is nil then the band is not drawn.
+	FillColor color.Color
+
+	// LineStyle is the style of the line through
+	// the points.  If the width is zero then the
+	// line is not drawn.
+	plot.LineStyle
+}
+
+// NewErrorBand returns an ErrorBand that is filled
+// with translucent gray and draws the line through
+// the points with the default line style.
+//
+// Like a Line, an ErrorBand accepts points and
+// errors that are NaN or infinite.  They mark gaps,
+// such as missing data: the band is broken at each
+// of them.
+func NewErrorBand(yerrs interface {
+	XYer
+	YErrorer
+}) (*ErrorBand, error) {
+	n := yerrs.Len()
+	b := &ErrorBand{
+		XYs:       make(XYs, n),
+		YErrors:   make(YErrors, n),
+		FillColor: color.NRGBA{R: 128, G: 128, B: 128, A: 96},
+		LineStyle: DefaultLineStyle,
+	}
+	for i := 0; i < n; i++ {
+		b.XYs[i].X, b.XYs[i].Y = yerrs.XY(i)
+		b.YErrors[i].Low, b.YErrors[i].High = yerrs.YError(i)
+	}
+	return b, nil
+}
+
+// Plot draws the ErrorBand, implementing the
+// plot.Plotter interface.  Each run of points
+// between gaps is drawn separately.
+func (b *ErrorBand) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	for _, run := range b.runs() {
+		n := run[1] - run[0]
+		line := make([]plot.Point, n)
+		band := make([]plot.Point, 2*n)
+		for i := 0; i < n; i++ {
+			p, err := b.XYs[run[0]+i], b.YErrors[run[0]+i]
+			x := trX(p.X)
+			line[i] = plot.Pt(x, trY(p.Y))
+			// The band runs forward along the upper
+			// curve and back along the lower curve.
+			band[i] = plot.Pt(x, trY(p.Y+math.Abs(err.High)))
+			band[2*n-1-i] = plot.Pt(x, trY(p.Y-math.Abs(err.Low)))
+		}
+		if b.FillColor != nil {
+			da.FillPolygon(b.FillColor, da.ClipPolygonXY(band))
+		}
+		if b.Width > 0 {
+			da.StrokeLines(b.LineStyle, da.ClipLinesXY(line)...)
+		}
+	}
+}
+
+// runs returns the start and end indices of the runs
+// of consecutive points whose coordinates and errors
+// are all finite.
+func (b *ErrorBand) runs() [][2]int {
+	var runs [][2]int
+	start := 0
+	for i := 0; i <= len(b.XYs); i++ {
+		if i < len(b.XYs) && b.finite(i) {
+			continue
+		}
+		if i > start {
+			runs = append(runs, [2]int{start, i})
+		}
+		start = i + 1
+	}
+	return runs
+}
+
+// finite returns true if the coordinates and
+// errors of the ith point are finite.
+func (b *ErrorBand) finite(i int) bool {
+	p, err := b.XYs[i], b.YErrors[i]
+	return CheckFloats(p.X, p.Y, err.Low, err.High) == nil
+}
+
+// DataRange returns the minimum and maximum X values
+// of the points and the minimum and maximum Y values
+// of the band, implementing the plot.DataRanger
+// interface.
+func (b *ErrorBand) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for i, p := range b.XYs {
+		if !b.finite(i) {
+			continue
+		}
+		err := b.YErrors[i]
+		xmin, xmax = math.Min(xmin, p.X), math.Max(xmax, p.X)
+		ymin = math.Min(ymin, math.Min(p.Y, p.Y-math.Abs(err.Low)))
+		ymax = math.Max(ymax, math.Max(p.Y, p.Y+math.Abs(err.High)))
+	}
+	return
+}
+
+// Thumbnail draws a filled rectangle with the line
+// through its center, implementing the
+// plot.Thumbnailer interface.
+func (b *ErrorBand) Thumbnail(da *plot.DrawArea) {
+	fillThumbnail(da, b.FillColor)
+	if b.Width > 0 {
+		y := da.Center().Y
+		da.StrokeLine2(b.LineStyle, da.Min.X, y, da.Max().X, y)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestErrorBandGaps(t *testing.T) {
+	b := &ErrorBand{
+		XYs:     XYs{{0, 1}, {1, 2}, {2, math.NaN()}, {3, 4}, {4, 5}, {5, 6}},
+		YErrors: YErrors{{1, 1}, {-2, 2}, {1, 1}, {1, 1}, {math.Inf(1), 1}, {1, 3}},
+	}
+	want := [][2]int{{0, 2}, {3, 4}, {5, 6}}
+	if got := b.runs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("runs() = %v, want %v", got, want)
+	}
+	xmin, xmax, ymin, ymax := b.DataRange()
+	if xmin != 0 || xmax != 5 || ymin != 0 || ymax != 9 {
+		t.Errorf("DataRange() = %g, %g, %g, %g, want 0, 5, 0, 9", xmin, xmax, ymin, ymax)
+	}
+}
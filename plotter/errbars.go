@@ -69,7 +69,7 @@ func (e *YErrorBars) Plot(da plot.DrawArea, p *plot.Plot) {
 		ylow := trY(e.XYs[i].Y - math.Abs(err.Low))
 		yhigh := trY(e.XYs[i].Y + math.Abs(err.High))
 
-		bar := da.ClipLinesY([]plot.Point{{x, ylow}, {x, yhigh}})
+		bar := da.ClipLinesXY([]plot.Point{{x, ylow}, {x, yhigh}})
 		da.StrokeLines(e.LineStyle, bar...)
 		e.drawCap(&da, x, ylow)
 		e.drawCap(&da, x, yhigh)
@@ -177,7 +177,7 @@ func (e *XErrorBars) Plot(da plot.DrawArea, p *plot.Plot) {
 		xlow := trX(e.XYs[i].X - math.Abs(err.Low))
 		xhigh := trX(e.XYs[i].X + math.Abs(err.High))
 
-		bar := da.ClipLinesX([]plot.Point{{xlow, y}, {xhigh, y}})
+		bar := da.ClipLinesXY([]plot.Point{{xlow, y}, {xhigh, y}})
 		da.StrokeLines(e.LineStyle, bar...)
 		e.drawCap(&da, xlow, y)
 		e.drawCap(&da, xhigh, y)
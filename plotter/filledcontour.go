@@ -0,0 +1,229 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// FilledContour implements the Plotter interface,
+// filling the bands of gridded data between successive
+// contour levels, each with its own color.  The bands
+// are bounded by the same lines that a Contour draws
+// at the levels, and by the edges of the grid where a
+// contour line leaves it, so the bands cover the whole
+// grid.
+type FilledContour struct {
+	// GridXYZ is the data of the contours.
+	GridXYZ
+
+	// Levels are the increasing boundaries of the
+	// bands.  The first band has the values below
+	// the first level and the last band has the
+	// values above the last level, so there is one
+	// more band than there are levels.
+	Levels []float64
+
+	// Colors is the palette.  It is spread evenly
+	// over the bands, so that the first band has the
+	// first color and the last band has the last
+	// color.
+	Colors []color.Color
+}
+
+// NewFilledContour returns a FilledContour of the grid
+// with the given levels and palette.  If levels is nil
+// then the levels are chosen as by NewContour.  Grid
+// cells with a NaN corner are not filled.
+func NewFilledContour(g GridXYZ, levels []float64, colors []color.Color) (*FilledContour, error) {
+	c, r := g.Dims()
+	if c < 2 || r < 2 {
+		return nil, errors.New("Contour grid needs at least two columns and rows")
+	}
+	if len(colors) == 0 {
+		return nil, errors.New("No colors in the palette")
+	}
+	if levels == nil {
+		levels = autoLevels(g)
+	}
+	if err := CheckFloats(levels...); err != nil {
+		return nil, err
+	}
+	for i := 1; i < len(levels); i++ {
+		if levels[i] <= levels[i-1] {
+			return nil, errors.New("Contour levels are not increasing")
+		}
+	}
+	return &FilledContour{
+		GridXYZ: g,
+		Levels:  append([]float64(nil), levels...),
+		Colors:  colors,
+	}, nil
+}
+
+// Color returns the color of the ith band.
+func (fc *FilledContour) Color(i int) color.Color {
+	n := len(fc.Levels)
+	if n == 0 {
+		return fc.Colors[0]
+	}
+	return fc.Colors[i*(len(fc.Colors)-1)/n]
+}
+
+// band returns the bounds of the ith band, which
+// are infinite for the first and last bands.
+func (fc *FilledContour) band(i int) (lo, hi float64) {
+	lo, hi = math.Inf(-1), math.Inf(1)
+	if i > 0 {
+		lo = fc.Levels[i-1]
+	}
+	if i < len(fc.Levels) {
+		hi = fc.Levels[i]
+	}
+	return lo, hi
+}
+
+// A gridVertex is a vertex of a polygon within a grid
+// cell and the value of the grid interpolated at it.
+type gridVertex struct {
+	x, y, z float64
+}
+
+// clipLevel returns the part of the polygon in which
+// the value is at least the level if above is true, or
+// at most the level if it is false, with the value
+// interpolated linearly along the polygon's edges.
+func clipLevel(poly []gridVertex, level float64, above bool) []gridVertex {
+	in := func(v gridVertex) bool {
+		if above {
+			return v.z >= level
+		}
+		return v.z <= level
+	}
+	var out []gridVertex
+	for i, cur := range poly {
+		prev := poly[(i+len(poly)-1)%len(poly)]
+		if in(cur) != in(prev) {
+			t := (level - prev.z) / (cur.z - prev.z)
+			out = append(out, gridVertex{
+				x: prev.x + t*(cur.x-prev.x),
+				y: prev.y + t*(cur.y-prev.y),
+				z: level,
+			})
+		}
+		if in(cur) {
+			out = append(out, cur)
+		}
+	}
+	return out
+}
+
+// cellPolygons returns the grid cell with the given
+// corners, counterclockwise from its lower left, as the
+// polygons to be clipped to the band from lo to hi.  The cell is a single
+// polygon unless it is a saddle at one of the levels,
+// in which clipping the whole cell would join the
+// opposite corners that the Contour resolution of the
+// saddle, by the mean of the corners, keeps apart.  The
+// cell is then cut into two triangles along the diagonal
+// between the corners that are joined.
+func cellPolygons(corners [4]gridVertex, lo, hi float64) [][]gridVertex {
+	mean := (corners[0].z + corners[1].z + corners[2].z + corners[3].z) / 4
+	saddle := func(level float64) bool {
+		a, b := corners[0].z > level, corners[1].z > level
+		return a != b && a == (corners[2].z > level) && b == (corners[3].z > level)
+	}
+
+	// diag is the first corner of the diagonal along
+	// which the cell is cut, or -1 if it is not cut.
+	diag := -1
+	switch {
+	case saddle(lo) && mean <= lo:
+		// The corners above lo are kept apart, so the
+		// corners below it are joined.
+		diag = 0
+		if corners[0].z > lo {
+			diag = 1
+		}
+	case saddle(hi) && mean > hi:
+		// The corners below hi are kept apart, so the
+		// corners above it are joined.
+		diag = 0
+		if corners[0].z <= hi {
+			diag = 1
+		}
+	}
+	if diag < 0 {
+		return [][]gridVertex{corners[:]}
+	}
+	a, b, c, d := corners[diag], corners[diag+1], corners[(diag+2)%4], corners[(diag+3)%4]
+	return [][]gridVertex{{a, b, c}, {c, d, a}}
+}
+
+// bandPolygons returns the parts of the cell with the
+// given corners in which the value is from lo to hi.
+func bandPolygons(corners [4]gridVertex, lo, hi float64) [][]gridVertex {
+	var polys [][]gridVertex
+	for _, poly := range cellPolygons(corners, lo, hi) {
+		if !math.IsInf(lo, 0) {
+			poly = clipLevel(poly, lo, true)
+		}
+		if !math.IsInf(hi, 0) {
+			poly = clipLevel(poly, hi, false)
+		}
+		if len(poly) >= 3 {
+			polys = append(polys, poly)
+		}
+	}
+	return polys
+}
+
+// Plot fills the bands, implementing the plot.Plotter
+// interface.
+func (fc *FilledContour) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	c, r := fc.Dims()
+	for i := 0; i+1 < c; i++ {
+		for j := 0; j+1 < r; j++ {
+			corners := [4]gridVertex{
+				{fc.X(i), fc.Y(j), fc.Z(i, j)},
+				{fc.X(i + 1), fc.Y(j), fc.Z(i+1, j)},
+				{fc.X(i + 1), fc.Y(j + 1), fc.Z(i+1, j+1)},
+				{fc.X(i), fc.Y(j + 1), fc.Z(i, j+1)},
+			}
+			zmin, zmax := math.Inf(1), math.Inf(-1)
+			for _, v := range corners {
+				zmin, zmax = math.Min(zmin, v.z), math.Max(zmax, v.z)
+			}
+			if math.IsNaN(zmin) || math.IsNaN(zmax) {
+				continue
+			}
+			for k := 0; k <= len(fc.Levels); k++ {
+				lo, hi := fc.band(k)
+				if zmax < lo || zmin > hi {
+					continue
+				}
+				for _, poly := range bandPolygons(corners, lo, hi) {
+					pts := make([]plot.Point, len(poly))
+					for n, v := range poly {
+						pts[n] = plot.Pt(trX(v.x), trY(v.y))
+					}
+					da.FillPolygon(fc.Color(k), da.ClipPolygonXY(pts))
+				}
+			}
+		}
+	}
+}
+
+// DataRange returns the range of the grid points,
+// implementing the plot.DataRanger interface.
+func (fc *FilledContour) DataRange() (xmin, xmax, ymin, ymax float64) {
+	c, r := fc.Dims()
+	return fc.X(0), fc.X(c - 1), fc.Y(0), fc.Y(r - 1)
+}
@@ -0,0 +1,66 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+// polygonArea returns the area of the polygon.
+func polygonArea(poly []gridVertex) float64 {
+	var a float64
+	for i, v := range poly {
+		w := poly[(i+1)%len(poly)]
+		a += v.x*w.y - w.x*v.y
+	}
+	return math.Abs(a) / 2
+}
+
+func TestFilledContour(t *testing.T) {
+	fc, err := NewFilledContour(coneGrid{}, []float64{1}, nil)
+	if err == nil {
+		t.Errorf("no error for an empty palette")
+	}
+	fc, err = NewFilledContour(coneGrid{}, []float64{1}, []color.Color{color.Black, color.White})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var area [2]float64
+	c, r := fc.Dims()
+	for i := 0; i+1 < c; i++ {
+		for j := 0; j+1 < r; j++ {
+			corners := [4]gridVertex{
+				{fc.X(i), fc.Y(j), fc.Z(i, j)},
+				{fc.X(i + 1), fc.Y(j), fc.Z(i+1, j)},
+				{fc.X(i + 1), fc.Y(j + 1), fc.Z(i+1, j+1)},
+				{fc.X(i), fc.Y(j + 1), fc.Z(i, j+1)},
+			}
+			for k := range area {
+				lo, hi := fc.band(k)
+				for _, poly := range bandPolygons(corners, lo, hi) {
+					area[k] += polygonArea(poly)
+				}
+			}
+		}
+	}
+	if math.Abs(area[0]-math.Pi) > 0.05 {
+		t.Errorf("area of the inner band is %g, want about %g", area[0], math.Pi)
+	}
+	if math.Abs(area[0]+area[1]-16) > 1e-9 {
+		t.Errorf("bands cover an area of %g, want the whole grid of 16", area[0]+area[1])
+	}
+}
+
+func TestFilledContourSaddle(t *testing.T) {
+	corners := [4]gridVertex{{0, 0, 1}, {1, 0, 0}, {1, 1, 1}, {0, 1, 0}}
+	if n := len(bandPolygons(corners, 0.6, math.Inf(1))); n != 2 {
+		t.Errorf("band above the mean has %d parts, want 2", n)
+	}
+	if n := len(bandPolygons(corners, 0.4, math.Inf(1))); n != 1 {
+		t.Errorf("band below the mean has %d parts, want 1", n)
+	}
+}
@@ -5,7 +5,22 @@
 package plotter
 
 import (
+	"image/color"
+	"math"
+
 	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+var (
+	// DefaultFunctionTolerance is the default tolerance
+	// used for adaptive function sampling.
+	DefaultFunctionTolerance = vg.Points(0.25)
+
+	// DefaultFunctionMaxSamples is the default maximum
+	// number of samples used for adaptive function
+	// sampling.
+	DefaultFunctionMaxSamples = 2000
 )
 
 // Function implements the Plotter interface,
@@ -14,15 +29,36 @@ type Function struct {
 	F       func(float64) float64
 	Samples int
 	plot.LineStyle
+
+	// Adaptive specifies that the function is sampled
+	// adaptively.  Beginning with Samples evenly spaced
+	// samples, each interval is repeatedly split in half
+	// where the function at its midpoint deviates from
+	// the straight line between its end points by more
+	// than Tolerance, until no more intervals need to be
+	// split or MaxSamples samples have been taken.
+	Adaptive bool
+
+	// Tolerance is the maximum distance that the
+	// drawn line may deviate from the function at
+	// the midpoint of an interval when sampling
+	// adaptively.
+	Tolerance vg.Length
+
+	// MaxSamples bounds the number of samples taken
+	// when sampling adaptively.
+	MaxSamples int
 }
 
 // NewFunction returns a Function that plots F using
 // the default line style with 50 samples.
 func NewFunction(f func(float64) float64) *Function {
 	return &Function{
-		F:         f,
-		Samples:   50,
-		LineStyle: DefaultLineStyle,
+		F:          f,
+		Samples:    50,
+		LineStyle:  DefaultLineStyle,
+		Tolerance:  DefaultFunctionTolerance,
+		MaxSamples: DefaultFunctionMaxSamples,
 	}
 }
 
@@ -31,6 +67,12 @@ func NewFunction(f func(float64) float64) *Function {
 func (f *Function) Plot(da plot.DrawArea, p *plot.Plot) {
 	trX, trY := p.Transforms(&da)
 
+	if f.Adaptive {
+		line := f.adaptiveSamples(trX, trY, p.X.Min, p.X.Max)
+		da.StrokeLines(f.LineStyle, da.ClipLinesXY(line)...)
+		return
+	}
+
 	d := (p.X.Max - p.X.Min) / float64(f.Samples-1)
 	line := make([]plot.Point, f.Samples)
 	for i := range line {
@@ -41,6 +83,78 @@ func (f *Function) Plot(da plot.DrawArea, p *plot.Plot) {
 	da.StrokeLines(f.LineStyle, da.ClipLinesXY(line)...)
 }
 
+// AutoColor sets the color of the line if it is
+// the default color, implementing the
+// plot.AutoColorer interface.
+func (f *Function) AutoColor(c color.Color) bool {
+	if !unsetColor(f.LineStyle.Color) {
+		return false
+	}
+	f.LineStyle.Color = c
+	return true
+}
+
+// adaptiveSamples returns the points of the function
+// between min and max, sampled adaptively.
+func (f *Function) adaptiveSamples(trX, trY func(float64) vg.Length, min, max float64) []plot.Point {
+	n := f.Samples
+	if n < 2 {
+		n = 2
+	}
+	xs := make([]float64, n)
+	d := (max - min) / float64(n-1)
+	for i := range xs {
+		xs[i] = min + float64(i)*d
+	}
+	pts := make([]plot.Point, n)
+	for i, x := range xs {
+		pts[i] = plot.Pt(trX(x), trY(f.F(x)))
+	}
+
+	for len(pts) < f.MaxSamples {
+		nxs := make([]float64, 1, 2*len(xs))
+		npts := make([]plot.Point, 1, 2*len(pts))
+		nxs[0], npts[0] = xs[0], pts[0]
+		split := false
+		for i := 1; i < len(xs); i++ {
+			if len(npts)+len(xs)-i < f.MaxSamples {
+				x := (xs[i-1] + xs[i]) / 2
+				mid := plot.Pt(trX(x), trY(f.F(x)))
+				if deviation(pts[i-1], pts[i], mid) > f.Tolerance {
+					nxs = append(nxs, x)
+					npts = append(npts, mid)
+					split = true
+				}
+			}
+			nxs = append(nxs, xs[i])
+			npts = append(npts, pts[i])
+		}
+		xs, pts = nxs, npts
+		if !split {
+			break
+		}
+	}
+	return pts
+}
+
+// deviation returns the distance from the point p to
+// the line segment between a and b.  If any of the
+// points are not finite then the deviation is infinite.
+func deviation(a, b, p plot.Point) vg.Length {
+	for _, v := range []vg.Length{a.X, a.Y, b.X, b.Y, p.X, p.Y} {
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return vg.Length(math.Inf(1))
+		}
+	}
+	dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+	px, py := float64(p.X-a.X), float64(p.Y-a.Y)
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return vg.Length(math.Hypot(px, py))
+	}
+	return vg.Length(math.Abs(dx*py-dy*px) / l)
+}
+
 // Thumbnail draws a line in the given style down the
 // center of a DrawArea as a thumbnail representation
 // of the LineStyle of the function.
@@ -48,3 +162,98 @@ func (f Function) Thumbnail(da *plot.DrawArea) {
 	y := da.Center().Y
 	da.StrokeLine2(f.LineStyle, da.Min.X, y, da.Max().X, y)
 }
+
+// Parametric implements the Plotter interface,
+// drawing a line for a parametric curve: the points
+// (x, y) = F(t) for t from TMin to TMax, such as a
+// circle, a spiral or a Lissajous figure.
+type Parametric struct {
+	F          func(t float64) (x, y float64)
+	TMin, TMax float64
+
+	// Samples is the number of evenly spaced
+	// values of t at which F is sampled.  At least
+	// two samples are always taken.
+	Samples int
+
+	plot.LineStyle
+}
+
+// NewParametric returns a Parametric that plots F for
+// t from tmin to tmax, sampled at the given number of
+// evenly spaced values of t, using the default line
+// style.
+func NewParametric(f func(t float64) (x, y float64), tmin, tmax float64, samples int) *Parametric {
+	return &Parametric{
+		F:         f,
+		TMin:      tmin,
+		TMax:      tmax,
+		Samples:   samples,
+		LineStyle: DefaultLineStyle,
+	}
+}
+
+// points returns the sampled points of the curve.
+func (f *Parametric) points() XYs {
+	n := f.Samples
+	if n < 2 {
+		n = 2
+	}
+	pts := make(XYs, n)
+	d := (f.TMax - f.TMin) / float64(n-1)
+	for i := range pts {
+		pts[i].X, pts[i].Y = f.F(f.TMin + float64(i)*d)
+	}
+	return pts
+}
+
+// Plot implements the Plotter interface, drawing a
+// line through the sampled points of the curve.  The
+// line is broken at points that are not finite, such
+// as where the curve goes off to infinity.
+func (f *Parametric) Plot(da plot.DrawArea, p *plot.Plot) {
+	trX, trY := p.Transforms(&da)
+	for _, pts := range finiteRuns(f.points()) {
+		line := make([]plot.Point, len(pts))
+		for i, pt := range pts {
+			line[i] = plot.Pt(trX(pt.X), trY(pt.Y))
+		}
+		da.StrokeLines(f.LineStyle, da.ClipLinesXY(line)...)
+	}
+}
+
+// DataRange returns the minimum and maximum X and Y
+// values of the sampled points of the curve, ignoring
+// points that are not finite, implementing the
+// plot.DataRanger interface.
+func (f *Parametric) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for _, pt := range f.points() {
+		if CheckFloats(pt.X, pt.Y) != nil {
+			continue
+		}
+		xmin, xmax = math.Min(xmin, pt.X), math.Max(xmax, pt.X)
+		ymin, ymax = math.Min(ymin, pt.Y), math.Max(ymax, pt.Y)
+	}
+	return
+}
+
+// AutoColor sets the color of the line if it is
+// the default color, implementing the
+// plot.AutoColorer interface.
+func (f *Parametric) AutoColor(c color.Color) bool {
+	if !unsetColor(f.LineStyle.Color) {
+		return false
+	}
+	f.LineStyle.Color = c
+	return true
+}
+
+// Thumbnail draws a line in the given style down the
+// center of a DrawArea, implementing the
+// plot.Thumbnailer interface.
+func (f *Parametric) Thumbnail(da *plot.DrawArea) {
+	y := da.Center().Y
+	da.StrokeLine2(f.LineStyle, da.Min.X, y, da.Max().X, y)
+}
@@ -0,0 +1,78 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+func TestFunctionAdaptiveSamples(t *testing.T) {
+	spike := func(x float64) float64 {
+		return 100 * math.Exp(-(x-0.51)*(x-0.51)/8e-4)
+	}
+	f := NewFunction(spike)
+	f.Samples = 10
+	f.Adaptive = true
+	tr := func(x float64) vg.Length { return vg.Length(x * 100) }
+
+	pts := f.adaptiveSamples(tr, func(y float64) vg.Length { return vg.Length(y) }, 0, 1)
+	if len(pts) > f.MaxSamples {
+		t.Errorf("got %d samples, want at most %d", len(pts), f.MaxSamples)
+	}
+	max := vg.Length(0)
+	for i, p := range pts {
+		if i > 0 && p.X <= pts[i-1].X {
+			t.Fatalf("samples are not increasing in x at %d", i)
+		}
+		if p.Y > max {
+			max = p.Y
+		}
+	}
+	if max < 99 {
+		t.Errorf("adaptive sampling missed the spike: max sampled y is %g", max)
+	}
+
+	f.F = func(x float64) float64 { return 2*x + 1 }
+	pts = f.adaptiveSamples(tr, tr, 0, 1)
+	if len(pts) != f.Samples {
+		t.Errorf("got %d samples of a straight line, want %d", len(pts), f.Samples)
+	}
+}
+
+func TestParametricDataRange(t *testing.T) {
+	f := NewParametric(func(t float64) (x, y float64) {
+		return math.Cos(t), 2 * math.Sin(t)
+	}, 0, 2*math.Pi, 5)
+	xmin, xmax, ymin, ymax := f.DataRange()
+	const tol = 1e-12
+	if math.Abs(xmin+1) > tol || math.Abs(xmax-1) > tol || math.Abs(ymin+2) > tol || math.Abs(ymax-2) > tol {
+		t.Errorf("data range is [%v, %v] × [%v, %v], want [-1, 1] × [-2, 2]", xmin, xmax, ymin, ymax)
+	}
+}
+
+func TestParametricGaps(t *testing.T) {
+	// The curve is undefined at t = 2, so the line
+	// is broken there.
+	f := NewParametric(func(t float64) (x, y float64) {
+		if t == 2 {
+			return t, math.Inf(1)
+		}
+		return t, -t
+	}, 0, 5, 6)
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(f)
+	line := f.LineStyle.Color
+	checkTrace(t, p, []tracedBox{
+		{plot.TracedLine, line, 0, 1, -1, 0},
+		{plot.TracedLine, line, 3, 5, -5, -3},
+	})
+}
@@ -0,0 +1,186 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// A GanttTask is a task of a Gantt chart.
+type GanttTask struct {
+	// Category is the name of the row of the
+	// task.  Tasks with the same Category share
+	// a row.
+	Category string
+
+	// Start and End are the X values at which
+	// the task starts and ends.
+	Start, End float64
+
+	// Color, if non-nil, is the fill color of
+	// the task, instead of the Gantt's Color.
+	Color color.Color
+
+	// Progress is the fraction of the task that
+	// is done, from 0 to 1.  That fraction of the
+	// span, from its start, is filled with the
+	// Gantt's ProgressColor.
+	Progress float64
+}
+
+// Gantt implements the Plotter interface, drawing a
+// Gantt chart: each task is a horizontal span from its
+// start to its end on the row of its category.  The
+// rows are at the Y values 0, 1 and so on, in the order
+// in which their categories first appear among the
+// tasks, and can be named with plot.NominalY, using the
+// names returned by Categories.
+type Gantt struct {
+	// Tasks are the tasks.
+	Tasks []GanttTask
+
+	// Height is the height of the spans.
+	Height vg.Length
+
+	// Color is the fill color of the spans of
+	// the tasks that have no color of their own.
+	Color color.Color
+
+	// ProgressColor is the fill color of the
+	// parts of the spans that are done.
+	ProgressColor color.Color
+
+	// LineStyle is the style of the outlines of
+	// the spans.
+	plot.LineStyle
+}
+
+// NewGantt returns a Gantt chart of the tasks, which
+// it copies, with spans of the given height, gray fills
+// and the default line style.
+func NewGantt(tasks []GanttTask, height vg.Length) (*Gantt, error) {
+	if height <= 0 {
+		return nil, errors.New("Height parameter was not positive")
+	}
+	if len(tasks) == 0 {
+		return nil, ErrNoData
+	}
+	for _, t := range tasks {
+		if err := CheckFloats(t.Start, t.End, t.Progress); err != nil {
+			return nil, err
+		}
+		if t.End < t.Start {
+			return nil, errors.New("Task ends before it starts")
+		}
+		if t.Progress < 0 || t.Progress > 1 {
+			return nil, errors.New("Task progress is not between 0 and 1")
+		}
+	}
+	return &Gantt{
+		Tasks:         append([]GanttTask(nil), tasks...),
+		Height:        height,
+		Color:         color.Gray{Y: 200},
+		ProgressColor: color.Gray{Y: 96},
+		LineStyle:     DefaultLineStyle,
+	}, nil
+}
+
+// Categories returns the names of the rows, in order
+// from Y value 0 up.
+func (g *Gantt) Categories() []string {
+	var cats []string
+	seen := make(map[string]bool)
+	for _, t := range g.Tasks {
+		if !seen[t.Category] {
+			seen[t.Category] = true
+			cats = append(cats, t.Category)
+		}
+	}
+	return cats
+}
+
+// rows returns the row of each task.
+func (g *Gantt) rows() []int {
+	row := make(map[string]int)
+	rows := make([]int, len(g.Tasks))
+	for i, t := range g.Tasks {
+		r, ok := row[t.Category]
+		if !ok {
+			r = len(row)
+			row[t.Category] = r
+		}
+		rows[i] = r
+	}
+	return rows
+}
+
+// Plot draws the spans of the tasks, implementing the
+// plot.Plotter interface.
+func (g *Gantt) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	rows := g.rows()
+	for i, t := range g.Tasks {
+		y := trY(float64(rows[i]))
+		ymin, ymax := y-g.Height/2, y+g.Height/2
+		rect := func(xmin, xmax vg.Length) []plot.Point {
+			return []plot.Point{
+				{xmin, ymin},
+				{xmin, ymax},
+				{xmax, ymax},
+				{xmax, ymin},
+			}
+		}
+		pts := rect(trX(t.Start), trX(t.End))
+		clr := t.Color
+		if clr == nil {
+			clr = g.Color
+		}
+		// The spans are clipped only along the time
+		// axis, so that the spans on the first and
+		// last rows are drawn whole, in the padding
+		// made for them by GlyphBoxes.
+		if clr != nil {
+			da.FillPolygon(clr, da.ClipPolygonX(pts))
+		}
+		if t.Progress > 0 && g.ProgressColor != nil {
+			done := trX(t.Start + t.Progress*(t.End-t.Start))
+			da.FillPolygon(g.ProgressColor, da.ClipPolygonX(rect(trX(t.Start), done)))
+		}
+		da.StrokeLines(g.LineStyle, da.ClipLinesX(append(pts, pts[0]))...)
+	}
+}
+
+// DataRange returns the range of the times of the
+// tasks and the range of rows, implementing the
+// plot.DataRanger interface.
+func (g *Gantt) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	for _, t := range g.Tasks {
+		xmin, xmax = math.Min(xmin, t.Start), math.Max(xmax, t.End)
+	}
+	return xmin, xmax, 0, float64(len(g.Categories()) - 1)
+}
+
+// GlyphBoxes returns a GlyphBox as high as the span
+// of each task, including its outline, implementing
+// the plot.GlyphBoxer interface.
+func (g *Gantt) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	rows := g.rows()
+	boxes := make([]plot.GlyphBox, len(g.Tasks))
+	for i, t := range g.Tasks {
+		boxes[i].X = plt.X.Norm(t.Start)
+		boxes[i].Y = plt.Y.Norm(float64(rows[i]))
+		boxes[i].Rect = plot.Rect{
+			Min:  plot.Point{Y: -(g.Height + g.LineStyle.Width) / 2},
+			Size: plot.Point{Y: g.Height + g.LineStyle.Width},
+		}
+	}
+	return boxes
+}
@@ -0,0 +1,76 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+func TestGantt(t *testing.T) {
+	if _, err := NewGantt([]GanttTask{{Start: 2, End: 1}}, vg.Points(10)); err == nil {
+		t.Errorf("no error for a task that ends before it starts")
+	}
+
+	g, err := NewGantt([]GanttTask{
+		{Category: "design", Start: 0, End: 3, Progress: 1},
+		{Category: "build", Start: 2, End: 8, Progress: 0.5},
+		{Category: "design", Start: 5, End: 6},
+		{Category: "test", Start: 7, End: 10},
+	}, vg.Points(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cats, want := g.Categories(), []string{"design", "build", "test"}; !reflect.DeepEqual(cats, want) {
+		t.Errorf("categories = %v, want %v", cats, want)
+	}
+	if rows, want := g.rows(), []int{0, 1, 0, 2}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %v, want %v", rows, want)
+	}
+	xmin, xmax, ymin, ymax := g.DataRange()
+	if xmin != 0 || xmax != 10 || ymin != 0 || ymax != 2 {
+		t.Errorf("data range = %v, %v, %v, %v, want 0, 10, 0, 2", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(g)
+	prims, err := p.Trace(vg.Inches(4), vg.Inches(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prims) < 4 {
+		t.Fatalf("traced %d primitives, want at least 4", len(prims))
+	}
+	// The height of the spans is a length, so it is
+	// taken from the fill of the span on the middle
+	// row.
+	ymin, ymax = Range(Values(prims[3].Y))
+	hh := (ymax - ymin) / 2
+
+	// Each span is filled and outlined, whole, on its
+	// row, and the done part of a span is filled over
+	// it.  The spans on the first and last rows are
+	// drawn half in the padding beyond the ends of the
+	// Y axis.
+	line := g.LineStyle.Color
+	checkTrace(t, p, []tracedBox{
+		{plot.TracedFill, g.Color, 0, 3, -hh, hh},
+		{plot.TracedFill, g.ProgressColor, 0, 3, -hh, hh},
+		{plot.TracedLine, line, 0, 3, -hh, hh},
+		{plot.TracedFill, g.Color, 2, 8, 1 - hh, 1 + hh},
+		{plot.TracedFill, g.ProgressColor, 2, 5, 1 - hh, 1 + hh},
+		{plot.TracedLine, line, 2, 8, 1 - hh, 1 + hh},
+		{plot.TracedFill, g.Color, 5, 6, -hh, hh},
+		{plot.TracedLine, line, 5, 6, -hh, hh},
+		{plot.TracedFill, g.Color, 7, 10, 2 - hh, 2 + hh},
+		{plot.TracedLine, line, 7, 10, 2 - hh, 2 + hh},
+	})
+}
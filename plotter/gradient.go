@@ -0,0 +1,150 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// DefaultGradientBands is the default number of bands
+// used to approximate a color gradient.
+var DefaultGradientBands = 32
+
+// AreaGradient implements the Plotter interface,
+// drawing a line and filling the area beneath it
+// with a vertical color gradient.
+type AreaGradient struct {
+	// XYs is a copy of the points for this area.
+	XYs
+
+	// LineStyle is the style of the line connecting
+	// the points.
+	plot.LineStyle
+
+	// Top is the fill color at the highest point
+	// of the area and Bottom is the fill color at
+	// the bottom of the data area of the plot.
+	// Colors between are linearly interpolated.
+	Top, Bottom color.Color
+
+	// Bands is the number of horizontal bands
+	// of solid color used to approximate the
+	// gradient.
+	Bands int
+}
+
+// NewAreaGradient returns an AreaGradient that uses the
+// default line style and fills the area under the line
+// with a gradient from top to bottom.
+func NewAreaGradient(xys XYer, top, bottom color.Color) (*AreaGradient, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+	if top == nil || bottom == nil {
+		return nil, errors.New("Nil gradient color")
+	}
+	return &AreaGradient{
+		XYs:       data,
+		LineStyle: DefaultLineStyle,
+		Top:       top,
+		Bottom:    bottom,
+		Bands:     DefaultGradientBands,
+	}, nil
+}
+
+// Plot draws the AreaGradient, implementing the plot.Plotter
+// interface.
+func (a *AreaGradient) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	ps := make([]plot.Point, len(a.XYs))
+	top := vg.Length(math.Inf(-1))
+	for i, p := range a.XYs {
+		ps[i].X = trX(p.X)
+		ps[i].Y = trY(p.Y)
+		if ps[i].Y > top {
+			top = ps[i].Y
+		}
+	}
+	if top > da.Max().Y {
+		top = da.Max().Y
+	}
+
+	bottom := trY(plt.Y.Min)
+	poly := make([]plot.Point, 0, len(ps)+2)
+	poly = append(poly, plot.Pt(ps[0].X, bottom))
+	poly = append(poly, ps...)
+	poly = append(poly, plot.Pt(ps[len(ps)-1].X, bottom))
+	fillGradient(&da, da.ClipPolygonXY(poly), bottom, top, a.Bottom, a.Top, a.Bands)
+
+	da.StrokeLines(a.LineStyle, da.ClipLinesXY(ps)...)
+}
+
+// DataRange returns the minimum and maximum
+// x and y values, implementing the plot.DataRanger
+// interface.
+func (a *AreaGradient) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(a)
+}
+
+// Thumbnail draws a gradient filled rectangle,
+// implementing the plot.Thumbnailer interface.
+func (a *AreaGradient) Thumbnail(da *plot.DrawArea) {
+	pts := []plot.Point{
+		{da.Min.X, da.Min.Y},
+		{da.Min.X, da.Max().Y},
+		{da.Max().X, da.Max().Y},
+		{da.Max().X, da.Min.Y},
+	}
+	fillGradient(da, pts, da.Min.Y, da.Max().Y, a.Bottom, a.Top, a.Bands)
+}
+
+// fillGradient fills a polygon with n horizontal bands of
+// color, interpolated from c0 at y0 to c1 at y1.
+func fillGradient(da *plot.DrawArea, poly []plot.Point, y0, y1 vg.Length, c0, c1 color.Color, n int) {
+	if len(poly) == 0 {
+		return
+	}
+	if n < 1 || y1 <= y0 {
+		da.FillPolygon(c1, poly)
+		return
+	}
+	h := (y1 - y0) / vg.Length(n)
+	for i := 0; i < n; i++ {
+		band := plot.DrawArea{
+			Canvas: da.Canvas,
+			Rect: plot.Rect{
+				Min:  plot.Pt(da.Min.X, y0+vg.Length(i)*h),
+				Size: plot.Pt(da.Size.X, h),
+			},
+		}
+		t := (float64(i) + 0.5) / float64(n)
+		da.FillPolygon(blend(c0, c1, t), band.ClipPolygonY(poly))
+	}
+}
+
+// blend returns the linear interpolation between
+// two colors, where t=0 gives c0 and t=1 gives c1.
+func blend(c0, c1 color.Color, t float64) color.Color {
+	n0 := color.NRGBAModel.Convert(c0).(color.NRGBA)
+	n1 := color.NRGBAModel.Convert(c1).(color.NRGBA)
+	lerp := func(a, b uint8) uint8 {
+		return uint8(float64(a) + t*(float64(b)-float64(a)) + 0.5)
+	}
+	return color.NRGBA{
+		R: lerp(n0.R, n1.R),
+		G: lerp(n0.G, n1.G),
+		B: lerp(n0.B, n1.B),
+		A: lerp(n0.A, n1.A),
+	}
+}
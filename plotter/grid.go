@@ -20,17 +20,35 @@ var (
 )
 
 // Grid implements the plot.Plotter interface, drawing
-// a set of grid lines at the major tick marks.
+// a set of grid lines at the major tick marks, and
+// optionally at the minor tick marks.  Major tick marks
+// are those with a label.
 type Grid struct {
 	// Vertical is the style of the vertical lines.
 	Vertical plot.LineStyle
 
 	// Horizontal is the style of the horizontal lines.
 	Horizontal plot.LineStyle
+
+	// VerticalMinor and HorizontalMinor are the
+	// styles of the vertical and horizontal lines at
+	// the unlabeled, minor tick marks.  Lines are
+	// only drawn at the minor tick marks of an axis
+	// if the Color of its style is non-nil.
+	VerticalMinor, HorizontalMinor plot.LineStyle
+
+	// Extra are grid lines at explicit values, such
+	// as a target value, that are drawn after the
+	// lines at the tick marks, each in its own style.
+	// They are drawn regardless of the tick marks,
+	// but like the RefLines that they are, not if
+	// they are outside of the axis range.
+	Extra []RefLine
 }
 
 // NewGrid returns a new grid with both vertical and
-// horizontal lines using the default grid line style.
+// horizontal lines at the major tick marks using the
+// default grid line style.
 func NewGrid() *Grid {
 	return &Grid{
 		Vertical:   DefaultGridLineStyle,
@@ -42,26 +60,43 @@ func NewGrid() *Grid {
 func (g *Grid) Plot(da plot.DrawArea, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&da)
 
-	if g.Vertical.Color == nil {
-		goto horiz
-	}
-	for _, tk := range plt.X.Tick.Marker(plt.X.Min, plt.X.Max) {
+	for _, tk := range plt.X.Ticks() {
+		sty := g.Vertical
 		if tk.IsMinor() {
+			sty = g.VerticalMinor
+		}
+		if sty.Color == nil {
 			continue
 		}
 		x := trX(tk.Value)
-		da.StrokeLine2(g.Vertical, x, da.Min.Y, x, da.Min.Y+da.Size.Y)
+		da.StrokeLine2(sty, x, da.Min.Y, x, da.Min.Y+da.Size.Y)
 	}
 
-horiz:
-	if g.Horizontal.Color == nil {
-		return
-	}
-	for _, tk := range plt.Y.Tick.Marker(plt.Y.Min, plt.Y.Max) {
+	for _, tk := range plt.Y.Ticks() {
+		sty := g.Horizontal
 		if tk.IsMinor() {
+			sty = g.HorizontalMinor
+		}
+		if sty.Color == nil {
 			continue
 		}
 		y := trY(tk.Value)
-		da.StrokeLine2(g.Horizontal, da.Min.X, y, da.Min.X+da.Size.X, y)
+		da.StrokeLine2(sty, da.Min.X, y, da.Min.X+da.Size.X, y)
 	}
+
+	for i := range g.Extra {
+		g.Extra[i].Plot(da, plt)
+	}
+}
+
+// AddVertical adds an extra vertical line at the
+// given X value.
+func (g *Grid) AddVertical(x float64, sty plot.LineStyle) {
+	g.Extra = append(g.Extra, *VLine(x, sty))
+}
+
+// AddHorizontal adds an extra horizontal line at
+// the given Y value.
+func (g *Grid) AddHorizontal(y float64, sty plot.LineStyle) {
+	g.Extra = append(g.Extra, *HLine(y, sty))
 }
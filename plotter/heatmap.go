@@ -0,0 +1,172 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// GridXYZ describes three dimensional data where the
+// X and Y coordinates are arranged on a rectangular
+// grid.
+type GridXYZ interface {
+	// Dims returns the number of columns
+	// and rows of the grid.
+	Dims() (c, r int)
+
+	// Z returns the value of the grid
+	// point at column c and row r.
+	Z(c, r int) float64
+
+	// X returns the X coordinate of
+	// column c.  The X coordinates must
+	// be increasing.
+	X(c int) float64
+
+	// Y returns the Y coordinate of row r.
+	// The Y coordinates must be increasing.
+	Y(r int) float64
+}
+
+// HeatMap implements the Plotter interface, drawing a
+// grid of values as a grid of cells, each centered on
+// its grid point and filled with the color of its
+// value.  The boundaries between cells are halfway
+// between the grid points, and the outer cells extend
+// as far beyond their grid points as the cells next to
+// them.  PColorMesh draws cells with explicit
+// boundaries instead.
+type HeatMap struct {
+	// GridXYZ is the data of the heat map.
+	GridXYZ
+
+	// Colors is the palette.  As in a ColorBar, the
+	// range from Min to Max is divided into len(Colors)
+	// equal parts, and each cell is filled with the color
+	// of the part containing its value.  Values outside
+	// of the range have the color of the nearest end.
+	Colors []color.Color
+
+	// Min and Max are the range of values mapped to
+	// the palette.  NewHeatMap sets them to the range
+	// of the finite values of the grid.
+	Min, Max float64
+}
+
+// NewHeatMap returns a HeatMap of the grid with the
+// given palette.  Grid points whose value is NaN are
+// not drawn.
+func NewHeatMap(g GridXYZ, colors []color.Color) (*HeatMap, error) {
+	if len(colors) == 0 {
+		return nil, errors.New("No colors in the palette")
+	}
+	c, r := g.Dims()
+	if c == 0 || r == 0 {
+		return nil, ErrNoData
+	}
+	h := &HeatMap{
+		GridXYZ: g,
+		Colors:  colors,
+		Min:     math.Inf(1),
+		Max:     math.Inf(-1),
+	}
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			v := g.Z(i, j)
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			h.Min, h.Max = math.Min(h.Min, v), math.Max(h.Max, v)
+		}
+	}
+	if h.Min > h.Max {
+		h.Min, h.Max = 0, 0
+	}
+	return h, nil
+}
+
+// Color returns the color of a cell with the given
+// value, or nil if the value is NaN.
+func (h *HeatMap) Color(v float64) color.Color {
+	return paletteColor(h.Colors, h.Min, h.Max, v)
+}
+
+// cellBounds returns the boundaries of the n cells
+// centered on the coordinates given by at.  A single
+// cell is one unit wide.
+func cellBounds(n int, at func(int) float64) []float64 {
+	b := make([]float64, n+1)
+	if n == 1 {
+		b[0], b[1] = at(0)-0.5, at(0)+0.5
+		return b
+	}
+	for i := 1; i < n; i++ {
+		b[i] = (at(i-1) + at(i)) / 2
+	}
+	b[0] = at(0) - (b[1] - at(0))
+	b[n] = at(n-1) + (at(n-1) - b[n-1])
+	return b
+}
+
+// Plot draws the cells, implementing the plot.Plotter
+// interface.
+func (h *HeatMap) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	c, r := h.Dims()
+	xs, ys := cellBounds(c, h.X), cellBounds(r, h.Y)
+	for i := 0; i < c; i++ {
+		xlo, xhi := trX(xs[i]), trX(xs[i+1])
+		for j := 0; j < r; j++ {
+			v := h.Z(i, j)
+			if math.IsNaN(v) {
+				continue
+			}
+			ylo, yhi := trY(ys[j]), trY(ys[j+1])
+			pts := []plot.Point{
+				{xlo, ylo},
+				{xhi, ylo},
+				{xhi, yhi},
+				{xlo, yhi},
+			}
+			da.FillPolygon(h.Color(v), da.ClipPolygonXY(pts))
+		}
+	}
+}
+
+// DataRange returns the range of the cells,
+// implementing the plot.DataRanger interface.
+func (h *HeatMap) DataRange() (xmin, xmax, ymin, ymax float64) {
+	c, r := h.Dims()
+	xs, ys := cellBounds(c, h.X), cellBounds(r, h.Y)
+	return xs[0], xs[c], ys[0], ys[r]
+}
+
+// GlyphBoxes returns a box of no size at the grid
+// point of each cell, implementing the plot.GlyphBoxer
+// interface.  The cells are within the data range, so
+// they need no padding.
+func (h *HeatMap) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	c, r := h.Dims()
+	boxes := make([]plot.GlyphBox, 0, c*r)
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			boxes = append(boxes, plot.GlyphBox{
+				X: plt.X.Norm(h.X(i)),
+				Y: plt.Y.Norm(h.Y(j)),
+			})
+		}
+	}
+	return boxes
+}
+
+// ColorBar returns a horizontal ColorBar that shows
+// the palette and range of values of the heat map.
+func (h *HeatMap) ColorBar() *ColorBar {
+	return newColorBar(h.Colors, h.Min, h.Max)
+}
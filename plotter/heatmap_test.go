@@ -0,0 +1,42 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// testGrid is a GridXYZ of values indexed by row
+// then column, with the grid points at whole numbers.
+type testGrid [][]float64
+
+func (g testGrid) Dims() (c, r int)   { return len(g[0]), len(g) }
+func (g testGrid) Z(c, r int) float64 { return g[r][c] }
+func (g testGrid) X(c int) float64    { return float64(c) }
+func (g testGrid) Y(r int) float64    { return float64(2 * r) }
+
+func TestHeatMap(t *testing.T) {
+	lo, hi := color.Gray{Y: 0}, color.Gray{Y: 255}
+	h, err := NewHeatMap(testGrid{{1, 2, 3}, {4, math.NaN(), 6}}, []color.Color{lo, hi})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h.Min != 1 || h.Max != 6 {
+		t.Errorf("value range is [%g, %g], want [1, 6]", h.Min, h.Max)
+	}
+	if h.Color(1) != lo || h.Color(6) != hi {
+		t.Errorf("extreme values have the wrong colors")
+	}
+	xmin, xmax, ymin, ymax := h.DataRange()
+	if xmin != -0.5 || xmax != 2.5 || ymin != -1 || ymax != 3 {
+		t.Errorf("data range is [%g, %g]×[%g, %g], want [-0.5, 2.5]×[-1, 3]", xmin, xmax, ymin, ymax)
+	}
+	if got := cellBounds(1, func(int) float64 { return 4 }); !reflect.DeepEqual(got, []float64{3.5, 4.5}) {
+		t.Errorf("bounds of a single cell are %v, want [3.5 4.5]", got)
+	}
+}
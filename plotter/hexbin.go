@@ -0,0 +1,193 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// HexBin implements the Plotter interface, drawing a
+// two dimensional histogram of points in hexagonal
+// bins: the plane is tiled with hexagons, and each
+// hexagon that contains points is filled with the color
+// of its value, which by default is the number of points
+// in it.  Unlike a Scatter, a HexBin shows the density
+// of points where they overlap, and it draws no more
+// than one hexagon per bin however many points there are.
+//
+// The hexagons have vertical sides and a point at the
+// top and bottom.  The rows of hexagons are offset by
+// half of a hexagon from each other.
+type HexBin struct {
+	// XYs is a copy of the points.
+	XYs
+
+	// Width is the width of the hexagons, between
+	// their vertical sides, and Height is their
+	// height, between their points, both in data
+	// coordinates.  A hexagon is regular when it is
+	// drawn with a height of 2/√3 times its width.
+	Width, Height float64
+
+	// Colors is the palette.  As in a HeatMap, the
+	// range from Min to Max is divided into len(Colors)
+	// equal parts, and each bin is filled with the color
+	// of the part containing its value.
+	Colors []color.Color
+
+	// Min and Max are the range of values mapped to
+	// the palette.  If Max is not greater than Min
+	// then the range of the values of the bins that
+	// contain points is used.
+	Min, Max float64
+
+	// Value, if non-nil, returns the value of a bin
+	// from the indices of the points in it, such as
+	// the mean of a third variable of the points.  If
+	// Value is nil then the value of a bin is the
+	// number of points in it.
+	Value func(indices []int) float64
+}
+
+// A hexBin is the column and row of a bin of a HexBin.
+// The odd rows are offset by half of a hexagon to the
+// right of the even rows.
+type hexBin struct {
+	c, r int
+}
+
+// NewHexBin returns a HexBin of the points with
+// hexagons of the given width and height and the
+// given palette.
+func NewHexBin(xys XYer, width, height float64, colors []color.Color) (*HexBin, error) {
+	if len(colors) == 0 {
+		return nil, errors.New("No colors in the palette")
+	}
+	if !(width > 0) || !(height > 0) {
+		return nil, errors.New("Hexagon size is not positive")
+	}
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &HexBin{
+		XYs:    data,
+		Width:  width,
+		Height: height,
+		Colors: colors,
+	}, nil
+}
+
+// bin returns the bin containing the point x, y.
+func (h *HexBin) bin(x, y float64) hexBin {
+	// In coordinates in which the hexagons are
+	// regular and one unit wide, the rows are √3/2
+	// apart, and the even and the odd rows are each
+	// a rectangular lattice of centers.  A point is in
+	// the bin whose center is nearest, which is the
+	// nearer of the nearest centers of each lattice.
+	s3 := math.Sqrt(3)
+	u, v := x/h.Width, y/h.Height*2/s3
+	ec, er := math.Floor(u+0.5), math.Floor(v/s3+0.5)
+	oc, or := math.Floor(u), math.Floor((v-s3/2)/s3+0.5)
+	de := (u-ec)*(u-ec) + (v-er*s3)*(v-er*s3)
+	do := (u-oc-0.5)*(u-oc-0.5) + (v-or*s3-s3/2)*(v-or*s3-s3/2)
+	if de <= do {
+		return hexBin{c: int(ec), r: 2 * int(er)}
+	}
+	return hexBin{c: int(oc), r: 2*int(or) + 1}
+}
+
+// center returns the center of a bin.
+func (h *HexBin) center(b hexBin) (x, y float64) {
+	x = float64(b.c) * h.Width
+	if b.r%2 != 0 {
+		x += h.Width / 2
+	}
+	return x, float64(b.r) * h.Height * 3 / 4
+}
+
+// Bins returns the center and the value of each
+// bin that contains points.
+func (h *HexBin) Bins() XYZs {
+	indices := make(map[hexBin][]int)
+	var order []hexBin
+	for i, p := range h.XYs {
+		b := h.bin(p.X, p.Y)
+		if _, ok := indices[b]; !ok {
+			order = append(order, b)
+		}
+		indices[b] = append(indices[b], i)
+	}
+	bins := make(XYZs, len(order))
+	for i, b := range order {
+		bins[i].X, bins[i].Y = h.center(b)
+		if h.Value != nil {
+			bins[i].Z = h.Value(indices[b])
+		} else {
+			bins[i].Z = float64(len(indices[b]))
+		}
+	}
+	return bins
+}
+
+// valueRange returns the range of values mapped to
+// the palette.
+func (h *HexBin) valueRange(bins XYZs) (min, max float64) {
+	if h.Max > h.Min {
+		return h.Min, h.Max
+	}
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, b := range bins {
+		if math.IsNaN(b.Z) || math.IsInf(b.Z, 0) {
+			continue
+		}
+		min, max = math.Min(min, b.Z), math.Max(max, b.Z)
+	}
+	if min > max {
+		return 0, 0
+	}
+	return min, max
+}
+
+// Plot draws the bins, implementing the plot.Plotter
+// interface.
+func (h *HexBin) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	bins := h.Bins()
+	min, max := h.valueRange(bins)
+	for _, b := range bins {
+		if math.IsNaN(b.Z) {
+			continue
+		}
+		pts := make([]plot.Point, 6)
+		for k := range pts {
+			a := math.Pi/6 + float64(k)*math.Pi/3
+			x := b.X + h.Width/math.Sqrt(3)*math.Cos(a)
+			y := b.Y + h.Height/2*math.Sin(a)
+			pts[k] = plot.Pt(trX(x), trY(y))
+		}
+		da.FillPolygon(paletteColor(h.Colors, min, max, b.Z), da.ClipPolygonXY(pts))
+	}
+}
+
+// DataRange returns the range of the bins that
+// contain points, implementing the plot.DataRanger
+// interface.
+func (h *HexBin) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(XYValues{h.Bins()})
+	return xmin - h.Width/2, xmax + h.Width/2, ymin - h.Height/2, ymax + h.Height/2
+}
+
+// ColorBar returns a horizontal ColorBar that shows
+// the palette and range of values of the bins.
+func (h *HexBin) ColorBar() *ColorBar {
+	min, max := h.valueRange(h.Bins())
+	return newColorBar(h.Colors, min, max)
+}
@@ -0,0 +1,53 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestHexBin(t *testing.T) {
+	colors := []color.Color{color.White, color.Black}
+	if _, err := NewHexBin(XYs{{0, 0}}, 0, 1, colors); err == nil {
+		t.Errorf("no error for a zero width")
+	}
+
+	w, h := 1.0, 2/math.Sqrt(3)
+	pts := XYs{
+		{0, 0}, {0.1, 0.1}, {-0.2, 0.1}, // the bin at the origin
+		{0.5, 3 * h / 4}, // the odd bin above and to the right
+		{0.45, 0.45},     // nearer to the odd bin
+		{3, 0},
+	}
+	hb, err := NewHexBin(pts, w, h, colors)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[hexBin]float64{
+		{c: 0, r: 0}: 3,
+		{c: 0, r: 1}: 2,
+		{c: 3, r: 0}: 1,
+	}
+	bins := hb.Bins()
+	if len(bins) != len(want) {
+		t.Fatalf("got %d bins, want %d", len(bins), len(want))
+	}
+	for _, b := range bins {
+		x, y := hb.center(hb.bin(b.X, b.Y))
+		if math.Abs(x-b.X) > 1e-9 || math.Abs(y-b.Y) > 1e-9 {
+			t.Errorf("bin center %v, %v is not in its own bin", b.X, b.Y)
+		}
+		if n := want[hb.bin(b.X, b.Y)]; b.Z != n {
+			t.Errorf("bin at %v, %v has %v points, want %v", b.X, b.Y, b.Z, n)
+		}
+	}
+
+	hb.Value = func(indices []int) float64 { return -float64(len(indices)) }
+	if min, max := hb.valueRange(hb.Bins()); min != -3 || max != -1 {
+		t.Errorf("value range = %v, %v, want -3, -1", min, max)
+	}
+}
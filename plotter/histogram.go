@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"image/color"
 	"math"
+	"sort"
 
 	"github.com/gonum/plot/plot"
 )
@@ -61,6 +62,135 @@ func NewHist(vs Valuer, n int) (*Histogram, error) {
 	return NewHistogram(unitYs{vs}, n)
 }
 
+// NewWeightedHistogram returns a new histogram of
+// the values, as in NewHist, except that the bin of
+// each value is increased by its weight instead of
+// by one.  There must be a weight for each value.
+func NewWeightedHistogram(values, weights Values, n int) (*Histogram, error) {
+	if len(values) != len(weights) {
+		return nil, errors.New("Values and weights have different lengths")
+	}
+	if len(values) == 0 {
+		return nil, ErrNoData
+	}
+	if err := CheckFloats(values...); err != nil {
+		return nil, err
+	}
+	if err := CheckFloats(weights...); err != nil {
+		return nil, err
+	}
+	return NewHistogram(weightedYs{values, weights}, n)
+}
+
+// weightedYs is an XYer of values and their weights.
+type weightedYs struct {
+	values, weights Values
+}
+
+func (w weightedYs) Len() int {
+	return len(w.values)
+}
+
+func (w weightedYs) XY(i int) (float64, float64) {
+	return w.values[i], w.weights[i]
+}
+
+// A BinRule is a rule for choosing the number of
+// bins of a histogram from its data.
+type BinRule int
+
+const (
+	// Sturges uses 1 + log₂(n) bins for n values.
+	// It suits data that are roughly normal, and
+	// gives too few bins for large data sets.
+	Sturges BinRule = iota
+
+	// FreedmanDiaconis uses bins that are
+	// 2 IQR / ∛n wide, where IQR is the
+	// interquartile range of the values.  It is
+	// robust to outliers.
+	FreedmanDiaconis
+
+	// Scott uses bins that are 3.49 σ / ∛n wide,
+	// where σ is the standard deviation of the
+	// values.
+	Scott
+)
+
+// NewHistogramAuto returns a new histogram of the
+// values, as in NewHist, with the number of bins
+// chosen by the given rule.  If the rule gives bins
+// of zero width, such as when more than half of the
+// values are equal, Sturges' rule is used instead.
+func NewHistogramAuto(vs Valuer, rule BinRule) (*Histogram, error) {
+	vals, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	return NewHist(vals, binCount(vals, rule))
+}
+
+// binCount returns the number of bins chosen by the
+// rule for the values, which is at least one and at
+// most the number of values.
+func binCount(vals Values, rule BinRule) int {
+	n := float64(len(vals))
+	sturges := int(math.Ceil(math.Log2(n))) + 1
+	min, max := Range(vals)
+
+	var width float64
+	switch rule {
+	case FreedmanDiaconis:
+		sorted := append(Values(nil), vals...)
+		sort.Float64s(sorted)
+		iqr := quantile(sorted, 0.75) - quantile(sorted, 0.25)
+		width = 2 * iqr / math.Cbrt(n)
+	case Scott:
+		width = 3.49 * stdDev(vals) / math.Cbrt(n)
+	}
+	if width <= 0 || max <= min {
+		return sturges
+	}
+	bins := int(math.Ceil((max - min) / width))
+	if bins > len(vals) {
+		bins = len(vals)
+	}
+	if bins < 1 {
+		bins = 1
+	}
+	return bins
+}
+
+// quantile returns the pth quantile of the sorted
+// values, interpolating linearly between them.
+func quantile(sorted Values, p float64) float64 {
+	pos := p * float64(len(sorted)-1)
+	i := int(pos)
+	if i+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	frac := pos - float64(i)
+	return sorted[i] + frac*(sorted[i+1]-sorted[i])
+}
+
+// stdDev returns the sample standard deviation of
+// the values, or zero if there is only one.
+func stdDev(vals Values) float64 {
+	if len(vals) < 2 {
+		return 0
+	}
+	var mean float64
+	for _, v := range vals {
+		mean += v
+	}
+	mean /= float64(len(vals))
+	var ss float64
+	for _, v := range vals {
+		ss += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(ss / float64(len(vals)-1))
+}
+
 type unitYs struct {
 	Valuer
 }
@@ -120,6 +250,25 @@ func (h *Histogram) Normalize(sum float64) {
 	}
 }
 
+// NormalizeTotal normalizes the histogram so that
+// the weights of its bins sum to a given value,
+// regardless of the width of the bins.  For example,
+// with a total of one the weight of each bin is its
+// fraction of the total weight.  The histogram is
+// unchanged if the total weight of its bins is zero.
+func (h *Histogram) NormalizeTotal(total float64) {
+	mass := 0.0
+	for _, b := range h.Bins {
+		mass += b.Weight
+	}
+	if mass == 0 {
+		return
+	}
+	for i := range h.Bins {
+		h.Bins[i].Weight *= total / mass
+	}
+}
+
 // binPoints returns a slice containing the
 // given number of bins, and the width of
 // each bin.
@@ -0,0 +1,163 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// Histogram2D implements the Plotter interface, drawing
+// a two dimensional histogram of points: the range of
+// the points is divided into a grid of equal rectangular
+// bins, and each bin that contains points is filled with
+// the color of the number of points in it.
+type Histogram2D struct {
+	// X and Y are the increasing boundaries of the
+	// columns and rows of bins.  Column i extends
+	// from X[i] to X[i+1], and row j from Y[j] to
+	// Y[j+1].
+	X, Y []float64
+
+	// Counts are the numbers of points in the
+	// bins, indexed by row then column.
+	Counts [][]float64
+
+	// Colors is the palette.  The range of the
+	// counts of the bins that contain points is
+	// divided into len(Colors) equal parts, and
+	// each bin is filled with the color of the
+	// part containing its count.
+	Colors []color.Color
+
+	// Log, if true, colors the bins by the base
+	// 10 logarithm of their counts, so that bins
+	// with few points are distinguishable when a
+	// few bins have many points.
+	Log bool
+}
+
+// NewHistogram2D returns a Histogram2D of the points
+// with the given numbers of columns and rows of bins
+// spanning the range of the points.
+func NewHistogram2D(xys XYer, cols, rows int, colors []color.Color) (*Histogram2D, error) {
+	if len(colors) == 0 {
+		return nil, errors.New("No colors in the palette")
+	}
+	if cols <= 0 || rows <= 0 {
+		return nil, errors.New("Histogram with non-positive number of bins")
+	}
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+	xmin, xmax, ymin, ymax := XYRange(data)
+	h := &Histogram2D{
+		X:      binBounds(xmin, xmax, cols),
+		Y:      binBounds(ymin, ymax, rows),
+		Counts: make([][]float64, rows),
+		Colors: colors,
+	}
+	for j := range h.Counts {
+		h.Counts[j] = make([]float64, cols)
+	}
+	for _, p := range data {
+		i, j := binIndex(h.X, p.X), binIndex(h.Y, p.Y)
+		h.Counts[j][i]++
+	}
+	return h, nil
+}
+
+// binBounds returns the boundaries of n equal bins
+// from min to max.  If max is not greater than min
+// then the bins span a unit range centered on min.
+func binBounds(min, max float64, n int) []float64 {
+	if max <= min {
+		min, max = min-0.5, min+0.5
+	}
+	bs := make([]float64, n+1)
+	for i := range bs {
+		bs[i] = min + float64(i)*(max-min)/float64(n)
+	}
+	bs[n] = max
+	return bs
+}
+
+// binIndex returns the index of the bin of v among
+// the equal bins with the given boundaries, which
+// must contain v.  The last bin includes its upper
+// boundary.
+func binIndex(bounds []float64, v float64) int {
+	n := len(bounds) - 1
+	i := int(float64(n) * (v - bounds[0]) / (bounds[n] - bounds[0]))
+	if i >= n {
+		i = n - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
+
+// mesh returns a PColorMesh of the bins that contain
+// points, with the value of each bin being its count
+// or, if Log is true, the logarithm of its count.
+func (h *Histogram2D) mesh() *PColorMesh {
+	m := &PColorMesh{
+		X:      h.X,
+		Y:      h.Y,
+		Values: make([][]float64, len(h.Counts)),
+		Colors: h.Colors,
+		Min:    math.Inf(1),
+		Max:    math.Inf(-1),
+	}
+	for j, row := range h.Counts {
+		m.Values[j] = make([]float64, len(row))
+		for i, n := range row {
+			v := math.NaN()
+			switch {
+			case n <= 0:
+			case h.Log:
+				v = math.Log10(n)
+			default:
+				v = n
+			}
+			m.Values[j][i] = v
+			if !math.IsNaN(v) {
+				m.Min, m.Max = math.Min(m.Min, v), math.Max(m.Max, v)
+			}
+		}
+	}
+	if m.Min > m.Max {
+		m.Min, m.Max = 0, 0
+	}
+	return m
+}
+
+// Plot draws the bins that contain points,
+// implementing the plot.Plotter interface.
+func (h *Histogram2D) Plot(da plot.DrawArea, plt *plot.Plot) {
+	h.mesh().Plot(da, plt)
+}
+
+// DataRange returns the range of the bin boundaries,
+// implementing the plot.DataRanger interface.
+func (h *Histogram2D) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return h.X[0], h.X[len(h.X)-1], h.Y[0], h.Y[len(h.Y)-1]
+}
+
+// ColorBar returns a horizontal ColorBar that shows
+// the palette and range of counts of the bins.  If
+// Log is true then the range is of the logarithms
+// of the counts.
+func (h *Histogram2D) ColorBar() *ColorBar {
+	return h.mesh().ColorBar()
+}
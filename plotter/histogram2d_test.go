@@ -0,0 +1,55 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+)
+
+func TestHistogram2D(t *testing.T) {
+	colors := []color.Color{color.White, color.Gray{Y: 128}, color.Black}
+	if _, err := NewHistogram2D(XYs{{0, 0}}, 0, 1, colors); err == nil {
+		t.Errorf("no error for zero columns")
+	}
+
+	pts := XYs{{0, 0}, {0.5, 0.5}, {4, 0}, {4, 2}, {4, 2}, {4, 2}}
+	h, err := NewHistogram2D(pts, 2, 2, colors)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]float64{{2, 1}, {0, 3}}
+	for j, row := range want {
+		for i, n := range row {
+			if h.Counts[j][i] != n {
+				t.Errorf("count of bin %d, %d = %v, want %v", i, j, h.Counts[j][i], n)
+			}
+		}
+	}
+	if c := h.ColorBar(); c.Min != 1 || c.Max != 3 {
+		t.Errorf("count range = %v, %v, want 1, 3", c.Min, c.Max)
+	}
+	h.Log = true
+	if c := h.ColorBar(); c.Min != 0 || c.Max != math.Log10(3) {
+		t.Errorf("log count range = %v, %v, want 0, log10(3)", c.Min, c.Max)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h.Log = false
+	p.Add(h)
+	// Only the bins that contain points are filled,
+	// with the colors of their counts of 2, 1 and 3.
+	checkTrace(t, p, []tracedBox{
+		{plot.TracedFill, colors[1], 0, 2, 0, 1},
+		{plot.TracedFill, colors[0], 2, 4, 0, 1},
+		{plot.TracedFill, colors[2], 2, 4, 1, 2},
+	})
+}
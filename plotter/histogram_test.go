@@ -0,0 +1,62 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWeightedHistogram(t *testing.T) {
+	h, err := NewWeightedHistogram(Values{0, 1, 1.5, 4}, Values{2, 1, 3, 4}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []float64{6, 4}
+	for i, b := range h.Bins {
+		if b.Weight != want[i] {
+			t.Errorf("bin %d has weight %v, want %v", i, b.Weight, want[i])
+		}
+	}
+
+	h.NormalizeTotal(1)
+	want = []float64{0.6, 0.4}
+	for i, b := range h.Bins {
+		if math.Abs(b.Weight-want[i]) > 1e-12 {
+			t.Errorf("normalized bin %d has weight %v, want %v", i, b.Weight, want[i])
+		}
+	}
+
+	if _, err := NewWeightedHistogram(Values{1, 2}, Values{1}, 2); err == nil {
+		t.Errorf("expected an error for mismatched weights")
+	}
+}
+
+func TestBinCount(t *testing.T) {
+	vals := make(Values, 1000)
+	for i := range vals {
+		vals[i] = float64(i)
+	}
+	for _, test := range []struct {
+		rule BinRule
+		want int
+	}{
+		// 1 + ⌈log₂ 1000⌉.
+		{Sturges, 11},
+		// IQR is 499.5, giving a width of 99.9.
+		{FreedmanDiaconis, 10},
+		// σ is 288.82, giving a width of 100.80.
+		{Scott, 10},
+	} {
+		if n := binCount(vals, test.rule); n != test.want {
+			t.Errorf("rule %d gave %d bins, want %d", test.rule, n, test.want)
+		}
+	}
+
+	same := Values{1, 1, 1, 1, 1, 2}
+	if n := binCount(same, FreedmanDiaconis); n != 4 {
+		t.Errorf("zero IQR gave %d bins, want Sturges' 4", n)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/gonum/plot/plot"
+)
+
+// Inset implements the Plotter interface, drawing
+// another plot, with its own axes and coordinate
+// system, in a rectangle within the data area.
+type Inset struct {
+	// Inner is the plot drawn in the inset.
+	Inner *plot.Plot
+
+	// Left, Bottom, Width and Height give the
+	// rectangle of the inset as fractions of the
+	// width and height of the data area, measured
+	// from its bottom-left corner.
+	Left, Bottom, Width, Height float64
+
+	// BackgroundColor fills the inset's rectangle
+	// before the inset plot is drawn, hiding the
+	// data beneath it.  If it is nil then the inset
+	// is drawn over the data.
+	BackgroundColor color.Color
+
+	// Border is the style of the line drawn around
+	// the inset's rectangle.  If its Color is nil
+	// then no border is drawn.
+	Border plot.LineStyle
+}
+
+// NewInset returns an Inset that draws p in the given
+// rectangle, given as fractions of the data area, with
+// a white background and no border.
+func NewInset(p *plot.Plot, left, bottom, width, height float64) (*Inset, error) {
+	if p == nil {
+		return nil, errors.New("No plot for the inset")
+	}
+	if err := CheckFloats(left, bottom, width, height); err != nil {
+		return nil, err
+	}
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("Inset size was not positive")
+	}
+	return &Inset{
+		Inner:           p,
+		Left:            left,
+		Bottom:          bottom,
+		Width:           width,
+		Height:          height,
+		BackgroundColor: color.White,
+	}, nil
+}
+
+// Plot draws the inset plot, implementing
+// the plot.Plotter interface.
+func (in *Inset) Plot(da plot.DrawArea, _ *plot.Plot) {
+	r := plot.Rect{
+		Min: plot.Point{
+			X: da.X(in.Left),
+			Y: da.Y(in.Bottom),
+		},
+	}
+	r.Size = plot.Point{
+		X: da.X(in.Left+in.Width) - r.Min.X,
+		Y: da.Y(in.Bottom+in.Height) - r.Min.Y,
+	}
+	max := r.Max()
+	box := []plot.Point{
+		r.Min, {max.X, r.Min.Y}, max, {r.Min.X, max.Y},
+	}
+	if in.BackgroundColor != nil {
+		da.FillPolygon(in.BackgroundColor, box)
+	}
+
+	// The inset plot is drawn to its own DrawArea,
+	// so its axes, padding and transforms are
+	// computed independently of the outer plot.
+	in.Inner.Draw(plot.DrawArea{Canvas: da.Canvas, Rect: r})
+
+	if in.Border.Color != nil && in.Border.Width > 0 {
+		da.StrokeLines(in.Border, append(box, r.Min))
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot/plot"
+)
+
+// A BandwidthRule is a rule for choosing the
+// bandwidth of a kernel density estimate from its
+// data.
+type BandwidthRule int
+
+const (
+	// SilvermanBandwidth uses Silverman's rule of
+	// thumb, 0.9 min(σ, IQR/1.34) n^-1/5, where σ
+	// is the standard deviation and IQR is the
+	// interquartile range of the n values.  It is
+	// robust to outliers and to data with more than
+	// one mode.
+	SilvermanBandwidth BandwidthRule = iota
+
+	// ScottBandwidth uses Scott's rule,
+	// 1.06 σ n^-1/5, which is best for data that
+	// are normal and oversmooths data that are not.
+	ScottBandwidth
+)
+
+// KDE implements the Plotter interface, drawing the
+// Gaussian kernel density estimate of a set of values:
+// a smooth curve that estimates the probability density
+// of the values without depending, as a histogram does,
+// on the placement of bins.
+type KDE struct {
+	// Values is a sorted copy of the values.
+	Values
+
+	// Bandwidth is the standard deviation of the
+	// Gaussian kernel, in the units of the values.
+	// NewKDE sets it by a BandwidthRule, and it may
+	// be changed to any positive value.
+	Bandwidth float64
+
+	// Samples is the number of points at which the
+	// density is evaluated to draw the curve.
+	Samples int
+
+	// FillColor, if non-nil, fills the area between
+	// the curve and zero.
+	FillColor color.Color
+
+	// LineStyle is the style of the curve.
+	plot.LineStyle
+}
+
+// NewKDE returns a KDE of the values with a bandwidth
+// chosen by the given rule, the default line style,
+// 100 samples and no fill.
+func NewKDE(vs Valuer, rule BandwidthRule) (*KDE, error) {
+	values, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	sort.Float64s(values)
+	return &KDE{
+		Values:    values,
+		Bandwidth: bandwidth(values, rule),
+		Samples:   100,
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// bandwidth returns the bandwidth chosen by the rule
+// for the sorted values.  It is 1 if the values do
+// not vary.
+func bandwidth(sorted Values, rule BandwidthRule) float64 {
+	if rule == ScottBandwidth {
+		sd := stdDev(sorted)
+		if sd == 0 {
+			return 1
+		}
+		return 1.06 * sd * math.Pow(float64(len(sorted)), -0.2)
+	}
+	return silverman(sorted, quantile(sorted, 0.75)-quantile(sorted, 0.25))
+}
+
+// Density returns the kernel density estimate of the
+// values at x.
+func (k *KDE) Density(x float64) float64 {
+	return gaussianDensity(k.Values, k.Bandwidth, x)
+}
+
+// Curve returns the points of the density curve, which
+// extends three bandwidths beyond the smallest and the
+// largest values, where the density is nearly zero.
+func (k *KDE) Curve() XYs {
+	if k.Samples < 2 || !(k.Bandwidth > 0) {
+		return nil
+	}
+	min := k.Values[0] - 3*k.Bandwidth
+	max := k.Values[len(k.Values)-1] + 3*k.Bandwidth
+	pts := make(XYs, k.Samples)
+	for i := range pts {
+		pts[i].X = min + (max-min)*float64(i)/float64(k.Samples-1)
+		pts[i].Y = k.Density(pts[i].X)
+	}
+	return pts
+}
+
+// Plot draws the density curve, and the fill beneath
+// it, implementing the plot.Plotter interface.
+func (k *KDE) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	curve := k.Curve()
+	if len(curve) == 0 {
+		return
+	}
+	ps := make([]plot.Point, len(curve))
+	for i, p := range curve {
+		ps[i] = plot.Pt(trX(p.X), trY(p.Y))
+	}
+
+	if k.FillColor != nil {
+		zero := trY(0)
+		fill := make([]plot.Point, 0, len(ps)+2)
+		fill = append(fill, plot.Pt(ps[0].X, zero))
+		fill = append(fill, ps...)
+		fill = append(fill, plot.Pt(ps[len(ps)-1].X, zero))
+		da.FillPolygon(k.FillColor, da.ClipPolygonXY(fill))
+	}
+	da.StrokeLines(k.LineStyle, da.ClipLinesXY(ps)...)
+}
+
+// DataRange returns the extent of the density curve
+// and the range of densities from zero to the
+// greatest, implementing the plot.DataRanger
+// interface.
+func (k *KDE) DataRange() (xmin, xmax, ymin, ymax float64) {
+	curve := k.Curve()
+	if len(curve) == 0 {
+		return k.Values[0], k.Values[len(k.Values)-1], 0, 0
+	}
+	xmin, xmax, _, ymax = XYRange(curve)
+	return xmin, xmax, 0, ymax
+}
+
+// AutoColor sets the color of the curve if it is
+// the default color, implementing the
+// plot.AutoColorer interface.
+func (k *KDE) AutoColor(c color.Color) bool {
+	if !unsetColor(k.LineStyle.Color) {
+		return false
+	}
+	k.LineStyle.Color = c
+	return true
+}
+
+// Thumbnail draws a filled rectangle, if the curve
+// is filled, and a line through the center of the
+// draw area, implementing the plot.Thumbnailer
+// interface.
+func (k *KDE) Thumbnail(da *plot.DrawArea) {
+	fillThumbnail(da, k.FillColor)
+	y := da.Center().Y
+	da.StrokeLine2(k.LineStyle, da.Min.X, y, da.Max().X, y)
+}
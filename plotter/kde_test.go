@@ -0,0 +1,64 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKDEBandwidth(t *testing.T) {
+	vs := Values{5, 1, 4, 2, 3}
+	sd := math.Sqrt(2.5)
+	for _, test := range []struct {
+		rule BandwidthRule
+		want float64
+	}{
+		// The IQR of 2 is less than 1.34σ,
+		// so Silverman's rule uses it.
+		{SilvermanBandwidth, 0.9 * 2 / 1.34 * math.Pow(5, -0.2)},
+		{ScottBandwidth, 1.06 * sd * math.Pow(5, -0.2)},
+	} {
+		k, err := NewKDE(vs, test.rule)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(k.Bandwidth-test.want) > 1e-12 {
+			t.Errorf("bandwidth by rule %d = %v, want %v", test.rule, k.Bandwidth, test.want)
+		}
+	}
+
+	k, err := NewKDE(Values{2, 2, 2}, ScottBandwidth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if k.Bandwidth != 1 {
+		t.Errorf("bandwidth of equal values = %v, want 1", k.Bandwidth)
+	}
+}
+
+func TestKDECurve(t *testing.T) {
+	k, err := NewKDE(Values{-1, 0, 0.5, 3}, SilvermanBandwidth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	k.Bandwidth = 0.5
+	k.Samples = 1000
+	curve := k.Curve()
+	if curve[0].X != -2.5 || curve[len(curve)-1].X != 4.5 {
+		t.Errorf("curve extends from %v to %v, want -2.5 to 4.5", curve[0].X, curve[len(curve)-1].X)
+	}
+	// The area beneath the curve is nearly one.
+	var area float64
+	for i := 1; i < len(curve); i++ {
+		area += (curve[i].X - curve[i-1].X) * (curve[i].Y + curve[i-1].Y) / 2
+	}
+	if math.Abs(area-1) > 0.01 {
+		t.Errorf("area beneath the curve = %v, want 1", area)
+	}
+	if _, _, ymin, _ := k.DataRange(); ymin != 0 {
+		t.Errorf("Y range starts at %v, want 0", ymin)
+	}
+}
@@ -6,6 +6,8 @@ package plotter
 
 import (
 	"errors"
+	"image/color"
+	"math"
 
 	"github.com/gonum/plot/plot"
 	"github.com/gonum/plot/vg"
@@ -43,6 +45,23 @@ type Labels struct {
 	// XOffset and YOffset are added directly to the final
 	// label X and Y location respectively.
 	XOffset, YOffset vg.Length
+
+	// AvoidOverlap, if true, moves labels that would
+	// overlap a previously drawn label, a labelled
+	// point or a glyph of one of the Avoid plotters
+	// to a nearby free position.  A leader line is
+	// drawn from each moved label to its point.
+	AvoidOverlap bool
+
+	// Avoid is a set of plotters whose glyphs the
+	// labels do not cover when AvoidOverlap is true.
+	// Typically this is the plotter that draws the
+	// labelled points.
+	Avoid []plot.GlyphBoxer
+
+	// LeaderStyle is the style of the leader lines
+	// drawn to moved labels.
+	LeaderStyle plot.LineStyle
 }
 
 // NewLabels returns a new Labels using the DefaultFont and
@@ -74,22 +93,157 @@ func NewLabels(d interface {
 		XYs:       xys,
 		Labels:    strs,
 		TextStyle: plot.TextStyle{Font: fnt},
+		LeaderStyle: plot.LineStyle{
+			Color: color.Gray{Y: 128},
+			Width: vg.Points(0.5),
+		},
 	}, nil
 }
 
 // Plot implements the Plotter interface, drawing labels.
 func (l *Labels) Plot(da plot.DrawArea, p *plot.Plot) {
 	trX, trY := p.Transforms(&da)
+	var anchors []plot.Point
+	var rects []plot.Rect
+	var labels []string
 	for i, label := range l.Labels {
 		x := trX(l.XYs[i].X)
 		y := trY(l.XYs[i].Y)
 		if !da.Contains(plot.Pt(x, y)) {
 			continue
 		}
-		x += l.XOffset
-		y += l.YOffset
-		da.FillText(l.TextStyle, x, y, l.XAlign, l.YAlign, label)
+		w, h := l.Width(label), l.Height(label)
+		anchors = append(anchors, plot.Pt(x, y))
+		rects = append(rects, plot.Rect{
+			Min: plot.Pt(
+				x+l.XOffset+w*vg.Length(l.XAlign),
+				y+l.YOffset+h*vg.Length(l.YAlign),
+			),
+			Size: plot.Pt(w, h),
+		})
+		labels = append(labels, label)
+	}
+
+	moved := make([]bool, len(rects))
+	if l.AvoidOverlap {
+		var obstacles []plot.Rect
+		for _, gb := range l.Avoid {
+			for _, b := range gb.GlyphBoxes(p) {
+				r := b.Rect
+				r.Min.X += da.Min.X + vg.Length(b.X)*da.Size.X
+				r.Min.Y += da.Min.Y + vg.Length(b.Y)*da.Size.Y
+				obstacles = append(obstacles, r)
+			}
+		}
+		moved = placeLabels(rects, anchors, obstacles, da.Rect)
+	}
+
+	for i, label := range labels {
+		if n := nearest(rects[i], anchors[i]); moved[i] && n != anchors[i] {
+			da.StrokeLines(l.LeaderStyle, []plot.Point{anchors[i], n})
+		}
+		da.FillText(l.TextStyle, rects[i].Min.X, rects[i].Min.Y, 0, 0, label)
+	}
+}
+
+// labelRings is the number of rings of candidate
+// positions tried around a point when placing
+// its label.
+const labelRings = 6
+
+// labelDirs are the directions in which candidate
+// label positions are tried, in order of preference.
+var labelDirs = []float64{
+	0, math.Pi, math.Pi / 2, -math.Pi / 2,
+	math.Pi / 4, 3 * math.Pi / 4, -math.Pi / 4, -3 * math.Pi / 4,
+}
+
+// placeLabels greedily moves each label rectangle,
+// in order, so that it does not overlap the labels
+// before it, any of the anchor points or any of the
+// obstacles, while staying within bounds.  The
+// preferred position of each label is its given
+// rectangle and the candidates tried after it are
+// on rings of increasing distance around the label's
+// anchor.  A label for which there is no free
+// position is left where it is.  The returned slice
+// reports which labels were moved.
+func placeLabels(rects []plot.Rect, anchors []plot.Point, obstacles []plot.Rect, bounds plot.Rect) []bool {
+	moved := make([]bool, len(rects))
+	free := func(i int, r plot.Rect) bool {
+		if !contains(bounds, r) {
+			return false
+		}
+		for _, o := range obstacles {
+			if overlaps(r, o) {
+				return false
+			}
+		}
+		for _, a := range anchors {
+			if overlaps(r, plot.Rect{Min: a}) {
+				return false
+			}
+		}
+		for j := 0; j < i; j++ {
+			if overlaps(r, rects[j]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for i, r := range rects {
+		if free(i, r) {
+			continue
+		}
+		a := anchors[i]
+		step := r.Size.Y / 2
+	search:
+		for k := 0; k < labelRings; k++ {
+			d := step * vg.Length(k)
+			for _, dir := range labelDirs {
+				sin, cos := math.Sincos(dir)
+				c := plot.Rect{
+					Min: plot.Pt(
+						a.X+vg.Length(cos)*(r.Size.X/2+d)-r.Size.X/2,
+						a.Y+vg.Length(sin)*(r.Size.Y/2+d)-r.Size.Y/2,
+					),
+					Size: r.Size,
+				}
+				if free(i, c) {
+					rects[i] = c
+					moved[i] = true
+					break search
+				}
+			}
+		}
 	}
+	return moved
+}
+
+// overlaps returns true if the rectangles a and b
+// intersect.  Rectangles that only share an edge do
+// not overlap, and a zero-sized rectangle overlaps
+// the rectangles that strictly contain it.
+func overlaps(a, b plot.Rect) bool {
+	return a.Min.X < b.Max().X && b.Min.X < a.Max().X &&
+		a.Min.Y < b.Max().Y && b.Min.Y < a.Max().Y
+}
+
+// contains returns true if the rectangle r
+// lies entirely within the bounds b.
+func contains(b, r plot.Rect) bool {
+	return r.Min.X >= b.Min.X && r.Min.Y >= b.Min.Y &&
+		r.Max().X <= b.Max().X && r.Max().Y <= b.Max().Y
+}
+
+// nearest returns the point of the rectangle r
+// nearest to the point p.
+func nearest(r plot.Rect, p plot.Point) plot.Point {
+	return plot.Pt(
+		vg.Length(math.Max(float64(r.Min.X), math.Min(float64(p.X), float64(r.Max().X)))),
+		vg.Length(math.Max(float64(r.Min.Y), math.Min(float64(p.Y), float64(r.Max().Y)))),
+	)
 }
 
 // DataRange returns the minimum and maximum X and Y values
@@ -0,0 +1,46 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+func TestPlaceLabels(t *testing.T) {
+	bounds := plot.Rect{Size: plot.Pt(200, 200)}
+	var anchors []plot.Point
+	var rects []plot.Rect
+	for i := 0; i < 6; i++ {
+		a := plot.Pt(100+2*vg.Length(i), 100+vg.Length(i%2))
+		anchors = append(anchors, a)
+		rects = append(rects, plot.Rect{Min: a, Size: plot.Pt(20, 8)})
+	}
+	lone := plot.Rect{Min: plot.Pt(10, 10), Size: plot.Pt(20, 8)}
+	anchors = append(anchors, lone.Min)
+	rects = append(rects, lone)
+
+	moved := placeLabels(rects, anchors, nil, bounds)
+	if n := len(rects) - 1; moved[n] || rects[n] != lone {
+		t.Errorf("lone label moved to %v, want it to stay at %v", rects[n], lone)
+	}
+	for i, r := range rects {
+		if !contains(bounds, r) {
+			t.Errorf("label %d at %v is out of bounds", i, r)
+		}
+		for _, a := range anchors {
+			if overlaps(r, plot.Rect{Min: a}) {
+				t.Errorf("label %d at %v covers point %v", i, r, a)
+			}
+		}
+		for j := 0; j < i; j++ {
+			if overlaps(r, rects[j]) {
+				t.Errorf("label %d at %v overlaps label %d at %v", i, r, j, rects[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// limited is a Plotter whose data range is
+// overridden by fixed limits.
+type limited struct {
+	plot.Plotter
+	xmin, xmax, ymin, ymax float64
+}
+
+// Limit returns a Plotter that draws p but whose
+// DataRange method returns the given bounds in place of
+// those of p.  A NaN bound is replaced by the
+// corresponding bound of p, or by an infinity that does
+// not affect the range of the plot if p is not a
+// plot.DataRanger.
+//
+// Limit can be used to keep a plotter from driving the
+// range of the plot's axes.  For example,
+//
+//	Limit(p, math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1))
+//
+// returns a Plotter that does not affect the axes at all.
+//
+// The returned Plotter implements plot.GlyphBoxer and
+// plot.Thumbnailer by calling the methods of p, if p
+// implements them.
+func Limit(p plot.Plotter, xmin, xmax, ymin, ymax float64) plot.Plotter {
+	return &limited{
+		Plotter: p,
+		xmin:    xmin,
+		xmax:    xmax,
+		ymin:    ymin,
+		ymax:    ymax,
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (l *limited) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	if d, ok := l.Plotter.(plot.DataRanger); ok {
+		xmin, xmax, ymin, ymax = d.DataRange()
+	}
+	if !math.IsNaN(l.xmin) {
+		xmin = l.xmin
+	}
+	if !math.IsNaN(l.xmax) {
+		xmax = l.xmax
+	}
+	if !math.IsNaN(l.ymin) {
+		ymin = l.ymin
+	}
+	if !math.IsNaN(l.ymax) {
+		ymax = l.ymax
+	}
+	return
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+func (l *limited) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if g, ok := l.Plotter.(plot.GlyphBoxer); ok {
+		return g.GlyphBoxes(plt)
+	}
+	return nil
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (l *limited) Thumbnail(da *plot.DrawArea) {
+	if t, ok := l.Plotter.(plot.Thumbnailer); ok {
+		t.Thumbnail(da)
+	}
+}
@@ -6,11 +6,41 @@ package plotter
 
 import (
 	"image/color"
+	"math"
 
 	"github.com/gonum/plot/plot"
 	"github.com/gonum/plot/vg"
 )
 
+// An Interpolation is a way of connecting
+// the points of a Line.
+type Interpolation int
+
+const (
+	// LinearInterpolation connects the points
+	// with straight line segments.
+	LinearInterpolation Interpolation = iota
+
+	// CatmullRomInterpolation connects the points
+	// with a Catmull-Rom spline, a smooth curve
+	// that passes through every point but may
+	// overshoot between them.
+	CatmullRomInterpolation
+
+	// MonotoneInterpolation connects the points
+	// with a monotone cubic spline, which does not
+	// overshoot: between two points, the curve stays
+	// within their range of Y values.  The X values
+	// of the points must be increasing, otherwise
+	// the points are connected linearly.
+	MonotoneInterpolation
+)
+
+// curveSteps is the number of line segments
+// used to draw the curve between two points
+// of a smoothed Line.
+const curveSteps = 16
+
 // Line implements the Plotter interface, drawing a line.
 type Line struct {
 	// XYs is a copy of the points for this line.
@@ -22,14 +52,40 @@ type Line struct {
 
 	// ShadeColor is the color of the shaded area.
 	ShadeColor *color.Color
+
+	// ShadeHatch is the hatching drawn over the
+	// shaded area.  It is drawn only if ShadeColor
+	// is non-nil.
+	ShadeHatch plot.HatchStyle
+
+	// Interpolation is the way that the points
+	// are connected.  Smooth curves are drawn as
+	// many short line segments, so that they are
+	// clipped and drawn by every canvas in the same
+	// way as straight lines.
+	Interpolation Interpolation
+
+	// Downsample, if true, reduces each run of points,
+	// when the line is drawn, to at most two points per
+	// dot of the width of the data area, choosing the
+	// points by the Largest-Triangle-Three-Buckets
+	// algorithm so that the shape of the line is kept.
+	// It speeds up drawing, and shrinks vector output,
+	// of lines with far more points than can be seen.
+	// The points of the Line are not changed.
+	Downsample bool
 }
 
 // NewLine returns a Line that uses the default line style and
 // does not draw glyphs.
+//
+// Unlike most plotters, a Line accepts points with NaN or
+// infinite coordinates.  They mark gaps in the line, such
+// as missing data: the line is broken at each of them.
 func NewLine(xys XYer) (*Line, error) {
-	data, err := CopyXYs(xys)
-	if err != nil {
-		return nil, err
+	data := make(XYs, xys.Len())
+	for i := range data {
+		data[i].X, data[i].Y = xys.XY(i)
 	}
 	return &Line{
 		XYs:       data,
@@ -38,37 +94,230 @@ func NewLine(xys XYer) (*Line, error) {
 }
 
 // Plot draws the Line, implementing the plot.Plotter
-// interface.
+// interface.  Each run of points between gaps is drawn,
+// and shaded, separately.
 func (pts *Line) Plot(da plot.DrawArea, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&da)
-	ps := make([]plot.Point, len(pts.XYs))
+	for _, xys := range finiteRuns(pts.XYs) {
+		if pts.Interpolation == MonotoneInterpolation {
+			xys = monotone(xys, curveSteps)
+		}
+		ps := make([]plot.Point, len(xys))
+		for i, p := range xys {
+			ps[i].X = trX(p.X)
+			ps[i].Y = trY(p.Y)
+		}
+		if pts.Downsample {
+			ps = lttb(ps, int(2*da.Size.X.Dots(da.Canvas)))
+		}
+		if pts.Interpolation == CatmullRomInterpolation {
+			ps = catmullRom(ps, curveSteps)
+		}
 
-	for i, p := range pts.XYs {
-		ps[i].X = trX(p.X)
-		ps[i].Y = trY(p.Y)
+		if pts.ShadeColor != nil {
+			minY := trY(plt.Y.Min)
+			shade := make([]plot.Point, 0, len(ps)+2)
+			shade = append(shade, plot.Pt(ps[0].X, minY))
+			shade = append(shade, ps...)
+			shade = append(shade, plot.Pt(ps[len(ps)-1].X, minY))
+			poly := da.ClipPolygonXY(shade)
+			da.FillPolygon(*pts.ShadeColor, poly)
+			da.FillHatch(pts.ShadeHatch, poly)
+		}
+
+		da.StrokeLines(pts.LineStyle, da.ClipLinesXY(ps)...)
 	}
+}
+
+// lttb returns at most n of the points, chosen by the
+// Largest-Triangle-Three-Buckets algorithm.  The first
+// and last points are kept, and the points between them
+// are divided into n-2 buckets of consecutive points.
+// From each bucket in turn the point is kept that makes
+// the largest triangle with the last point kept and the
+// mean of the next bucket, which keeps peaks and dips
+// that a regular sample would miss.  If there are no
+// more than n points, or n is less than 3, then the
+// points are returned unchanged.
+func lttb(ps []plot.Point, n int) []plot.Point {
+	if n < 3 || len(ps) <= n {
+		return ps
+	}
+	bucket := float64(len(ps)-2) / float64(n-2)
+	out := make([]plot.Point, 0, n)
+	out = append(out, ps[0])
+	prev := ps[0]
+	for i := 0; i < n-2; i++ {
+		lo, hi := int(float64(i+1)*bucket)+1, int(float64(i+2)*bucket)+1
+		if hi > len(ps) {
+			hi = len(ps)
+		}
+		var mean plot.Point
+		for _, p := range ps[lo:hi] {
+			mean.X += p.X
+			mean.Y += p.Y
+		}
+		mean.X /= vg.Length(hi - lo)
+		mean.Y /= vg.Length(hi - lo)
 
-	if pts.ShadeColor != nil && len(ps) > 0 {
-		da.SetColor(*pts.ShadeColor)
-		minY := trY(plt.Y.Min)
-		var pa vg.Path
-		pa.Move(ps[0].X, minY)
-		for i := range pts.XYs {
-			pa.Line(ps[i].X, ps[i].Y)
+		best, area := lo-1, -1.0
+		for j := int(float64(i)*bucket) + 1; j < lo; j++ {
+			p := ps[j]
+			s := math.Abs(float64((prev.X-p.X)*(mean.Y-p.Y) - (prev.Y-p.Y)*(mean.X-p.X)))
+			if s > area {
+				best, area = j, s
+			}
 		}
-		pa.Line(ps[len(pts.XYs)-1].X, minY)
-		pa.Close()
-		da.Fill(pa)
+		prev = ps[best]
+		out = append(out, prev)
+	}
+	return append(out, ps[len(ps)-1])
+}
+
+// finiteRuns splits the points into runs of consecutive
+// points with finite coordinates.  The points with NaN or
+// infinite coordinates, which separate the runs, are
+// dropped.
+func finiteRuns(xys XYs) []XYs {
+	var runs []XYs
+	start := 0
+	for i := 0; i <= len(xys); i++ {
+		if i < len(xys) && CheckFloats(xys[i].X, xys[i].Y) == nil {
+			continue
+		}
+		if i > start {
+			runs = append(runs, xys[start:i])
+		}
+		start = i + 1
+	}
+	return runs
+}
+
+// AutoColor sets the color of the line if it is
+// the default color, implementing the
+// plot.AutoColorer interface.
+func (pts *Line) AutoColor(c color.Color) bool {
+	if !unsetColor(pts.LineStyle.Color) {
+		return false
 	}
+	pts.LineStyle.Color = c
+	return true
+}
 
-	da.StrokeLines(pts.LineStyle, da.ClipLinesXY(ps)...)
+// Clone returns a copy of the line, implementing
+// the plot.Cloner interface.
+func (pts *Line) Clone() plot.Plotter {
+	c := *pts
+	c.XYs = append(XYs(nil), pts.XYs...)
+	c.Dashes = append([]vg.Length(nil), pts.Dashes...)
+	c.ShadeHatch.Dashes = append([]vg.Length(nil), pts.ShadeHatch.Dashes...)
+	if pts.ShadeColor != nil {
+		shade := *pts.ShadeColor
+		c.ShadeColor = &shade
+	}
+	return &c
 }
 
 // DataRange returns the minimum and maximum
 // x and y values, implementing the plot.DataRanger
-// interface.
+// interface.  The points that mark gaps are ignored.
 func (pts *Line) DataRange() (xmin, xmax, ymin, ymax float64) {
-	return XYRange(pts)
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for _, run := range finiteRuns(pts.XYs) {
+		x0, x1, y0, y1 := XYRange(run)
+		xmin, xmax = math.Min(xmin, x0), math.Max(xmax, x1)
+		ymin, ymax = math.Min(ymin, y0), math.Max(ymax, y1)
+	}
+	return
+}
+
+// catmullRom returns the points of a Catmull-Rom
+// spline through the given points, with steps line
+// segments between each pair of points.  The spline
+// is computed in drawing coordinates, so it is smooth
+// as drawn regardless of the scales of the axes.
+func catmullRom(ps []plot.Point, steps int) []plot.Point {
+	if len(ps) < 3 {
+		return ps
+	}
+	curve := make([]plot.Point, 0, (len(ps)-1)*steps+1)
+	curve = append(curve, ps[0])
+	for i := 0; i < len(ps)-1; i++ {
+		p0, p1, p2, p3 := ps[i], ps[i], ps[i+1], ps[i+1]
+		if i > 0 {
+			p0 = ps[i-1]
+		}
+		if i+2 < len(ps) {
+			p3 = ps[i+2]
+		}
+		for j := 1; j <= steps; j++ {
+			t := vg.Length(j) / vg.Length(steps)
+			t2, t3 := t*t, t*t*t
+			at := func(a0, a1, a2, a3 vg.Length) vg.Length {
+				return (2*a1 + (a2-a0)*t + (2*a0-5*a1+4*a2-a3)*t2 + (3*a1-a0-3*a2+a3)*t3) / 2
+			}
+			curve = append(curve, plot.Point{
+				X: at(p0.X, p1.X, p2.X, p3.X),
+				Y: at(p0.Y, p1.Y, p2.Y, p3.Y),
+			})
+		}
+	}
+	return curve
+}
+
+// monotone returns the points of a monotone cubic
+// spline through the given points, with steps line
+// segments between each pair of points.  The tangents
+// are found by the method of Fritsch and Carlson.  If
+// the X values are not increasing then the points are
+// returned unchanged.
+func monotone(xys XYs, steps int) XYs {
+	n := len(xys)
+	if n < 3 {
+		return xys
+	}
+	delta := make([]float64, n-1)
+	for i := range delta {
+		h := xys[i+1].X - xys[i].X
+		if h <= 0 {
+			return xys
+		}
+		delta[i] = (xys[i+1].Y - xys[i].Y) / h
+	}
+
+	m := make([]float64, n)
+	m[0], m[n-1] = delta[0], delta[n-2]
+	for i := 1; i < n-1; i++ {
+		if delta[i-1]*delta[i] > 0 {
+			m[i] = (delta[i-1] + delta[i]) / 2
+		}
+	}
+	for i, d := range delta {
+		if d == 0 {
+			m[i], m[i+1] = 0, 0
+			continue
+		}
+		a, b := m[i]/d, m[i+1]/d
+		if s := a*a + b*b; s > 9 {
+			t := 3 / math.Sqrt(s)
+			m[i], m[i+1] = t*a*d, t*b*d
+		}
+	}
+
+	curve := make(XYs, 0, (n-1)*steps+1)
+	curve = append(curve, xys[0])
+	for i := 0; i < n-1; i++ {
+		x0, y0 := xys[i].X, xys[i].Y
+		h, y1 := xys[i+1].X-x0, xys[i+1].Y
+		for j := 1; j <= steps; j++ {
+			t := float64(j) / float64(steps)
+			t2, t3 := t*t, t*t*t
+			y := (2*t3-3*t2+1)*y0 + (t3-2*t2+t)*h*m[i] + (3*t2-2*t3)*y1 + (t3-t2)*h*m[i+1]
+			curve = append(curve, struct{ X, Y float64 }{x0 + t*h, y})
+		}
+	}
+	return curve
 }
 
 // Thumbnail the thumbnail for the Line,
@@ -83,6 +332,7 @@ func (pts *Line) Thumbnail(da *plot.DrawArea) {
 		}
 		poly := da.ClipPolygonY(points)
 		da.FillPolygon(*pts.ShadeColor, poly)
+		da.FillHatch(pts.ShadeHatch, poly)
 
 		points = append(points, plot.Pt(da.Min.X, da.Min.Y))
 	} else {
@@ -0,0 +1,197 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"bytes"
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/vgsvg"
+)
+
+func TestMonotone(t *testing.T) {
+	// A step in monotonic data, on which an
+	// interpolating spline would overshoot.
+	xys := XYs{{0, 0}, {1, 0}, {2, 0.1}, {3, 10}, {4, 10}, {5, 10}}
+	curve := monotone(xys, curveSteps)
+	if want := (len(xys)-1)*curveSteps + 1; len(curve) != want {
+		t.Fatalf("got %d points, want %d", len(curve), want)
+	}
+	for i := 1; i < len(curve); i++ {
+		if curve[i].Y < curve[i-1].Y {
+			t.Errorf("curve decreases from %v to %v", curve[i-1], curve[i])
+		}
+		if curve[i].Y < 0 || curve[i].Y > 10 {
+			t.Errorf("curve overshoots at %v", curve[i])
+		}
+	}
+	for i, p := range xys {
+		if c := curve[i*curveSteps]; c != p {
+			t.Errorf("curve does not pass through %v, got %v", p, c)
+		}
+	}
+}
+
+func TestColorCycle(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	red := color.RGBA{R: 255, A: 255}
+	p.ColorCycle = []color.Color{red, color.RGBA{G: 255, A: 255}}
+	var lines []*Line
+	for i := 0; i < 4; i++ {
+		l, err := NewLine(XYs{{0, 0}, {1, 1}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, l)
+	}
+	explicit := color.RGBA{B: 255, A: 255}
+	lines[1].Color = explicit
+
+	// An explicit color that is the same as the
+	// default color is not replaced either.
+	lines[3].Color = color.Black
+	for _, l := range lines {
+		p.Add(l)
+	}
+	want := []color.Color{red, explicit, color.RGBA{G: 255, A: 255}, color.Black}
+	for i, l := range lines {
+		if l.Color != want[i] {
+			t.Errorf("line %d has color %v, want %v", i, l.Color, want[i])
+		}
+	}
+}
+
+func TestLineGaps(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	l, err := NewLine(XYs{{nan, 0}, {0, 1}, {1, 2}, {2, nan}, {3, 4}, {4, inf}, {5, -1}, {6, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs := finiteRuns(l.XYs)
+	if want := []int{2, 1, 2}; len(runs) != len(want) {
+		t.Fatalf("got %d runs, want %d", len(runs), len(want))
+	} else {
+		for i, r := range runs {
+			if len(r) != want[i] {
+				t.Errorf("run %d has %d points, want %d", i, len(r), want[i])
+			}
+		}
+	}
+	xmin, xmax, ymin, ymax := l.DataRange()
+	if xmin != 0 || xmax != 6 || ymin != -1 || ymax != 4 {
+		t.Errorf("got range %v..%v, %v..%v, want 0..6, -1..4", xmin, xmax, ymin, ymax)
+	}
+}
+
+func TestLinePointsMarkEvery(t *testing.T) {
+	xys := make(XYs, 10)
+	for i := range xys {
+		xys[i].X, xys[i].Y = float64(i), float64(i)
+	}
+	l, err := NewLinePointsPlotter(xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l.MarkEvery = 4
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+	prims, err := p.Trace(vg.Inches(4), vg.Inches(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var marked []float64
+	for _, prim := range prims {
+		if prim.Kind == plot.TracedGlyph {
+			marked = append(marked, math.Floor(prim.X[0]+0.5))
+		}
+	}
+	want := []float64{0, 4, 8, 9}
+	if len(marked) != len(want) {
+		t.Fatalf("marked points %v, want %v", marked, want)
+	}
+	for i := range want {
+		if marked[i] != want[i] {
+			t.Errorf("marked points %v, want %v", marked, want)
+			break
+		}
+	}
+}
+
+func TestSVGClasses(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		l, err := NewLine(XYs{{0, 0}, {1, float64(i)}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(l)
+		p.Legend.Add("line", l)
+	}
+	c := vgsvg.New(vg.Inches(4), vg.Inches(3))
+	c.Classes = true
+	p.Draw(plot.MakeDrawArea(c))
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+	for want, n := range map[string]int{
+		`<g class="axis x-axis">`:     1,
+		`<g class="axis y-axis">`:     1,
+		`<g class="legend">`:          1,
+		`<g class="series series-0">`: 2,
+		`<g class="series series-1">`: 2,
+		`stroke="#000000"`:            1,
+	} {
+		if got := strings.Count(svg, want); got < n {
+			t.Errorf("SVG contains %q %d times, want at least %d", want, got, n)
+		}
+	}
+	if strings.Contains(svg, "stroke:") {
+		t.Errorf("SVG contains style attributes")
+	}
+}
+
+func TestLTTB(t *testing.T) {
+	ps := make([]plot.Point, 1000)
+	for i := range ps {
+		ps[i] = plot.Pt(vg.Length(i), 0)
+	}
+	ps[500].Y = 100
+	got := lttb(ps, 50)
+	if len(got) != 50 {
+		t.Fatalf("downsampled to %d points, want 50", len(got))
+	}
+	if got[0] != ps[0] || got[len(got)-1] != ps[len(ps)-1] {
+		t.Errorf("end points were not kept")
+	}
+	var spike bool
+	for i, p := range got {
+		if i > 0 && p.X <= got[i-1].X {
+			t.Errorf("points are out of order at %d", i)
+		}
+		spike = spike || p.Y == 100
+	}
+	if !spike {
+		t.Errorf("spike was not kept")
+	}
+	if len(lttb(ps[:10], 50)) != 10 {
+		t.Errorf("short line was changed")
+	}
+}
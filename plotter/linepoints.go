@@ -0,0 +1,123 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+
+	"github.com/gonum/plot/plot"
+)
+
+// LinePoints implements the Plotter interface,
+// drawing a line connecting a set of points and
+// a glyph at each of the points.
+type LinePoints struct {
+	// XYs is a copy of the points.
+	XYs
+
+	// LineStyle is the style of the line
+	// connecting the points.
+	plot.LineStyle
+
+	// GlyphStyle is the style of the glyphs
+	// drawn at each point.
+	plot.GlyphStyle
+
+	// MarkEvery, if greater than one, draws the
+	// glyphs only at every MarkEvery-th point,
+	// starting with the first, and at the last
+	// point, for lines with too many points to mark
+	// them all.  The line still connects all of the
+	// points.
+	MarkEvery int
+}
+
+// NewLinePointsPlotter returns a LinePoints that
+// uses the default line and glyph styles.  Unlike
+// NewLinePoints, which returns a separate Line and
+// Scatter, it returns a single plotter.
+func NewLinePointsPlotter(xys XYer) (*LinePoints, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &LinePoints{
+		XYs:        data,
+		LineStyle:  DefaultLineStyle,
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
+// Plot draws the line and then the glyphs,
+// implementing the plot.Plotter interface.
+func (pts *LinePoints) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	ps := make([]plot.Point, len(pts.XYs))
+	for i, p := range pts.XYs {
+		ps[i].X = trX(p.X)
+		ps[i].Y = trY(p.Y)
+	}
+	da.StrokeLines(pts.LineStyle, da.ClipLinesXY(ps)...)
+	for i, p := range ps {
+		if pts.marked(i) {
+			da.DrawGlyph(pts.GlyphStyle, p)
+		}
+	}
+}
+
+// marked returns true if the glyph of the
+// ith point is drawn.
+func (pts *LinePoints) marked(i int) bool {
+	return pts.MarkEvery <= 1 || i%pts.MarkEvery == 0 || i == len(pts.XYs)-1
+}
+
+// AutoColor sets the colors of the line and the
+// glyphs that have the default color, implementing
+// the plot.AutoColorer interface.
+func (pts *LinePoints) AutoColor(c color.Color) bool {
+	used := false
+	if unsetColor(pts.LineStyle.Color) {
+		pts.LineStyle.Color = c
+		used = true
+	}
+	if unsetColor(pts.GlyphStyle.Color) {
+		pts.GlyphStyle.Color = c
+		used = true
+	}
+	return used
+}
+
+// DataRange returns the minimum and maximum
+// x and y values, implementing the plot.DataRanger
+// interface.
+func (pts *LinePoints) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(pts)
+}
+
+// GlyphBoxes returns a slice of plot.GlyphBoxes,
+// implementing the plot.GlyphBoxer interface.
+func (pts *LinePoints) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	var bs []plot.GlyphBox
+	for i, p := range pts.XYs {
+		if !pts.marked(i) {
+			continue
+		}
+		bs = append(bs, plot.GlyphBox{
+			X:    plt.X.Norm(p.X),
+			Y:    plt.Y.Norm(p.Y),
+			Rect: pts.GlyphStyle.Rect(),
+		})
+	}
+	return bs
+}
+
+// Thumbnail draws a line with a glyph at its
+// center, implementing the plot.Thumbnailer
+// interface.
+func (pts *LinePoints) Thumbnail(da *plot.DrawArea) {
+	y := da.Center().Y
+	da.StrokeLine2(pts.LineStyle, da.Min.X, y, da.Max().X, y)
+	da.DrawGlyph(pts.GlyphStyle, da.Center())
+}
@@ -8,14 +8,24 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"image"
 	"image/color"
+	"image/draw"
+	"image/png"
 	"math"
 	"math/rand"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/gonum/plot/plot"
 	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/plotutil"
 	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/vgimg"
+	"github.com/gonum/plot/vg/vgsvg"
 )
 
 var examples = []struct {
@@ -39,7 +49,78 @@ var examples = []struct {
 	{"example_bubbles", Example_bubbles},
 	{"example_histogram", Example_histogram},
 	{"example_barChart", Example_barChart},
+	{"example_hatchedBars", Example_hatchedBars},
 	{"example_stackedBarChart", Example_stackedBarChart},
+	{"example_areaGradient", Example_areaGradient},
+	{"example_quiver", Example_quiver},
+	{"example_colorBar", Example_colorBar},
+	{"example_brokenAxis", Example_brokenAxis},
+	{"example_legendBox", Example_legendBox},
+	{"example_verticalText", Example_verticalText},
+	{"example_titles", Example_titles},
+	{"example_labelPlacement", Example_labelPlacement},
+	{"example_confidenceEllipse", Example_confidenceEllipse},
+	{"example_legendIcons", Example_legendIcons},
+	{"example_stem", Example_stem},
+	{"example_alpha", Example_alpha},
+	{"example_referenceLines", Example_referenceLines},
+	{"example_inwardTicks", Example_inwardTicks},
+	{"example_polygons", Example_polygons},
+	{"example_colorCycle", Example_colorCycle},
+	{"example_gaps", Example_gaps},
+	{"example_clone", Example_clone},
+	{"example_outlinedGlyphs", Example_outlinedGlyphs},
+	{"example_barValues", Example_barValues},
+	{"example_gradientBackground", Example_gradientBackground},
+	{"example_errorBand", Example_errorBand},
+	{"example_landCover", Example_landCover},
+	{"example_discreteColorBar", Example_discreteColorBar},
+	{"example_secondaryAxis", Example_secondaryAxis},
+	{"example_roundedFrame", Example_roundedFrame},
+	{"example_smooth", Example_smooth},
+	{"example_tooltips", Example_tooltips},
+	{"example_weightedHistogram", Example_weightedHistogram},
+	{"example_autoBins", Example_autoBins},
+	{"example_despined", Example_despined},
+	{"example_dendrogram", Example_dendrogram},
+	{"example_spiral", Example_spiral},
+	{"example_targetGrid", Example_targetGrid},
+	{"example_fontFallback", Example_fontFallback},
+	{"example_spectrogram", Example_spectrogram},
+	{"example_markEvery", Example_markEvery},
+	{"example_csv", Example_csv},
+	{"example_json", Example_json},
+	{"example_ecdf", Example_ecdf},
+	{"example_radar", Example_radar},
+	{"example_symlog", Example_symlog},
+	{"example_mirrorTicks", Example_mirrorTicks},
+	{"example_region", Example_region},
+	{"example_downsample", Example_downsample},
+	{"example_heatMap", Example_heatMap},
+	{"example_contour", Example_contour},
+	{"example_filledContour", Example_filledContour},
+	{"example_violins", Example_violins},
+	{"example_pie", Example_pie},
+	{"example_polar", Example_polar},
+	{"example_candlesticks", Example_candlesticks},
+	{"example_steps", Example_steps},
+	{"example_area", Example_area},
+	{"example_groupedBars", Example_groupedBars},
+	{"example_horizontalBars", Example_horizontalBars},
+	{"example_streamlines", Example_streamlines},
+	{"example_hexBin", Example_hexBin},
+	{"example_histogram2D", Example_histogram2D},
+	{"example_kde", Example_kde},
+	{"example_ridgeline", Example_ridgeline},
+	{"example_waterfall", Example_waterfall},
+	{"example_gantt", Example_gantt},
+	{"example_categories", Example_categories},
+	{"example_logGrid", Example_logGrid},
+	{"example_rotatedLabels", Example_rotatedLabels},
+	{"example_inset", Example_inset},
+	{"example_smoothLines", Example_smoothLines},
+	{"example_frequencyResponse", Example_frequencyResponse},
+	{"example_translucentBands", Example_translucentBands},
 }
 
 func main() {
@@ -51,6 +132,15 @@ func main() {
 		drawJpg(ex.name, ex.mkplot)
 		drawPdf(ex.name, ex.mkplot)
 	}
+	drawGif("example_movingSine", Example_movingSine)
+	drawGrid("example_smallMultiples", Example_smallMultiples)
+	drawComposite("example_composite", Example_functions, Example_stem, Example_alpha)
+	drawMinSize("example_minSize", Example_categories)
+	drawScaled("example_scaled", Example_functions)
+	drawCropped("example_cropped", Example_spike, 45, 55, -1, 12)
+	drawAligned("example_aligned", alignedPanels()...)
+	drawLocalized("example_german", Example_revenue, plot.NumberFormat{Decimal: ",", Thousands: "."})
+	drawThemed("example_themed", Example_themed, themeCSS)
 }
 
 func drawEps(name string, mkplot func() *plot.Plot) {
@@ -89,6 +179,177 @@ func drawJpg(name string, mkplot func() *plot.Plot) {
 	}
 }
 
+func drawGif(name string, mkplots func() []*plot.Plot) {
+	f, err := os.Create(name + ".gif")
+	if err != nil {
+		panic(err)
+	}
+	if err := plot.WriteGIF(f, mkplots(), vg.Inches(4), vg.Inches(4), 10); err != nil {
+		panic(err)
+	}
+	if err := f.Close(); err != nil {
+		panic(err)
+	}
+}
+
+// drawGrid draws rows of plots in a grid of
+// equal cells on a single PNG image.
+func drawGrid(name string, mkplots func() [][]*plot.Plot) {
+	rows := mkplots()
+	c := vgimg.New(vg.Inches(6), vg.Inches(6))
+	da := plot.MakeDrawArea(c)
+	h := da.Size.Y / vg.Length(len(rows))
+	for i, row := range rows {
+		w := da.Size.X / vg.Length(len(row))
+		for j, p := range row {
+			p.Draw(plot.DrawArea{
+				Canvas: c,
+				Rect: plot.Rect{
+					Min:  plot.Pt(da.Min.X+vg.Length(j)*w, da.Max().Y-vg.Length(i+1)*h),
+					Size: plot.Pt(w, h),
+				},
+			})
+		}
+	}
+	f, err := os.Create(name + ".png")
+	if err != nil {
+		panic(err)
+	}
+	if _, err := (vgimg.PngCanvas{Canvas: c}).WriteTo(f); err != nil {
+		panic(err)
+	}
+	if err := f.Close(); err != nil {
+		panic(err)
+	}
+}
+
+// drawComposite draws plots side by side into regions
+// of a single image that already has a border drawn
+// around it, and saves the image as a PNG.
+func drawComposite(name string, mkplots ...func() *plot.Plot) {
+	const size, margin = 288, 16
+	img := image.NewRGBA(image.Rect(0, 0, len(mkplots)*(size+margin)+margin, size+2*margin))
+	gray := image.NewUniform(color.Gray{Y: 200})
+	draw.Draw(img, img.Bounds(), gray, image.ZP, draw.Src)
+	for i, mkplot := range mkplots {
+		x := margin + i*(size+margin)
+		r := image.Rect(x, margin, x+size, margin+size)
+		c := vgimg.NewImageRegion(img, r, vgimg.DefaultDPI)
+		mkplot().Draw(plot.MakeDrawArea(c))
+	}
+	f, err := os.Create(name + ".png")
+	if err != nil {
+		panic(err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		panic(err)
+	}
+	if err := f.Close(); err != nil {
+		panic(err)
+	}
+}
+
+// drawScaled draws a plot as PNGs for standard
+// and high density displays.
+func drawScaled(name string, mkplot func() *plot.Plot) {
+	if err := mkplot().SaveScaled(name+".png", vg.Inches(4), vg.Inches(4), 1, 2); err != nil {
+		panic(err)
+	}
+}
+
+// drawCropped draws a plot as an SVG next to a zoomed
+// view of the given ranges of its data.
+func drawCropped(name string, mkplot func() *plot.Plot, xmin, xmax, ymin, ymax float64) {
+	c := vgsvg.New(vg.Inches(8), vg.Inches(4))
+	da := plot.MakeDrawArea(c)
+	da.Size.X /= 2
+	p := mkplot()
+	p.Draw(da)
+	da.Min.X += da.Size.X
+	p.DrawCropped(da, xmin, xmax, ymin, ymax)
+
+	f, err := os.Create(name + ".svg")
+	if err != nil {
+		panic(err)
+	}
+	if _, err := c.WriteTo(f); err != nil {
+		panic(err)
+	}
+	if err := f.Close(); err != nil {
+		panic(err)
+	}
+}
+
+// drawAligned draws plots as an SVG, one above another.
+func drawAligned(name string, plots ...*plot.Plot) {
+	const w, h = 4, 2
+	c := vgsvg.New(vg.Inches(w), vg.Inches(h*float64(len(plots))))
+	for i, p := range plots {
+		da := plot.MakeDrawArea(c)
+		da.Size.Y = vg.Inches(h)
+		da.Min.Y = vg.Inches(h * float64(len(plots)-1-i))
+		p.Draw(da)
+	}
+
+	f, err := os.Create(name + ".svg")
+	if err != nil {
+		panic(err)
+	}
+	if _, err := c.WriteTo(f); err != nil {
+		panic(err)
+	}
+	if err := f.Close(); err != nil {
+		panic(err)
+	}
+}
+
+// drawLocalized draws a plot as an SVG with its
+// numbers in the given format.
+func drawLocalized(name string, mkplot func() *plot.Plot, f plot.NumberFormat) {
+	defer func(old plot.NumberFormat) { plot.Numbers = old }(plot.Numbers)
+	plot.Numbers = f
+	if err := mkplot().Save(4, 4, name+".svg"); err != nil {
+		panic(err)
+	}
+}
+
+// drawThemed draws a plot as an SVG with CSS classes,
+// inline in an HTML page that styles it with the
+// given stylesheet.
+func drawThemed(name string, mkplot func() *plot.Plot, css string) {
+	c := vgsvg.New(vg.Inches(4), vg.Inches(4))
+	c.Classes = true
+	mkplot().Draw(plot.MakeDrawArea(c))
+
+	f, err := os.Create(name + ".html")
+	if err != nil {
+		panic(err)
+	}
+	if _, err := fmt.Fprintf(f, "<!DOCTYPE html>\n<html><head><style>\n%s</style></head><body>\n", css); err != nil {
+		panic(err)
+	}
+	if _, err := c.WriteInline(f); err != nil {
+		panic(err)
+	}
+	if _, err := fmt.Fprintln(f, "</body></html>"); err != nil {
+		panic(err)
+	}
+	if err := f.Close(); err != nil {
+		panic(err)
+	}
+}
+
+// drawMinSize draws a plot as a PNG at the smallest
+// size at which its text fits, plus a margin.
+func drawMinSize(name string, mkplot func() *plot.Plot) {
+	p := mkplot()
+	w, h := p.MinSize()
+	margin := vg.Points(10)
+	if err := p.SaveSize(w+margin, h+margin, vgimg.DefaultDPI, name+".png"); err != nil {
+		panic(err)
+	}
+}
+
 // Draw the plotinum logo.
 func Example_logo() *plot.Plot {
 	p, err := plot.New()
@@ -160,6 +421,28 @@ func Example_functions() *plot.Plot {
 	return p
 }
 
+// Example_movingSine returns the frames of an
+// animation of a sine wave moving to the right.
+func Example_movingSine() []*plot.Plot {
+	const frames = 20
+	ps := make([]*plot.Plot, frames)
+	for i := range ps {
+		p, err := plot.New()
+		if err != nil {
+			panic(err)
+		}
+		p.Title.Text = "Moving sine"
+		phase := 2 * math.Pi * float64(i) / frames
+		sin := plotter.NewFunction(func(x float64) float64 { return math.Sin(x - phase) })
+		sin.Color = color.RGBA{R: 255, A: 255}
+		p.Add(sin)
+		p.X.Min, p.X.Max = 0, 2*math.Pi
+		p.Y.Min, p.Y.Max = -1, 1
+		ps[i] = p
+	}
+	return ps
+}
+
 // Example_boxPlots draws vertical boxplots.
 func Example_boxPlots() *plot.Plot {
 	rand.Seed(int64(0))
@@ -618,18 +901,18 @@ func Example_points() *plot.Plot {
 	l.LineStyle.Dashes = []vg.Length{vg.Points(5), vg.Points(5)}
 	l.LineStyle.Color = color.RGBA{B: 255, A: 255}
 
-	lpLine, lpPoints, err := plotter.NewLinePoints(linePointsData)
-	if err != nil {
-		panic(err)
-	}
-	lpLine.Color = color.RGBA{G: 255, A: 255}
-	lpPoints.Shape = plot.CircleGlyph{}
-	lpPoints.Color = color.RGBA{R: 255, A: 255}
+	lp := must(plotter.NewLinePointsPlotter(linePointsData)).(*plotter.LinePoints)
+	lp.LineStyle.Color = color.RGBA{G: 255, A: 255}
+	lp.Shape = plot.CircleGlyph{}
+	lp.GlyphStyle.Color = color.RGBA{R: 255, A: 255}
 
-	p.Add(s, l, lpLine, lpPoints)
+	rug := must(plotter.NewRug(plotter.XValues{scatterData})).(*plotter.Rug)
+	rug.Color = s.GlyphStyle.Color
+
+	p.Add(s, l, lp, rug)
 	p.Legend.Add("scatter", s)
 	p.Legend.Add("line", l)
-	p.Legend.Add("line points", lpLine, lpPoints)
+	p.Legend.Add("line points", lp)
 
 	return p
 }
@@ -683,6 +966,17 @@ func Example_errBars() *plot.Plot {
 	p.Add(scatter, xerrs, yerrs)
 	p.Add(plotter.NewGlyphBoxes())
 
+	// A reference line along y = x that extends
+	// far beyond the data, but does not affect the
+	// range of the axes.
+	ref, err := plotter.NewLine(plotter.XYs{{-1e3, -1e3}, {1e3, 1e3}})
+	if err != nil {
+		panic(err)
+	}
+	ref.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+	inf := math.Inf(1)
+	p.Add(plotter.Limit(ref, inf, -inf, inf, -inf))
+
 	return p
 }
 
@@ -819,6 +1113,43 @@ func Example_barChart() *plot.Plot {
 	return p
 }
 
+// An example of a bar chart that uses hatching
+// to tell the groups apart when printed in grayscale.
+func Example_hatchedBars() *plot.Plot {
+	groups := []plotter.Values{
+		{20, 35, 30, 35, 27},
+		{25, 32, 34, 20, 25},
+		{12, 28, 15, 21, 8},
+	}
+	names := []string{"A", "B", "C"}
+	hatches := []plot.HatchPattern{plot.DiagonalHatch, plot.CrossHatch, plot.DotHatch}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Hatched bars"
+	p.Y.Label.Text = "Heights"
+
+	w := vg.Points(10)
+	for i, g := range groups {
+		bars := must(plotter.NewBarChart(g, w)).(*plotter.BarChart)
+		gray := uint8(255 - 40*i)
+		bars.Color = color.Gray{Y: gray}
+		bars.Offset = vg.Length(i-1) * w
+		bars.Hatch = plot.HatchStyle{
+			Pattern:   hatches[i],
+			LineStyle: plot.LineStyle{Color: color.Black, Width: vg.Points(0.5)},
+		}
+		p.Add(bars)
+		p.Legend.Add(names[i], bars)
+	}
+	p.Legend.Top = true
+	p.NominalX("Zero", "One", "Two", "Three", "Four")
+
+	return p
+}
+
 // An example of making a stacked bar chart.
 func Example_stackedBarChart() *plot.Plot {
 	groupA := plotter.Values{20, 35, 30, 35, 27}
@@ -863,6 +1194,2265 @@ func Example_stackedBarChart() *plot.Plot {
 	return p
 }
 
+// An example of filling the area under a line with a gradient.
+func Example_areaGradient() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Area gradient"
+
+	pts := make(plotter.XYs, 100)
+	for i := range pts {
+		pts[i].X = float64(i) / 10
+		pts[i].Y = stdNorm(pts[i].X - 5)
+	}
+	a := must(plotter.NewAreaGradient(pts,
+		color.RGBA{B: 255, A: 255},
+		color.RGBA{})).(*plotter.AreaGradient)
+	p.Add(a)
+	p.Y.Min = 0
+
+	return p
+}
+
+// An example of drawing a vector field.
+func Example_quiver() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Quiver"
+
+	var field plotter.Vectors
+	for x := -2.0; x <= 2; x += 0.5 {
+		for y := -2.0; y <= 2; y += 0.5 {
+			field = append(field, struct{ X, Y, U, V float64 }{x, y, -y, x})
+		}
+	}
+	q := must(plotter.NewQuiver(field)).(*plotter.Quiver)
+	q.Colors = []color.Color{
+		color.RGBA{B: 255, A: 255},
+		color.RGBA{G: 196, B: 128, A: 255},
+		color.RGBA{R: 255, A: 255},
+	}
+	p.Add(q)
+
+	return p
+}
+
+// An example of a color bar.
+func Example_colorBar() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Color bar"
+
+	colors := make([]color.Color, 16)
+	for i := range colors {
+		v := uint8(255 * i / (len(colors) - 1))
+		colors[i] = color.RGBA{R: v, B: 255 - v, A: 255}
+	}
+	c := must(plotter.NewColorBar(colors, -1, 1)).(*plotter.ColorBar)
+	p.Add(c)
+	p.HideY()
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	return p
+}
+
+// An example of a broken X axis that excludes
+// the large gap between two clusters of points.
+func Example_brokenAxis() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Broken axis"
+
+	pts := make(plotter.XYs, 40)
+	for i := range pts {
+		pts[i].X = 10 * rand.Float64()
+		if i%2 == 1 {
+			pts[i].X += 1000
+		}
+		pts[i].Y = rand.Float64()
+	}
+	p.Add(must(plotter.NewScatter(pts)))
+	p.X.Min, p.X.Max = 0, 1010
+	p.X.Breaks = []plot.Break{{Min: 10, Max: 1000}}
+
+	return p
+}
+
+// An example of a legend with a border and a
+// background, drawn over a dense scatter plot.
+func Example_legendBox() *plot.Plot {
+	rand.Seed(int64(0))
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Legend box"
+
+	pts := make(plotter.XYs, 2000)
+	for i := range pts {
+		pts[i].X = rand.Float64()
+		pts[i].Y = rand.Float64()
+	}
+	s := must(plotter.NewScatter(pts)).(*plotter.Scatter)
+	s.Color = color.RGBA{B: 255, A: 255}
+	s.Radius = vg.Points(1)
+	p.Add(s)
+
+	p.Legend.Add("uniform", s)
+	p.Legend.Top = true
+	p.Legend.BackgroundColor = color.White
+	p.Legend.Border = plot.LineStyle{Color: color.Black, Width: vg.Points(0.5)}
+	p.Legend.BoxPadding = vg.Points(4)
+
+	return p
+}
+
+// An example of a vertical Y axis title and a
+// right-to-left X axis title.  A font with the
+// glyphs of the titles must be added with
+// vg.AddFont for them to be drawn.
+func Example_verticalText() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Text direction"
+	p.Y.Label.Text = "縦軸の題名"
+	p.Y.Label.Direction = plot.TopToBottom
+	p.X.Label.Text = "ציר אופקי"
+	p.X.Label.Direction = plot.RightToLeft
+
+	sin := plotter.NewFunction(math.Sin)
+	p.Add(sin)
+	p.X.Min, p.X.Max = 0, 2*math.Pi
+	p.Y.Min, p.Y.Max = -1, 1
+
+	return p
+}
+
+// An example of a two-line title with a
+// right-aligned subtitle below it.
+func Example_titles() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Damped oscillation\nof a spring"
+	p.Title.Subtitle.Text = "k = 2, c = 0.3"
+	p.Title.Subtitle.XAlign = plot.XRight
+	p.Title.Padding = vg.Points(4)
+
+	f := plotter.NewFunction(func(x float64) float64 {
+		return math.Exp(-0.3*x) * math.Cos(2*x)
+	})
+	p.Add(f)
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = -1, 1
+
+	return p
+}
+
+// An example of a 95% confidence ellipse
+// over correlated bivariate normal samples.
+func Example_confidenceEllipse() *plot.Plot {
+	rand.Seed(int64(0))
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "95% confidence ellipse"
+
+	pts := make(plotter.XYs, 200)
+	for i := range pts {
+		u, v := rand.NormFloat64(), rand.NormFloat64()
+		pts[i].X = 2 * u
+		pts[i].Y = 1 + 0.8*u + 0.6*v
+	}
+	s := must(plotter.NewScatter(pts)).(*plotter.Scatter)
+	s.GlyphStyle.Radius = vg.Points(1.5)
+
+	e := must(plotter.NewConfidenceEllipse(pts, 0.95)).(*plotter.ConfidenceEllipse)
+	e.Color = color.RGBA{R: 200, A: 255}
+	e.FillColor = color.NRGBA{R: 200, A: 48}
+	p.Add(e, s)
+	p.Legend.Add("samples", s)
+	p.Legend.Add("95%", e)
+
+	return p
+}
+
+// An example of a legend with custom icons: one
+// drawn by a function and one from an image.
+func Example_legendIcons() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Weather"
+	p.X.Label.Text = "Day"
+
+	sunny := make(plotter.XYs, 7)
+	rainy := make(plotter.XYs, 7)
+	for i := range sunny {
+		sunny[i].X = float64(i)
+		sunny[i].Y = 8 + 3*math.Sin(float64(i))
+		rainy[i].X = float64(i)
+		rainy[i].Y = 4 + 2*math.Cos(float64(i))
+	}
+	sunLine := must(plotter.NewLine(sunny)).(*plotter.Line)
+	sunLine.Color = color.RGBA{R: 230, G: 160, A: 255}
+	rainLine := must(plotter.NewLine(rainy)).(*plotter.Line)
+	rainLine.Color = color.RGBA{B: 200, A: 255}
+	p.Add(sunLine, rainLine)
+
+	// A sun drawn as a disc with rays.
+	sun := plot.ThumbnailFunc(func(da *plot.DrawArea) {
+		c := da.Center()
+		r := da.Size.Y / 4
+		rays := plot.LineStyle{Color: sunLine.Color, Width: vg.Points(0.5)}
+		for i := 0; i < 8; i++ {
+			s, c0 := math.Sincos(float64(i) * math.Pi / 4)
+			u, v := vg.Length(c0), vg.Length(s)
+			da.StrokeLine2(rays, c.X+u*r*1.3, c.Y+v*r*1.3, c.X+u*r*2, c.Y+v*r*2)
+		}
+		da.DrawGlyph(plot.GlyphStyle{Color: sunLine.Color, Radius: r, Shape: plot.CircleGlyph{}}, c)
+	})
+
+	// A rain drop as a small image.
+	const n = 12
+	drop := image.NewNRGBA(image.Rect(0, 0, n, n))
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			dx, dy := float64(x)-n/2+0.5, float64(y)-n*2/3
+			if dy < 0 && math.Abs(dx) < -dy/2 || dx*dx+dy*dy < n*n/16 {
+				drop.Set(x, y, rainLine.Color)
+			}
+		}
+	}
+
+	p.Legend.Add("sunshine (h)", sun)
+	p.Legend.Add("rain (mm)", plot.ImageThumbnail(drop))
+	p.Legend.Top = true
+
+	return p
+}
+
+// An example of a stem plot of the impulse
+// response of a damped resonant filter.
+func Example_stem() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Impulse response"
+	p.X.Label.Text = "n"
+	p.Y.Label.Text = "h[n]"
+
+	h := make(plotter.XYs, 30)
+	for n := range h {
+		h[n].X = float64(n)
+		h[n].Y = math.Pow(0.85, float64(n)) * math.Cos(0.6*float64(n))
+	}
+	s := must(plotter.NewStem(h, 0)).(*plotter.Stem)
+	s.LineStyle.Color = color.RGBA{B: 200, A: 255}
+	s.GlyphStyle.Color = color.RGBA{B: 200, A: 255}
+	s.GlyphStyle.Shape = plot.CircleGlyph{}
+	p.Add(s)
+	p.Legend.Add("h", s)
+
+	return p
+}
+
+// An example of a 2x2 grid of small multiples
+// that share the range of their Y axes.
+func Example_smallMultiples() [][]*plot.Plot {
+	rand.Seed(int64(0))
+	rows := make([][]*plot.Plot, 2)
+	for i := range rows {
+		rows[i] = make([]*plot.Plot, 2)
+		for j := range rows[i] {
+			p, err := plot.New()
+			if err != nil {
+				panic(err)
+			}
+			k := 2*i + j
+			p.Title.Text = fmt.Sprintf("Sensor %d", k+1)
+			pts := make(plotter.XYs, 20)
+			for n := range pts {
+				pts[n].X = float64(n)
+				pts[n].Y = float64(k+1) * (1 + rand.NormFloat64())
+			}
+			p.Add(must(plotter.NewLine(pts)).(*plotter.Line))
+			rows[i][j] = p
+		}
+	}
+	plot.AlignRanges('y', rows[0][0], rows[0][1], rows[1][0], rows[1][1])
+
+	return rows
+}
+
+// An example of dimming a line and its glyphs
+// with Alpha, without changing their color.
+func Example_alpha() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Opacity"
+
+	blue := color.RGBA{B: 255, A: 255}
+	for i, alpha := range []float64{1, 0.3} {
+		pts := make(plotter.XYs, 10)
+		for j := range pts {
+			pts[j].X = float64(j)
+			pts[j].Y = math.Sin(float64(j)/2) + float64(i)/2
+		}
+		lp := must(plotter.NewLinePointsPlotter(pts)).(*plotter.LinePoints)
+		lp.LineStyle.Color = blue
+		lp.LineStyle.Width = vg.Points(3)
+		lp.LineStyle.Alpha = alpha
+		lp.GlyphStyle.Color = blue
+		lp.GlyphStyle.Alpha = alpha
+		lp.GlyphStyle.Shape = plot.CircleGlyph{}
+		p.Add(lp)
+		p.Legend.Add(fmt.Sprintf("%d%%", int(alpha*100)), lp)
+	}
+
+	return p
+}
+
+// An example of a time series with a dashed
+// threshold line and a zero line.
+func Example_referenceLines() *plot.Plot {
+	rand.Seed(int64(0))
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Reference lines"
+	p.X.Label.Text = "Time (s)"
+
+	pts := make(plotter.XYs, 100)
+	v := 0.0
+	for i := range pts {
+		v += rand.NormFloat64()
+		pts[i].X = float64(i)
+		pts[i].Y = v
+	}
+	l := must(plotter.NewLine(pts)).(*plotter.Line)
+
+	zero := plotter.HLine(0, plot.LineStyle{Color: color.Gray{Y: 128}, Width: vg.Points(0.5)})
+	threshold := plotter.HLine(5, plot.LineStyle{
+		Color:  color.RGBA{R: 200, A: 255},
+		Width:  vg.Points(1),
+		Dashes: []vg.Length{vg.Points(4), vg.Points(2)},
+	})
+	event := plotter.VLine(60, plot.LineStyle{Color: color.RGBA{B: 200, A: 255}, Width: vg.Points(0.5)})
+	p.Add(zero, threshold, event, l)
+	p.Legend.Add("signal", l)
+	p.Legend.Add("threshold", threshold)
+	p.Legend.Add("event", event)
+	p.Legend.Top = true
+
+	return p
+}
+
+// An example of tick marks drawn inside
+// of the data area.
+func Example_inwardTicks() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Inward ticks"
+	p.X.Tick.Direction = plot.TicksInside
+	p.Y.Tick.Direction = plot.TicksInside
+	p.X.Tick.Length = vg.Points(6)
+	p.Y.Tick.Length = vg.Points(6)
+
+	f := plotter.NewFunction(func(x float64) float64 { return x * x })
+	p.Add(f)
+	p.X.Min, p.X.Max = -2, 2
+	p.Y.Min, p.Y.Max = 0, 4
+
+	return p
+}
+
+// An example of filled polygons drawn as map
+// regions, one of which has a lake as a hole.
+func Example_polygons() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Regions"
+
+	west := must(plotter.NewPolygon(
+		plotter.XYs{{0, 0}, {4, 0}, {5, 3}, {3, 6}, {0, 5}},
+		plotter.XYs{{1.5, 2}, {2.5, 1.5}, {3, 3}, {2, 3.5}},
+	)).(*plotter.Polygon)
+	west.FillColor = color.RGBA{R: 120, G: 190, B: 120, A: 255}
+
+	east := must(plotter.NewPolygon(
+		plotter.XYs{{4, 0}, {8, 0}, {9, 4}, {6, 6}, {3, 6}, {5, 3}},
+	)).(*plotter.Polygon)
+	east.FillColor = color.RGBA{R: 230, G: 200, B: 120, A: 255}
+
+	island := must(plotter.NewPolygon(
+		plotter.XYs{{8, 7}, {9, 6.5}, {9.5, 7.5}, {8.5, 8}},
+	)).(*plotter.Polygon)
+	island.FillColor = color.RGBA{R: 200, G: 140, B: 200, A: 255}
+
+	p.Add(west, east, island)
+	p.Legend.Add("West", west)
+	p.Legend.Add("East", east)
+	p.Legend.Add("Island", island)
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	return p
+}
+
+// An example of lines that are given colors
+// automatically from the plot's color cycle.
+func Example_colorCycle() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Color cycle"
+	p.ColorCycle = plotutil.DarkColors
+
+	for i := 0; i < 5; i++ {
+		phase := float64(i) * math.Pi / 5
+		f := plotter.NewFunction(func(x float64) float64 { return math.Sin(x + phase) })
+		f.Width = vg.Points(1.5)
+		p.Add(f)
+		p.Legend.Add(fmt.Sprintf("φ = %.2f", phase), f)
+	}
+	p.X.Min, p.X.Max = 0, 2*math.Pi
+	p.Y.Min, p.Y.Max = -1.2, 1.2
+
+	return p
+}
+
+// An example of a line with gaps where
+// data are missing, marked by NaN values.
+func Example_gaps() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Missing data"
+
+	pts := make(plotter.XYs, 60)
+	for i := range pts {
+		pts[i].X = float64(i)
+		pts[i].Y = math.Sin(float64(i) / 6)
+		if i%20 >= 12 && i%20 < 16 {
+			pts[i].Y = math.NaN()
+		}
+	}
+	l := must(plotter.NewLine(pts)).(*plotter.Line)
+	var shade color.Color = color.NRGBA{B: 255, A: 64}
+	l.ShadeColor = &shade
+	p.Add(l)
+
+	return p
+}
+
+// An example of a zoomed-in variation of a
+// plot, made from a clone of it so that the
+// original plot is not changed.
+func Example_clone() *plot.Plot {
+	p := Example_gaps()
+	zoom := p.Clone()
+	zoom.Title.Text = "Missing data (detail)"
+	zoom.X.Min, zoom.X.Max = 10, 30
+	zoom.Y.Min, zoom.Y.Max = -1.2, 0.2
+
+	return zoom
+}
+
+// An example of hollow and outlined glyphs
+// drawn over a busy background.
+func Example_outlinedGlyphs() *plot.Plot {
+	rand.Seed(int64(0))
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Outlined glyphs"
+
+	for i := 0; i < 20; i++ {
+		bg := make(plotter.XYs, 2)
+		for j := range bg {
+			bg[j].X, bg[j].Y = 10*rand.Float64(), 10*rand.Float64()
+		}
+		l := must(plotter.NewLine(bg)).(*plotter.Line)
+		l.Color = color.RGBA{R: uint8(128 + rand.Intn(128)), G: uint8(128 + rand.Intn(128)), B: 200, A: 255}
+		l.Width = vg.Points(4)
+		p.Add(l)
+	}
+
+	edge := plot.LineStyle{Color: color.Black, Width: vg.Points(1)}
+	hollow := must(plotter.NewScatter(randomPoints(10))).(*plotter.Scatter)
+	hollow.Shape = plot.CircleGlyph{}
+	hollow.GlyphStyle.Color = nil
+	hollow.Radius = vg.Points(4)
+	hollow.Outline = edge
+
+	filled := must(plotter.NewScatter(randomPoints(10))).(*plotter.Scatter)
+	filled.Shape = plot.PyramidGlyph{}
+	filled.GlyphStyle.Color = color.RGBA{R: 255, G: 220, A: 255}
+	filled.Radius = vg.Points(4)
+	filled.Outline = edge
+
+	p.Add(hollow, filled)
+	p.Legend.Add("hollow", hollow)
+	p.Legend.Add("outlined", filled)
+
+	return p
+}
+
+// An example of labelling bars with their values,
+// above the positive bars and below the negative
+// ones, and inside of the ends of the bars.
+func Example_barValues() *plot.Plot {
+	changes := plotter.Values{12.5, -4, 8, 21, -9.5}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Bar values"
+	p.Y.Label.Text = "Change"
+
+	w := vg.Points(20)
+	outside := must(plotter.NewBarChart(changes, w)).(*plotter.BarChart)
+	outside.Color = color.Gray{Y: 192}
+	outside.Offset = -w / 2
+	outside.ShowValues = true
+
+	inside := must(plotter.NewBarChart(changes, w)).(*plotter.BarChart)
+	inside.Color = color.RGBA{B: 255, A: 255}
+	inside.Offset = w / 2
+	inside.ShowValues = true
+	inside.ValuesInside = true
+	inside.ValueStyle.Color = color.White
+	inside.ValueFormat = func(v float64) string {
+		return fmt.Sprintf("%+.0f", v)
+	}
+
+	p.Add(outside, inside, plotter.HLine(0, plotter.DefaultLineStyle))
+	p.NominalX("Mon", "Tue", "Wed", "Thu", "Fri")
+
+	return p
+}
+
+// An example of a plot with a subtle gradient
+// drawn behind it.
+func Example_gradientBackground() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Gradient background"
+	p.Background = plot.LinearGradient{
+		From:  color.White,
+		To:    color.RGBA{R: 220, G: 230, B: 245, A: 255},
+		Angle: math.Pi / 2,
+	}
+
+	l := must(plotter.NewLine(randomPoints(25))).(*plotter.Line)
+	l.Color = color.RGBA{B: 192, A: 255}
+	p.Add(l)
+
+	return p
+}
+
+// An example of a least squares regression line
+// with the 95% confidence band of its mean.
+func Example_errorBand() *plot.Plot {
+	rand.Seed(int64(0))
+	n := 30
+	pts := make(plotter.XYs, n)
+	for i := range pts {
+		pts[i].X = float64(i)
+		pts[i].Y = 2 + 0.5*pts[i].X + rand.NormFloat64()*2
+	}
+
+	var mx, my float64
+	for _, p := range pts {
+		mx += p.X / float64(n)
+		my += p.Y / float64(n)
+	}
+	var sxx, sxy float64
+	for _, p := range pts {
+		sxx += (p.X - mx) * (p.X - mx)
+		sxy += (p.X - mx) * (p.Y - my)
+	}
+	slope := sxy / sxx
+	var sse float64
+	for _, p := range pts {
+		r := p.Y - (my + slope*(p.X-mx))
+		sse += r * r
+	}
+	se := math.Sqrt(sse / float64(n-2))
+
+	type bandPoints struct {
+		plotter.XYs
+		plotter.YErrors
+	}
+	fit := bandPoints{
+		XYs:     make(plotter.XYs, n),
+		YErrors: make(plotter.YErrors, n),
+	}
+	for i, p := range pts {
+		fit.XYs[i].X = p.X
+		fit.XYs[i].Y = my + slope*(p.X-mx)
+		ci := 1.96 * se * math.Sqrt(1/float64(n)+(p.X-mx)*(p.X-mx)/sxx)
+		fit.YErrors[i].Low, fit.YErrors[i].High = ci, ci
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Regression"
+	band := must(plotter.NewErrorBand(fit)).(*plotter.ErrorBand)
+	band.FillColor = color.NRGBA{B: 255, A: 64}
+	band.Color = color.RGBA{B: 192, A: 255}
+	scatter := must(plotter.NewScatter(pts)).(*plotter.Scatter)
+	p.Add(band, scatter)
+	p.Legend.Add("fit", band)
+	p.Legend.Add("data", scatter)
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	return p
+}
+
+// landCoverClasses are the names of the classes of
+// the land cover palette.
+var landCoverClasses = []string{"Water", "Bare", "Grass", "Forest"}
+
+// landCoverPalette returns a palette that maps
+// vegetation index values to land cover classes.
+func landCoverPalette() *plotter.DiscretePalette {
+	pal, err := plotter.NewDiscretePalette(
+		[]float64{-1, 0, 0.2, 0.5, 1},
+		[]color.Color{
+			color.RGBA{R: 70, G: 130, B: 180, A: 255},
+			color.RGBA{R: 210, G: 180, B: 140, A: 255},
+			color.RGBA{R: 154, G: 205, B: 50, A: 255},
+			color.RGBA{G: 100, A: 255},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+	return pal
+}
+
+// An example of a map of land cover classes, each
+// drawn in the fixed color of a discrete palette.
+func Example_landCover() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Land cover"
+
+	pal := landCoverPalette()
+	cells := make([][]plotter.XYer, len(pal.Colors))
+	for x := 0; x < 16; x++ {
+		for y := 0; y < 10; y++ {
+			fx, fy := float64(x), float64(y)
+			ndvi := math.Sin(fx/4)*math.Cos(fy/3) + 0.3*math.Sin(fx*fy/10)
+			ndvi = math.Max(-1, math.Min(1, ndvi))
+			for i, clr := range pal.Colors {
+				if pal.Color(ndvi) == clr {
+					cells[i] = append(cells[i], plotter.XYs{
+						{fx, fy}, {fx + 1, fy}, {fx + 1, fy + 1}, {fx, fy + 1},
+					})
+					break
+				}
+			}
+		}
+	}
+	for i, rings := range cells {
+		if len(rings) == 0 {
+			continue
+		}
+		class := must(plotter.NewPolygon(rings...)).(*plotter.Polygon)
+		class.FillColor = pal.Colors[i]
+		class.Width = 0
+		p.Add(class)
+		p.Legend.Add(landCoverClasses[i], class)
+	}
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	return p
+}
+
+// An example of a color bar of a discrete palette,
+// with a tick mark at each boundary between the
+// ranges of the classes.
+func Example_discreteColorBar() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Vegetation index classes"
+
+	pal := landCoverPalette()
+	p.Add(pal.ColorBar())
+	p.X.Tick.Marker = pal.Ticks
+	p.HideY()
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	return p
+}
+
+// An example of a price line on the Y axis drawn
+// over a bar chart of the trading volume, which is
+// bound to the secondary Y axis on the right.
+func Example_secondaryAxis() *plot.Plot {
+	rand.Seed(int64(0))
+	n := 20
+	prices := make(plotter.XYs, n)
+	volumes := make(plotter.Values, n)
+	price := 100.0
+	for i := range prices {
+		price += rand.NormFloat64() * 2
+		prices[i].X = float64(i)
+		prices[i].Y = price
+		volumes[i] = 1000 + rand.Float64()*4000
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Price and volume"
+	p.X.Label.Text = "Day"
+	p.Y.Label.Text = "Price"
+	p.Y2.Label.Text = "Volume"
+
+	bars := must(plotter.NewBarChart(volumes, vg.Points(8))).(*plotter.BarChart)
+	bars.Color = color.Gray{Y: 200}
+	bars.LineStyle.Width = 0
+	line := must(plotter.NewLine(prices)).(*plotter.Line)
+	line.Color = color.RGBA{B: 192, A: 255}
+	line.Width = vg.Points(1.5)
+
+	p.Add(plotter.OnY2(bars), line)
+	p.Y2.Min = 0
+	p.Legend.Add("price", line)
+	p.Legend.Add("volume", bars)
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	return p
+}
+
+// An example of a chart in a pill-shaped card:
+// the data area has rounded corners, and the
+// shaded line is clipped to them.
+func Example_roundedFrame() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Weekly active users"
+	p.BackgroundColor = color.Gray{Y: 235}
+	p.Frame = &plot.Frame{
+		Radius:    vg.Inches(2),
+		Color:     color.White,
+		LineStyle: plot.LineStyle{Color: color.Gray{Y: 160}, Width: vg.Points(1)},
+	}
+	p.HideAxes()
+
+	pts := make(plotter.XYs, 50)
+	for i := range pts {
+		pts[i].X = float64(i)
+		pts[i].Y = 10 + 3*math.Sin(float64(i)/5) + float64(i)/10
+	}
+	l := must(plotter.NewLine(pts)).(*plotter.Line)
+	l.Color = color.RGBA{G: 128, B: 192, A: 255}
+	l.Width = vg.Points(2)
+	var shade color.Color = color.NRGBA{G: 128, B: 192, A: 64}
+	l.ShadeColor = &shade
+	p.Add(l)
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	return p
+}
+
+// An example of noisy daily data overlaid with
+// its 7 day moving average.
+func Example_smooth() *plot.Plot {
+	rand.Seed(int64(0))
+	days := make(plotter.XYs, 90)
+	for i := range days {
+		days[i].X = float64(i)
+		days[i].Y = 50 + 10*math.Sin(float64(i)/12) + rand.NormFloat64()*5
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Daily values"
+	p.X.Label.Text = "Day"
+
+	raw := must(plotter.NewLine(days)).(*plotter.Line)
+	raw.Color = color.Gray{Y: 180}
+	avg := must(plotter.NewSmooth(days, 7)).(*plotter.Smooth)
+	avg.Color = color.RGBA{R: 192, A: 255}
+	avg.Width = vg.Points(2)
+	p.Add(raw, avg)
+	p.Legend.Add("daily", raw)
+	p.Legend.Add("7 day average", avg)
+	p.Legend.Top = true
+
+	return p
+}
+
+// An example of a scatter plot whose points have
+// tooltips and data attributes in SVG output.
+func Example_tooltips() *plot.Plot {
+	rand.Seed(int64(0))
+	pts := randomPoints(20)
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Hover over the points"
+
+	s := must(plotter.NewScatter(pts)).(*plotter.Scatter)
+	s.Tooltip = func(i int) string {
+		return fmt.Sprintf("(%.2f, %.2f)", pts[i].X, pts[i].Y)
+	}
+	s.Data = func(i int) map[string]string {
+		return map[string]string{"index": strconv.Itoa(i)}
+	}
+	p.Add(s)
+
+	return p
+}
+
+// An example of a histogram of measurements that are
+// weighted by their exposure times, normalized to give
+// the fraction of the total exposure in each bin.
+func Example_weightedHistogram() *plot.Plot {
+	rand.Seed(int64(0))
+	n := 2000
+	vals := make(plotter.Values, n)
+	exposure := make(plotter.Values, n)
+	for i := range vals {
+		vals[i] = rand.NormFloat64()
+		// Longer exposures favor the higher values.
+		exposure[i] = rand.ExpFloat64() * (1 + math.Max(vals[i], -0.9))
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Exposure-weighted measurements"
+	p.X.Label.Text = "Measurement"
+	p.Y.Label.Text = "Fraction of exposure"
+
+	h, err := plotter.NewWeightedHistogram(vals, exposure, 20)
+	if err != nil {
+		panic(err)
+	}
+	h.NormalizeTotal(1)
+	p.Add(h)
+
+	return p
+}
+
+// An example of a histogram whose number of bins is
+// chosen from the spread of the data.
+func Example_autoBins() *plot.Plot {
+	rand.Seed(int64(0))
+	vals := make(plotter.Values, 5000)
+	for i := range vals {
+		vals[i] = math.Exp(rand.NormFloat64() * 0.5)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Freedman-Diaconis bins"
+
+	h, err := plotter.NewHistogramAuto(vals, plotter.FreedmanDiaconis)
+	if err != nil {
+		panic(err)
+	}
+	h.Normalize(1)
+	p.Add(h)
+
+	return p
+}
+
+// An example of a scatter plot in the style of many
+// journals, with only the left and bottom sides of
+// its frame and no separate axis lines.
+func Example_despined() *plot.Plot {
+	rand.Seed(int64(0))
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Despined"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+	p.Frame = &plot.Frame{
+		LineStyle: p.X.LineStyle,
+		HideTop:   true,
+		HideRight: true,
+	}
+	p.X.HideLine = true
+	p.Y.HideLine = true
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	s := must(plotter.NewScatter(randomPoints(30))).(*plotter.Scatter)
+	p.Add(s)
+
+	return p
+}
+
+// An example of a dendrogram of the hierarchical
+// clustering of samples.
+func Example_dendrogram() *plot.Plot {
+	d, err := plotter.NewDendrogram([]plotter.Merge{
+		{A: 1, B: 4, Height: 0.8},
+		{A: 0, B: 6, Height: 1.1},
+		{A: 2, B: 5, Height: 1.6},
+		{A: 7, B: 3, Height: 2.3},
+		{A: 8, B: 9, Height: 3.9},
+		{A: 10, B: 11, Height: 5.2},
+	})
+	if err != nil {
+		panic(err)
+	}
+	d.Labels = []string{"A", "B", "C", "D", "E", "F", "G"}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Sample clustering"
+	p.Y.Label.Text = "Distance"
+	p.X.Categories, err = d.OrderedLabels()
+	if err != nil {
+		panic(err)
+	}
+	p.Add(d)
+
+	return p
+}
+
+// An example of a noisy signal with a spike, which
+// is drawn zoomed in by drawCropped.
+func Example_spike() *plot.Plot {
+	rand.Seed(int64(0))
+	sig := make(plotter.XYs, 1000)
+	for i := range sig {
+		x := float64(i) / 10
+		sig[i].X = x
+		sig[i].Y = rand.NormFloat64()*0.3 + 10*math.Exp(-(x-50)*(x-50)/0.5)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Signal"
+	p.X.Label.Text = "Time"
+	l := must(plotter.NewLine(sig)).(*plotter.Line)
+	p.Add(l)
+
+	return p
+}
+
+// An example of parametric curves: an Archimedean
+// spiral and a Lissajous figure.
+func Example_spiral() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Parametric curves"
+	p.ColorCycle = plotutil.DarkColors
+
+	spiral := plotter.NewParametric(func(t float64) (x, y float64) {
+		return t * math.Cos(t), t * math.Sin(t)
+	}, 0, 6*math.Pi, 500)
+	lissajous := plotter.NewParametric(func(t float64) (x, y float64) {
+		return 12 * math.Sin(3*t+math.Pi/2), 12 * math.Sin(2*t)
+	}, 0, 2*math.Pi, 300)
+	lissajous.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+	p.Add(spiral, lissajous)
+	p.Legend.Add("spiral", spiral)
+	p.Legend.Add("Lissajous", lissajous)
+
+	return p
+}
+
+// An example of a faint grid with a bold line
+// at a target value.
+func Example_targetGrid() *plot.Plot {
+	rand.Seed(int64(0))
+	sales := make(plotter.XYs, 24)
+	for i := range sales {
+		sales[i].X = float64(i + 1)
+		sales[i].Y = 30 + float64(i) + rand.NormFloat64()*4
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Monthly sales"
+	p.X.Label.Text = "Month"
+
+	g := plotter.NewGrid()
+	g.Vertical.Color = color.Gray{Y: 200}
+	g.Horizontal.Color = color.Gray{Y: 200}
+	g.AddHorizontal(42, plot.LineStyle{
+		Color: color.RGBA{R: 196, A: 255},
+		Width: vg.Points(2),
+	})
+	p.Add(g)
+	l := must(plotter.NewLinePointsPlotter(sales)).(*plotter.LinePoints)
+	p.Add(l)
+
+	return p
+}
+
+// An example of labels that mix Latin text with
+// symbols, which are drawn in fallback fonts if the
+// label font has no glyphs for them.
+func Example_fontFallback() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	var fallbacks []vg.Font
+	for _, name := range []string{"Times-Roman", "Courier"} {
+		f, err := vg.MakeFont(name, p.Title.Font.Size)
+		if err != nil {
+			panic(err)
+		}
+		fallbacks = append(fallbacks, f)
+	}
+	p.Title.Text = "Decay of λ → 0"
+	p.Title.Fallbacks = fallbacks
+	p.X.Label.Text = "Time (µs)"
+	p.X.Label.Fallbacks = fallbacks
+	p.Y.Label.Text = "Amplitude ± σ"
+	p.Y.Label.Fallbacks = fallbacks
+
+	decay := plotter.NewFunction(func(x float64) float64 { return math.Exp(-x) })
+	p.Add(decay)
+	p.X.Min, p.X.Max = 0, 5
+	p.Y.Min, p.Y.Max = 0, 1
+
+	return p
+}
+
+// An example of a spectrogram with log-spaced
+// frequency bins, drawn as a PColorMesh.
+func Example_spectrogram() *plot.Plot {
+	const nt, nf = 40, 24
+	times := make([]float64, nt+1)
+	for i := range times {
+		times[i] = float64(i) * 0.25
+	}
+	freqs := make([]float64, nf+1)
+	for j := range freqs {
+		freqs[j] = 20 * math.Pow(1000, float64(j)/nf)
+	}
+	power := make([][]float64, nf)
+	for j := range power {
+		power[j] = make([]float64, nt)
+		f := math.Sqrt(freqs[j] * freqs[j+1])
+		for i := range power[j] {
+			// A tone that sweeps up in frequency.
+			t := (times[i] + times[i+1]) / 2
+			tone := 100 * math.Pow(2, t/2)
+			d := math.Log2(f / tone)
+			power[j][i] = math.Exp(-d * d * 4)
+		}
+	}
+
+	colors := make([]color.Color, 32)
+	for i := range colors {
+		v := uint8(255 * i / (len(colors) - 1))
+		colors[i] = color.RGBA{R: v, G: v / 2, B: 255 - v, A: 255}
+	}
+	m, err := plotter.NewPColorMesh(times, freqs, power, colors)
+	if err != nil {
+		panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Spectrogram"
+	p.X.Label.Text = "Time (s)"
+	p.Y.Label.Text = "Frequency (Hz)"
+	p.Y.Scale = plot.LogScale
+	p.Y.Tick.Marker = plot.LogTicks
+	p.X.Padding = 0
+	p.Y.Padding = 0
+	p.Add(m)
+
+	return p
+}
+
+// An example of a dense line whose points are
+// marked only every 500 points.
+func Example_markEvery() *plot.Plot {
+	rand.Seed(int64(0))
+	walk := make(plotter.XYs, 10000)
+	y := 0.0
+	for i := range walk {
+		y += rand.NormFloat64()
+		walk[i].X = float64(i)
+		walk[i].Y = y
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Random walk"
+	p.X.Label.Text = "Step"
+
+	l := must(plotter.NewLinePointsPlotter(walk)).(*plotter.LinePoints)
+	l.MarkEvery = 500
+	l.Shape = plot.TriangleGlyph{}
+	l.Radius = vg.Points(3)
+	p.Add(l)
+
+	return p
+}
+
+// measurements is CSV data for Example_csv.
+const measurements = `hour,temperature,humidity
+0,11.2,81
+3,10.4,85
+6,10.9,84
+9,14.8,70
+12,18.3,58
+15,19.1,55
+18,16.0,63
+21,13.1,74
+`
+
+// An example of plotting CSV data.
+func Example_csv() *plot.Plot {
+	temp, err := plotter.XYsFromCSVNamed(strings.NewReader(measurements), "hour", "temperature")
+	if err != nil {
+		panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Temperature"
+	p.X.Label.Text = "Hour"
+	p.Y.Label.Text = "°C"
+	p.Add(must(plotter.NewLinePointsPlotter(temp)))
+
+	return p
+}
+
+// An example of a bar chart of large values with
+// decimal value labels, which is drawn in a German
+// number format by drawLocalized.
+func Example_revenue() *plot.Plot {
+	revenue := plotter.Values{10250.5, 12875.25, 9800, 14300.75}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Umsatz (€)"
+
+	bars := must(plotter.NewBarChart(revenue, vg.Points(30))).(*plotter.BarChart)
+	bars.Color = color.RGBA{R: 64, G: 96, B: 192, A: 255}
+	bars.ShowValues = true
+	p.Add(bars)
+	p.NominalX("Q1", "Q2", "Q3", "Q4")
+
+	return p
+}
+
+// alignedPanels returns plots of data with very
+// different ranges, whose fixed margins line up their
+// axes when they are drawn one above another.
+func alignedPanels() []*plot.Plot {
+	margins := plot.Margins{Left: vg.Inches(0.9), Bottom: vg.Inches(0.5)}
+	var plots []*plot.Plot
+	for i, scale := range []float64{1, 1000, 1e6} {
+		p, err := plot.New()
+		if err != nil {
+			panic(err)
+		}
+		p.Title.Text = fmt.Sprintf("Panel %d", i+1)
+		p.Y.Label.Text = "Count"
+		p.Margins = margins
+		f := plotter.NewFunction(func(x float64) float64 {
+			return scale * (1 + math.Sin(x))
+		})
+		p.Add(f)
+		p.X.Min, p.X.Max = 0, 10
+		p.Y.Min, p.Y.Max = 0, 2*scale
+		plots = append(plots, p)
+	}
+	return plots
+}
+
+// An example of a line chart that is defined
+// entirely in JSON.
+func Example_json() *plot.Plot {
+	var p plot.Plot
+	if err := json.Unmarshal([]byte(lineChartJSON), &p); err != nil {
+		panic(err)
+	}
+	return &p
+}
+
+// lineChartJSON is the spec of the plot of Example_json.
+const lineChartJSON = `{
+	"title": "Defined in JSON",
+	"x": {"label": "Day"},
+	"y": {"label": "Requests", "min": 0},
+	"legend": {"top": true},
+	"plotters": [
+		{
+			"type": "linepoints",
+			"legend": "server a",
+			"data": {
+				"points": [[1, 120], [2, 180], [3, 150], [4, 230], [5, 210]],
+				"line": {"color": "#1f77b4", "width": 1.5},
+				"glyph": {"color": "#1f77b4", "shape": "circle"}
+			}
+		},
+		{
+			"type": "line",
+			"legend": "server b",
+			"data": {
+				"points": [[1, 90], [2, 110], [3, 160], [4, 140], [5, 190]],
+				"line": {"color": "#ff7f0e", "width": 1.5, "dashes": [4, 2]}
+			}
+		}
+	]
+}`
+
+// An example of a plot whose lines are drawn in the
+// default black and colored by the stylesheet themeCSS
+// when drawThemed draws it.
+func Example_themed() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Themed by CSS"
+	for i := 0; i < 3; i++ {
+		l := must(plotter.NewLine(randomPoints(10))).(*plotter.Line)
+		p.Add(l)
+		p.Legend.Add("series "+strconv.Itoa(i), l)
+	}
+	return p
+}
+
+// themeCSS colors the lines of Example_themed, and
+// their legend thumbnails, by their series classes.
+const themeCSS = `.series-0 path { stroke: #1f77b4; }
+.series-1 path { stroke: #ff7f0e; }
+.series-2 path { stroke: #2ca02c; stroke-width: 2; }
+.axis text { fill: #555555; }
+`
+
+// An example of the empirical cumulative distribution
+// functions of two samples, overlaid to compare their
+// distributions.
+func Example_ecdf() *plot.Plot {
+	rand.Seed(int64(0))
+	norm := make(plotter.Values, 200)
+	for i := range norm {
+		norm[i] = rand.NormFloat64()
+	}
+	exp := make(plotter.Values, 200)
+	for i := range exp {
+		exp[i] = rand.ExpFloat64() - 1
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Empirical CDFs"
+	p.Y.Label.Text = "Fraction ≤ x"
+	p.ColorCycle = plotutil.SoftColors
+	a := must(plotter.NewECDF(norm)).(*plotter.ECDF)
+	b := must(plotter.NewECDF(exp)).(*plotter.ECDF)
+	b.Smooth = true
+	p.Add(a, b)
+	p.Legend.Add("normal", a)
+	p.Legend.Add("exponential - 1", b)
+	p.Legend.Top, p.Legend.Left = true, true
+	return p
+}
+
+// An example of a radar chart comparing two
+// products over five named axes.
+func Example_radar() *plot.Plot {
+	r, err := plotter.NewRadar("Speed", "Reliability", "Comfort", "Safety", "Efficiency")
+	if err != nil {
+		panic(err)
+	}
+	a, err := r.Add(4, 3, 5, 4, 2)
+	if err != nil {
+		panic(err)
+	}
+	a.Color = plotutil.Color(0)
+	a.FillColor = color.NRGBA{R: 0xb4, G: 0x3e, B: 0x3e, A: 0x60}
+	b, err := r.Add(2, 5, 3, 5, 4)
+	if err != nil {
+		panic(err)
+	}
+	b.Color = plotutil.Color(1)
+	b.FillColor = color.NRGBA{R: 0x3e, G: 0x6e, B: 0xb4, A: 0x60}
+	r.Max = 5
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Radar chart"
+	p.HideAxes()
+	p.Add(r)
+	p.Legend.Add("model a", a)
+	p.Legend.Add("model b", b)
+	p.Legend.Top = true
+	return p
+}
+
+// An example of data spanning -1000 to 1000 with
+// fine detail near zero, drawn on a symmetric log
+// scale that is linear between -1 and 1.
+func Example_symlog() *plot.Plot {
+	pts := make(plotter.XYs, 401)
+	for i := range pts {
+		x := float64(i-200) / 20
+		pts[i].X = x
+		pts[i].Y = x*x*x + math.Sin(8*x)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Symmetric log scale"
+	p.Y.Label.Text = "x³ + sin 8x"
+	if err := p.Y.SetScale("symlog", 1); err != nil {
+		panic(err)
+	}
+	p.Add(plotter.NewGrid(), must(plotter.NewLine(pts)))
+	return p
+}
+
+// An example of a boxed plot with tick marks, drawn
+// inward, on all four sides and no grid.
+func Example_mirrorTicks() *plot.Plot {
+	rand.Seed(int64(0))
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Mirrored tick marks"
+	p.Frame = &plot.Frame{LineStyle: plotter.DefaultLineStyle}
+	for _, a := range []*plot.Axis{&p.X, &p.Y} {
+		a.Padding = 0
+		a.Tick.Direction = plot.TicksInside
+		a.Tick.Mirror = true
+	}
+	p.Add(must(plotter.NewScatter(randomPoints(25))))
+	return p
+}
+
+// An example of a signal with a window of time
+// highlighted by a translucent region.
+func Example_region() *plot.Plot {
+	pts := make(plotter.XYs, 200)
+	for i := range pts {
+		t := float64(i) / 20
+		pts[i].X = t
+		pts[i].Y = math.Sin(2*t) + 0.5*math.Exp(-(t-3.5)*(t-3.5)*8)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Region of interest"
+	p.X.Label.Text = "Time (s)"
+	window := must(plotter.NewRegion(3, 4, math.Inf(-1), math.Inf(1))).(*plotter.Region)
+	window.FillColor = color.NRGBA{R: 255, G: 165, A: 80}
+	p.Add(window, must(plotter.NewLine(pts)))
+	p.Legend.Add("event", window)
+	return p
+}
+
+// An example of a line of a million points that is
+// downsampled when it is drawn, so that the output is
+// small but looks the same.
+func Example_downsample() *plot.Plot {
+	rand.Seed(int64(0))
+	pts := make(plotter.XYs, 1000000)
+	for i := range pts {
+		x := float64(i) / float64(len(pts))
+		pts[i].X = x
+		pts[i].Y = math.Sin(20*x) + 0.2*rand.NormFloat64()
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "A million points"
+	l := must(plotter.NewLine(pts)).(*plotter.Line)
+	l.Downsample = true
+	p.Add(l)
+	return p
+}
+
+// An example of a heat map of a function sampled
+// on a grid.
+func Example_heatMap() *plot.Plot {
+	colors := make([]color.Color, 16)
+	for i := range colors {
+		v := uint8(255 * i / (len(colors) - 1))
+		colors[i] = color.RGBA{R: v, G: 64, B: 255 - v, A: 255}
+	}
+	h, err := plotter.NewHeatMap(bumpGrid{}, colors)
+	if err != nil {
+		panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Heat map"
+	p.Add(h)
+
+	cb := h.ColorBar()
+	cb.Position = plotter.ColorBarRight
+	p.Add(cb)
+	return p
+}
+
+// bumpGrid is a GridXYZ of two overlapping bumps
+// sampled on a 20 by 15 grid.
+type bumpGrid struct{}
+
+func (bumpGrid) Dims() (c, r int) { return 20, 15 }
+func (bumpGrid) X(c int) float64  { return float64(c) }
+func (bumpGrid) Y(r int) float64  { return float64(r) }
+func (bumpGrid) Z(c, r int) float64 {
+	x, y := float64(c), float64(r)
+	return math.Exp(-((x-6)*(x-6)+(y-5)*(y-5))/20) + 0.6*math.Exp(-((x-14)*(x-14)+(y-10)*(y-10))/10)
+}
+
+// An example of labeled contour lines of a function
+// sampled on a grid, with a style for each level.
+func Example_contour() *plot.Plot {
+	c, err := plotter.NewContour(bumpGrid{}, nil)
+	if err != nil {
+		panic(err)
+	}
+	c.Labels = true
+	c.LineStyles = make([]plot.LineStyle, len(c.Levels))
+	for i := range c.LineStyles {
+		c.LineStyles[i] = plotter.DefaultLineStyle
+		c.LineStyles[i].Color = plotutil.Color(i)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Contours"
+	p.Add(c)
+	return p
+}
+
+// An example of the bands between contour levels
+// filled from a palette, with the contour lines
+// drawn over them.
+func Example_filledContour() *plot.Plot {
+	colors := make([]color.Color, 8)
+	for i := range colors {
+		v := uint8(255 * i / (len(colors) - 1))
+		colors[i] = color.RGBA{R: v, G: 64, B: 255 - v, A: 255}
+	}
+	levels := []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9}
+	f, err := plotter.NewFilledContour(bumpGrid{}, levels, colors)
+	if err != nil {
+		panic(err)
+	}
+	c, err := plotter.NewContour(bumpGrid{}, levels)
+	if err != nil {
+		panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Filled contours"
+	p.Add(f, c)
+	return p
+}
+
+// An example of violin plots, which show that one of
+// the samples has two modes, with a box plot inside of
+// each violin.
+func Example_violins() *plot.Plot {
+	rand.Seed(int64(0))
+	n := 200
+	normal := make(plotter.Values, n)
+	expon := make(plotter.Values, n)
+	bimodal := make(plotter.Values, n)
+	for i := 0; i < n; i++ {
+		normal[i] = rand.NormFloat64()
+		expon[i] = rand.ExpFloat64()
+		bimodal[i] = rand.NormFloat64()/2 - 1.5
+		if i%2 == 0 {
+			bimodal[i] += 3
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Violin plots"
+	for i, vs := range []plotter.Values{normal, expon, bimodal} {
+		v, err := plotter.NewViolin(vg.Points(60), float64(i), vs)
+		if err != nil {
+			panic(err)
+		}
+		v.FillColor = plotutil.Color(i)
+		v.Box = true
+		p.Add(v)
+	}
+	p.NominalX("Normal", "Exponential", "Bimodal")
+	return p
+}
+
+// An example of a donut chart with an exploded
+// wedge and a legend entry for each wedge.
+func Example_pie() *plot.Plot {
+	names := []string{"Rent", "Food", "Transport", "Savings", "Other"}
+	colors := make([]color.Color, len(names))
+	for i := range colors {
+		colors[i] = plotutil.Color(i)
+	}
+	pie, err := plotter.NewPie(plotter.Values{35, 20, 15, 20, 10}, colors)
+	if err != nil {
+		panic(err)
+	}
+	pie.InnerRadius = 0.4
+	pie.Explode = []vg.Length{0, 0, 0, vg.Points(8)}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Monthly budget"
+	p.Add(pie)
+	p.HideAxes()
+	for i, name := range names {
+		p.Legend.Add(name, pie.Thumbnailer(i))
+	}
+	p.Legend.Top = true
+	return p
+}
+
+// An example of a rose curve and a scatter of
+// points drawn in polar coordinates.
+func Example_polar() *plot.Plot {
+	pl, err := plot.NewPolar()
+	if err != nil {
+		panic(err)
+	}
+	pl.R.Max = 2
+
+	rose := make(plotter.XYs, 361)
+	for i := range rose {
+		theta := 2 * math.Pi * float64(i) / float64(len(rose)-1)
+		rose[i].X = theta
+		rose[i].Y = 1 + math.Cos(3*theta)
+	}
+	pts, err := plotter.PolarXYs(pl, rose)
+	if err != nil {
+		panic(err)
+	}
+	l, err := plotter.NewLine(pts)
+	if err != nil {
+		panic(err)
+	}
+	l.Color = plotutil.Color(0)
+
+	obs := make(plotter.XYs, 24)
+	for i := range obs {
+		obs[i].X = rand.Float64() * 2 * math.Pi
+		obs[i].Y = rand.Float64() * 2
+	}
+	pts, err = plotter.PolarXYs(pl, obs)
+	if err != nil {
+		panic(err)
+	}
+	s, err := plotter.NewScatter(pts)
+	if err != nil {
+		panic(err)
+	}
+	s.Color = plotutil.Color(1)
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Polar coordinates"
+	p.HideAxes()
+	p.Add(pl, l, s)
+	return p
+}
+
+// An example of a candlestick chart of a random
+// walk of daily prices.
+func Example_candlesticks() *plot.Plot {
+	rnd := rand.New(rand.NewSource(1))
+	data := make(plotter.OHLCs, 30)
+	price := 100.0
+	for i := range data {
+		d := &data[i]
+		d.Open = price
+		d.Close = price + rnd.NormFloat64()*2
+		d.High = math.Max(d.Open, d.Close) + rnd.Float64()*1.5
+		d.Low = math.Min(d.Open, d.Close) - rnd.Float64()*1.5
+		price = d.Close
+	}
+	c, err := plotter.NewCandlesticks(data, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Candlesticks"
+	p.X.Label.Text = "Day"
+	p.Y.Label.Text = "Price"
+	p.Add(plotter.NewGrid(), c)
+	return p
+}
+
+// An example of hourly counts drawn as filled
+// steps, with the same counts drawn with the
+// other kinds of steps above them.
+func Example_steps() *plot.Plot {
+	rnd := rand.New(rand.NewSource(1))
+	counts := make(plotter.XYs, 12)
+	for i := range counts {
+		counts[i].X = float64(i)
+		counts[i].Y = float64(rnd.Intn(10))
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Steps"
+	p.X.Label.Text = "Hour"
+	p.Y.Label.Text = "Count"
+	for i, kind := range []plotter.StepKind{plotter.PostStep, plotter.PreStep, plotter.MidStep} {
+		shifted := make(plotter.XYs, len(counts))
+		for j, c := range counts {
+			shifted[j].X, shifted[j].Y = c.X, c.Y+float64(12*i)
+		}
+		s, err := plotter.NewStep(shifted)
+		if err != nil {
+			panic(err)
+		}
+		s.Kind = kind
+		s.Color = plotutil.Color(i)
+		if kind == plotter.PostStep {
+			s.FillColor = color.NRGBA{R: 0xb4, G: 0x3e, B: 0x3e, A: 0x40}
+		}
+		p.Add(s)
+		p.Legend.Add([]string{"post", "pre", "mid"}[i], s)
+	}
+	p.Legend.Top = true
+	return p
+}
+
+// An example of a confidence region drawn as the
+// area between two lines, above an area filled
+// down to zero.
+func Example_area() *plot.Plot {
+	n := 50
+	mean, upper, lower := make(plotter.XYs, n), make(plotter.XYs, n), make(plotter.XYs, n)
+	for i := range mean {
+		x := float64(i) / 5
+		y := 2 + math.Sin(x)
+		mean[i].X, mean[i].Y = x, y
+		upper[i].X, upper[i].Y = x, y+0.2+0.05*x
+		lower[i].X, lower[i].Y = x, y-0.2-0.05*x
+	}
+	under, err := plotter.NewArea(mean, 0)
+	if err != nil {
+		panic(err)
+	}
+	under.FillColor = color.NRGBA{R: 0x3e, G: 0x6e, B: 0xb4, A: 0x30}
+	band, err := plotter.NewAreaBetween(upper, lower)
+	if err != nil {
+		panic(err)
+	}
+	band.FillColor = color.NRGBA{R: 0xb4, G: 0x3e, B: 0x3e, A: 0x60}
+	band.LineStyle = plot.LineStyle{Color: plotutil.Color(0), Width: vg.Points(0.5), Dashes: []vg.Length{vg.Points(2), vg.Points(2)}}
+	l, err := plotter.NewLine(mean)
+	if err != nil {
+		panic(err)
+	}
+	l.Color = plotutil.Color(0)
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Areas"
+	p.Add(under, band, l)
+	p.Legend.Add("mean", l)
+	p.Legend.Add("confidence", band)
+	return p
+}
+
+// An example of a grouped bar chart with a bar for
+// each of three series in each of four categories.
+func Example_groupedBars() *plot.Plot {
+	series := []struct {
+		name   string
+		values plotter.Values
+	}{
+		{"2013", plotter.Values{20, 35, 30, 35}},
+		{"2014", plotter.Values{25, 32, 34, 20}},
+		{"2015", plotter.Values{22, 38, 28, 30}},
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Grouped bar chart"
+	p.Y.Label.Text = "Sales"
+
+	bars := make([]*plotter.BarChart, len(series))
+	for i, s := range series {
+		bars[i] = must(plotter.NewBarChart(s.values, vg.Points(1))).(*plotter.BarChart)
+		bars[i].Color = plotutil.Color(i)
+		p.Add(bars[i])
+		p.Legend.Add(s.name, bars[i])
+	}
+	plotter.GroupBars(vg.Points(45), bars...)
+	p.NominalX("North", "South", "East", "West")
+	p.Legend.Top = true
+	return p
+}
+
+// An example of a horizontal bar chart, whose
+// long category names fit on the Y axis.
+func Example_horizontalBars() *plot.Plot {
+	b := must(plotter.NewBarChart(plotter.Values{42, 35, 28, 17, 9}, vg.Points(15))).(*plotter.BarChart)
+	b.Horizontal = true
+	b.Color = plotutil.Color(2)
+	b.ShowValues = true
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Horizontal bar chart"
+	p.X.Label.Text = "Responses"
+	p.Add(b)
+	p.NominalY("Documentation", "Performance", "Error messages", "Installation", "Other")
+	return p
+}
+
+// dipoleGrid is a GridVectors of the field of a pair
+// of opposite charges sampled on a 30 by 30 grid.
+type dipoleGrid struct{}
+
+func (dipoleGrid) Dims() (c, r int) { return 30, 30 }
+func (dipoleGrid) X(c int) float64  { return -2 + 4*float64(c)/29 }
+func (dipoleGrid) Y(r int) float64  { return -2 + 4*float64(r)/29 }
+func (g dipoleGrid) Vector(c, r int) (u, v float64) {
+	x, y := g.X(c), g.Y(r)
+	for _, q := range []struct{ x, q float64 }{{-0.7, 1}, {0.7, -1}} {
+		dx, dy := x-q.x, y
+		d3 := math.Pow(dx*dx+dy*dy+0.01, 1.5)
+		u += q.q * dx / d3
+		v += q.q * dy / d3
+	}
+	return u, v
+}
+
+// An example of the streamlines of the electric
+// field of a dipole.
+func Example_streamlines() *plot.Plot {
+	s, err := plotter.NewStreamlines(dipoleGrid{})
+	if err != nil {
+		panic(err)
+	}
+	s.Density = 25
+	s.Color = plotutil.Color(2)
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Streamlines"
+	p.Add(s)
+	return p
+}
+
+// An example of hexagonal binning of a large
+// number of normally distributed points.
+func Example_hexBin() *plot.Plot {
+	rand.Seed(int64(0))
+	pts := make(plotter.XYs, 5000)
+	for i := range pts {
+		pts[i].X = rand.NormFloat64()
+		pts[i].Y = pts[i].X + rand.NormFloat64()
+	}
+	colors := make([]color.Color, 16)
+	for i := range colors {
+		v := uint8(255 * i / (len(colors) - 1))
+		colors[i] = color.RGBA{R: v, G: 64, B: 255 - v, A: 255}
+	}
+	h, err := plotter.NewHexBin(pts, 0.25, 0.5, colors)
+	if err != nil {
+		panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Hexagonal binning"
+	p.Add(h)
+	return p
+}
+
+// An example of a two dimensional histogram of
+// correlated points, colored by the logarithm of
+// the counts of the bins.
+func Example_histogram2D() *plot.Plot {
+	rand.Seed(int64(0))
+	pts := make(plotter.XYs, 5000)
+	for i := range pts {
+		pts[i].X = rand.NormFloat64()
+		pts[i].Y = pts[i].X + rand.NormFloat64()
+	}
+	colors := make([]color.Color, 16)
+	for i := range colors {
+		v := uint8(255 * i / (len(colors) - 1))
+		colors[i] = color.RGBA{R: v, G: 64, B: 255 - v, A: 255}
+	}
+	h, err := plotter.NewHistogram2D(pts, 30, 30, colors)
+	if err != nil {
+		panic(err)
+	}
+	h.Log = true
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "2D histogram"
+	p.Add(h)
+	return p
+}
+
+// An example of a kernel density estimate of a
+// bimodal sample drawn over its histogram.
+func Example_kde() *plot.Plot {
+	rand.Seed(int64(0))
+	vals := make(plotter.Values, 200)
+	for i := range vals {
+		vals[i] = rand.NormFloat64()
+		if i%3 == 0 {
+			vals[i] += 4
+		}
+	}
+	h := must(plotter.NewHist(vals, 20)).(*plotter.Histogram)
+	h.Normalize(1)
+	h.FillColor = color.Gray{Y: 220}
+	k := must(plotter.NewKDE(vals, plotter.SilvermanBandwidth)).(*plotter.KDE)
+	k.FillColor = color.NRGBA{R: 196, G: 64, B: 64, A: 64}
+	k.Color = plotutil.Color(0)
+	k.Width = vg.Points(2)
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Kernel density estimate"
+	p.Add(h, k)
+	return p
+}
+
+// An example of a ridgeline plot of the densities
+// of samples whose spread grows month by month.
+func Example_ridgeline() *plot.Plot {
+	rand.Seed(int64(0))
+	months := []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun"}
+	traces := make([]plotter.XYer, len(months))
+	for i := range months {
+		vals := make(plotter.Values, 100)
+		for j := range vals {
+			vals[j] = float64(i) + (1+float64(i)/2)*rand.NormFloat64()
+		}
+		k := must(plotter.NewKDE(vals, plotter.SilvermanBandwidth)).(*plotter.KDE)
+		traces[i] = k.Curve()
+	}
+	r, err := plotter.NewRidgeline(traces...)
+	if err != nil {
+		panic(err)
+	}
+	r.FillColors = []color.Color{color.NRGBA{R: 160, G: 200, B: 240, A: 255}}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Ridgeline"
+	p.Add(r)
+	p.NominalY(months...)
+	return p
+}
+
+// An example of a waterfall chart of the
+// contributions to a year's profit.
+func Example_waterfall() *plot.Plot {
+	w, err := plotter.NewWaterfall(plotter.Values{120, -45, -30, 25, -10}, vg.Points(20))
+	if err != nil {
+		panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Waterfall"
+	p.Y.Label.Text = "Profit"
+	p.Add(w)
+	p.NominalX("Sales", "Costs", "Tax", "Grants", "Other", "Total")
+	return p
+}
+
+// An example of a Gantt chart of the phases of a
+// project, some of which are partly done.
+func Example_gantt() *plot.Plot {
+	g, err := plotter.NewGantt([]plotter.GanttTask{
+		{Category: "Design", Start: 0, End: 3, Progress: 1},
+		{Category: "Build", Start: 2, End: 9, Progress: 0.6},
+		{Category: "Design", Start: 6, End: 7, Progress: 0.5},
+		{Category: "Test", Start: 5, End: 11, Progress: 0.2},
+		{Category: "Release", Start: 11, End: 12, Color: plotutil.Color(0)},
+	}, vg.Points(16))
+	if err != nil {
+		panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Gantt chart"
+	p.X.Label.Text = "Week"
+	p.Add(g)
+	p.NominalY(g.Categories()...)
+	return p
+}
+
+// xyLabels is a set of labelled points.
+type xyLabels struct {
+	plotter.XYs
+	labels []string
+}
+
+func (l xyLabels) Label(i int) string {
+	return l.labels[i]
+}
+
+// An example of labelling every point in a tight
+// cluster, with the labels moved apart so that
+// they do not overlap.
+func Example_labelPlacement() *plot.Plot {
+	rand.Seed(int64(0))
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Label placement"
+
+	pts := make(plotter.XYs, 12)
+	names := make([]string, len(pts))
+	for i := range pts {
+		pts[i].X = 5 + 0.3*rand.NormFloat64()
+		pts[i].Y = 5 + 0.3*rand.NormFloat64()
+		names[i] = fmt.Sprintf("P%d", i)
+	}
+	s := must(plotter.NewScatter(pts)).(*plotter.Scatter)
+	l := must(plotter.NewLabels(xyLabels{pts, names})).(*plotter.Labels)
+	l.AvoidOverlap = true
+	l.Avoid = []plot.GlyphBoxer{s}
+	p.Add(s, l)
+	p.X.Min, p.X.Max = 3, 7
+	p.Y.Min, p.Y.Max = 3, 7
+
+	return p
+}
+
+// An example of a categorical X axis: monthly
+// sales as bars, with a box plot of the daily
+// sales of one month at the center of its
+// category.
+func Example_categories() *plot.Plot {
+	rand.Seed(int64(0))
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Sales per month"
+	p.X.Categories = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun"}
+
+	sales := plotter.Values{120, 95, 140, 160, 150, 175}
+	bars := must(plotter.NewBarChart(sales, vg.Points(20))).(*plotter.BarChart)
+	bars.Color = color.RGBA{B: 200, A: 255}
+	p.Add(bars)
+
+	daily := make(plotter.Values, 30)
+	for i := range daily {
+		daily[i] = 175 + 20*rand.NormFloat64()
+	}
+	jun, _ := p.X.Category("Jun")
+	p.Add(must(plotter.NewBoxPlot(vg.Points(10), jun, daily)))
+
+	return p
+}
+
+// An example of a grid on a log scale Y axis,
+// with fainter lines at the minor tick marks.
+func Example_logGrid() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Log grid"
+	p.Y.Scale = plot.LogScale
+	p.Y.Tick.Marker = plot.LogTicks
+
+	g := plotter.NewGrid()
+	g.HorizontalMinor = plot.LineStyle{
+		Color: color.Gray{200},
+		Width: vg.Points(0.25),
+	}
+	p.Add(g)
+
+	exp := plotter.NewFunction(math.Exp)
+	p.Add(exp)
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 1, math.Exp(10)
+
+	return p
+}
+
+// An example of long category labels on the X
+// axis rotated by 45° so that they do not overlap.
+func Example_rotatedLabels() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Rotated labels"
+	p.X.Categories = []string{
+		"Northern region", "Southern region", "Eastern region",
+		"Western region", "Central region", "Overseas",
+	}
+	p.X.Tick.LabelRotation = math.Pi / 4
+
+	vs := plotter.Values{12, 17, 9, 14, 21, 5}
+	p.Add(must(plotter.NewBarChart(vs, vg.Points(15))))
+
+	return p
+}
+
+// An example of an inset showing a zoom of
+// a narrow spike in a function.
+func Example_inset() *plot.Plot {
+	spike := func(x float64) float64 {
+		return math.Sin(x) + 2*math.Exp(-(x-5)*(x-5)/0.005)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Inset"
+	f := plotter.NewFunction(spike)
+	f.Adaptive = true
+	p.Add(f)
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = -1.5, 3
+
+	zoom, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	zoom.Add(plotter.NewFunction(spike))
+	zoom.X.Min, zoom.X.Max = 4.8, 5.2
+	zoom.Y.Min, zoom.Y.Max = -1.5, 1.5
+
+	in := must(plotter.NewInset(zoom, 0.6, 0.55, 0.38, 0.43)).(*plotter.Inset)
+	in.Border = plot.LineStyle{Color: color.Black, Width: vg.Points(0.5)}
+	p.Add(in)
+
+	return p
+}
+
+// An example of a sparse series of measurements
+// drawn with straight, Catmull-Rom and monotone
+// cubic lines.
+func Example_smoothLines() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Smooth lines"
+
+	data := plotter.XYs{{0, 1}, {1, 1.2}, {2, 4}, {3, 8}, {4, 8.2}, {5, 8.3}, {6, 12}}
+	for i, interp := range []plotter.Interpolation{
+		plotter.LinearInterpolation,
+		plotter.CatmullRomInterpolation,
+		plotter.MonotoneInterpolation,
+	} {
+		l := must(plotter.NewLine(data)).(*plotter.Line)
+		l.Interpolation = interp
+		l.Color = []color.Color{
+			color.Gray{160},
+			color.RGBA{R: 255, A: 255},
+			color.RGBA{B: 255, A: 255},
+		}[i]
+		p.Add(l)
+		p.Legend.Add([]string{"linear", "Catmull-Rom", "monotone"}[i], l)
+	}
+	p.Add(must(plotter.NewScatter(data)))
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	return p
+}
+
+// An example of a log scale axis with SI prefixes:
+// the frequency response of a first-order low-pass
+// filter from 10Hz to 1MHz.
+func Example_frequencyResponse() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Low-pass filter"
+	p.X.Label.Text = "Frequency (Hz)"
+	p.Y.Label.Text = "Gain (dB)"
+	p.X.Scale = plot.LogScale
+	p.X.Tick.Marker = plot.SILogTicks
+
+	g := plotter.NewGrid()
+	g.VerticalMinor = plot.LineStyle{Color: color.Gray{200}, Width: vg.Points(0.25)}
+	p.Add(g)
+
+	const cutoff = 1e3
+	pts := make(plotter.XYs, 101)
+	for i := range pts {
+		f := math.Pow(10, 1+5*float64(i)/float64(len(pts)-1))
+		pts[i].X = f
+		pts[i].Y = -10 * math.Log10(1+(f/cutoff)*(f/cutoff))
+	}
+	p.Add(must(plotter.NewLine(pts)))
+	p.X.Min, p.X.Max = 10, 1e6
+
+	return p
+}
+
+// An example of two overlapping bands with 50%
+// opacity.  The overlap should be the same purple
+// in the PDF and PNG output.
+func Example_translucentBands() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Translucent bands"
+
+	low := must(plotter.NewPolygon(
+		plotter.XYs{{0, 1}, {10, 3}, {10, 6}, {0, 4}},
+	)).(*plotter.Polygon)
+	low.FillColor = color.NRGBA{R: 255, A: 128}
+	high := must(plotter.NewPolygon(
+		plotter.XYs{{0, 6}, {10, 1}, {10, 4}, {0, 9}},
+	)).(*plotter.Polygon)
+	high.FillColor = color.NRGBA{B: 255, A: 128}
+
+	p.Add(low, high)
+	p.Legend.Add("Low", low)
+	p.Legend.Add("High", high)
+	p.Legend.Top = true
+
+	return p
+}
+
 func must(p plot.Plotter, err error) plot.Plotter {
 	if err != nil {
 		panic(err)
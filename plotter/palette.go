@@ -0,0 +1,107 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"sort"
+
+	"github.com/gonum/plot/plot"
+)
+
+// DiscretePalette maps ranges of values to colors,
+// such as classes of a categorical map, instead of
+// blending colors continuously.
+type DiscretePalette struct {
+	// Boundaries are the increasing values that
+	// separate the ranges.  Colors[i] is used for
+	// the values from Boundaries[i] up to
+	// Boundaries[i+1].
+	Boundaries []float64
+
+	// Colors is the color of each range.  There
+	// is one fewer color than boundaries.
+	Colors []color.Color
+
+	// Labels, if non-nil, are the labels of the
+	// tick marks returned by Ticks, one for each
+	// boundary.  Otherwise the ticks are labelled
+	// with the boundary values.
+	Labels []string
+}
+
+// NewDiscretePalette returns a DiscretePalette with the
+// given boundaries and colors.  There must be one more
+// boundary than there are colors.
+func NewDiscretePalette(boundaries []float64, colors []color.Color) (*DiscretePalette, error) {
+	if len(colors) == 0 {
+		return nil, errors.New("No colors in the palette")
+	}
+	if len(boundaries) != len(colors)+1 {
+		return nil, errors.New("Palette needs one more boundary than colors")
+	}
+	if err := CheckFloats(boundaries...); err != nil {
+		return nil, err
+	}
+	for i := 1; i < len(boundaries); i++ {
+		if boundaries[i] <= boundaries[i-1] {
+			return nil, errors.New("Palette boundaries are not increasing")
+		}
+	}
+	return &DiscretePalette{
+		Boundaries: append([]float64(nil), boundaries...),
+		Colors:     append([]color.Color(nil), colors...),
+	}, nil
+}
+
+// Color returns the color of the range containing v.
+// A value on a boundary between two ranges is in the
+// higher range, except for the last boundary, which
+// is in the last range.  Color returns nil if v is
+// outside of the boundaries.
+func (p *DiscretePalette) Color(v float64) color.Color {
+	n := len(p.Boundaries)
+	if n < 2 || v < p.Boundaries[0] || v > p.Boundaries[n-1] {
+		return nil
+	}
+	// The index of the first boundary above v
+	// is one more than the index of its range.
+	i := sort.Search(n, func(i int) bool { return p.Boundaries[i] > v })
+	if i == n {
+		return p.Colors[n-2]
+	}
+	return p.Colors[i-1]
+}
+
+// Ticks returns a tick mark at each boundary between
+// min and max.  It is suitable for the Tick.Marker
+// field of the axis of a plot with a ColorBar made
+// from the palette.
+func (p *DiscretePalette) Ticks(min, max float64) []plot.Tick {
+	var ticks []plot.Tick
+	for i, b := range p.Boundaries {
+		if b < min || b > max {
+			continue
+		}
+		label := plot.FormatFloat(b, 'g', -1, 64)
+		if p.Labels != nil {
+			label = p.Labels[i]
+		}
+		ticks = append(ticks, plot.Tick{Value: b, Label: label})
+	}
+	return ticks
+}
+
+// ColorBar returns a horizontal ColorBar that shows
+// the ranges of the palette, with tick marks at the
+// boundaries when it is drawn beside the data area.
+func (p *DiscretePalette) ColorBar() *ColorBar {
+	n := len(p.Boundaries)
+	c := newColorBar(p.Colors, p.Boundaries[0], p.Boundaries[n-1])
+	c.Boundaries = p.Boundaries
+	c.Ticks = p.Ticks
+	return c
+}
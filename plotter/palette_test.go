@@ -0,0 +1,38 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestDiscretePaletteColor(t *testing.T) {
+	a, b, c := color.Gray{Y: 0}, color.Gray{Y: 128}, color.Gray{Y: 255}
+	p, err := NewDiscretePalette([]float64{0, 1, 5, 10}, []color.Color{a, b, c})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		v    float64
+		want color.Color
+	}{
+		{-1, nil},
+		{0, a},
+		{0.5, a},
+		{1, b},
+		{4.9, b},
+		{5, c},
+		{10, c},
+		{10.1, nil},
+	} {
+		if got := p.Color(test.v); got != test.want {
+			t.Errorf("Color(%g) = %v, want %v", test.v, got, test.want)
+		}
+	}
+	if _, err := NewDiscretePalette([]float64{0, 2, 1}, []color.Color{a, b}); err == nil {
+		t.Errorf("expected an error for decreasing boundaries")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// PColorMesh implements the Plotter interface, drawing
+// a grid of rectangular cells, such as the time and
+// frequency bins of a spectrogram, each filled with the
+// color of its value.  Unlike the cells of an image, the
+// cells need not all be the same size: the boundaries
+// between them are given explicitly.
+type PColorMesh struct {
+	// X and Y are the increasing boundaries of the
+	// columns and rows of cells.  Column i extends
+	// from X[i] to X[i+1], and row j from Y[j] to
+	// Y[j+1].
+	X, Y []float64
+
+	// Values are the values of the cells, indexed
+	// by row then column.  Cells whose value is NaN
+	// are not drawn.
+	Values [][]float64
+
+	// Colors is the palette.  As in a ColorBar, the
+	// range from Min to Max is divided into len(Colors)
+	// equal parts, and each cell is filled with the color
+	// of the part containing its value.  Values outside
+	// of the range have the color of the nearest end.
+	Colors []color.Color
+
+	// Min and Max are the range of values mapped to
+	// the palette.  NewPColorMesh sets them to the
+	// range of the finite values of the cells.
+	Min, Max float64
+}
+
+// NewPColorMesh returns a PColorMesh of the given cell
+// boundaries and values, which it copies.  There must
+// be one more X boundary than there are columns of
+// values, and one more Y boundary than there are rows.
+func NewPColorMesh(x, y []float64, values [][]float64, colors []color.Color) (*PColorMesh, error) {
+	if len(colors) == 0 {
+		return nil, errors.New("No colors in the palette")
+	}
+	if len(x) < 2 || len(y) < 2 {
+		return nil, ErrNoData
+	}
+	for _, bs := range [][]float64{x, y} {
+		if err := CheckFloats(bs...); err != nil {
+			return nil, err
+		}
+		for i := 1; i < len(bs); i++ {
+			if bs[i] <= bs[i-1] {
+				return nil, errors.New("Mesh boundaries are not increasing")
+			}
+		}
+	}
+	if len(values) != len(y)-1 {
+		return nil, errors.New("Mesh needs one more Y boundary than rows")
+	}
+	m := &PColorMesh{
+		X:      append([]float64(nil), x...),
+		Y:      append([]float64(nil), y...),
+		Values: make([][]float64, len(values)),
+		Colors: colors,
+		Min:    math.Inf(1),
+		Max:    math.Inf(-1),
+	}
+	for j, row := range values {
+		if len(row) != len(x)-1 {
+			return nil, errors.New("Mesh needs one more X boundary than columns")
+		}
+		m.Values[j] = append([]float64(nil), row...)
+		for _, v := range row {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			m.Min, m.Max = math.Min(m.Min, v), math.Max(m.Max, v)
+		}
+	}
+	if m.Min > m.Max {
+		m.Min, m.Max = 0, 0
+	}
+	return m, nil
+}
+
+// Color returns the color of a cell with the given
+// value, or nil if the value is NaN.
+func (m *PColorMesh) Color(v float64) color.Color {
+	return paletteColor(m.Colors, m.Min, m.Max, v)
+}
+
+// paletteColor returns the color of v in a palette
+// that divides the range from min to max into equal
+// parts, one for each color, as in a ColorBar.  Values
+// outside of the range, including infinities, have the
+// color of the nearest end.  NaN has no color, so
+// paletteColor returns nil for it.
+func paletteColor(colors []color.Color, min, max, v float64) color.Color {
+	if math.IsNaN(v) {
+		return nil
+	}
+	n := len(colors)
+	i := 0
+	if max > min && v > min {
+		i = n - 1
+		if v < max {
+			i = int(float64(n) * (v - min) / (max - min))
+		}
+	}
+	if i >= n {
+		i = n - 1
+	}
+	return colors[i]
+}
+
+// Plot draws the cells, implementing the plot.Plotter
+// interface.
+func (m *PColorMesh) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	for j, row := range m.Values {
+		ylo, yhi := trY(m.Y[j]), trY(m.Y[j+1])
+		for i, v := range row {
+			if math.IsNaN(v) {
+				continue
+			}
+			xlo, xhi := trX(m.X[i]), trX(m.X[i+1])
+			pts := []plot.Point{
+				{xlo, ylo},
+				{xhi, ylo},
+				{xhi, yhi},
+				{xlo, yhi},
+			}
+			da.FillPolygon(m.Color(v), da.ClipPolygonXY(pts))
+		}
+	}
+}
+
+// DataRange returns the range of the cell boundaries,
+// implementing the plot.DataRanger interface.
+func (m *PColorMesh) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return m.X[0], m.X[len(m.X)-1], m.Y[0], m.Y[len(m.Y)-1]
+}
+
+// ColorBar returns a horizontal ColorBar that shows
+// the palette and range of values of the mesh.
+func (m *PColorMesh) ColorBar() *ColorBar {
+	return newColorBar(m.Colors, m.Min, m.Max)
+}
@@ -0,0 +1,83 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+func TestPColorMeshCells(t *testing.T) {
+	lo, hi := color.Gray{Y: 0}, color.Gray{Y: 255}
+	m, err := NewPColorMesh(
+		[]float64{0, 1, 10},
+		[]float64{0, 2},
+		[][]float64{{3, 7}},
+		[]color.Color{lo, hi},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(m)
+	prims, err := p.Trace(vg.Inches(4), vg.Inches(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prims) != 2 {
+		t.Fatalf("traced %d cells, want 2", len(prims))
+	}
+	for i, want := range []struct {
+		xmin, xmax float64
+		c          color.Color
+	}{
+		{0, 1, lo},
+		{1, 10, hi},
+	} {
+		prim := prims[i]
+		xmin, xmax := math.Inf(1), math.Inf(-1)
+		for _, x := range prim.X {
+			xmin, xmax = math.Min(xmin, x), math.Max(xmax, x)
+		}
+		if math.Abs(xmin-want.xmin) > 1e-9 || math.Abs(xmax-want.xmax) > 1e-9 || prim.Color != want.c {
+			t.Errorf("cell %d spans [%v, %v] in %v, want [%v, %v] in %v", i, xmin, xmax, prim.Color, want.xmin, want.xmax, want.c)
+		}
+	}
+
+	if _, err := NewPColorMesh([]float64{0, 1}, []float64{0, 1}, [][]float64{{1, 2}}, []color.Color{lo}); err == nil {
+		t.Errorf("expected an error for too many columns")
+	}
+}
+
+func TestPaletteColor(t *testing.T) {
+	colors := []color.Color{color.Gray{Y: 0}, color.Gray{Y: 128}, color.Gray{Y: 255}}
+	for _, test := range []struct {
+		v    float64
+		want color.Color
+	}{
+		{v: math.Inf(-1), want: colors[0]},
+		{v: -5, want: colors[0]},
+		{v: 0, want: colors[0]},
+		{v: 1.5, want: colors[1]},
+		{v: 3, want: colors[2]},
+		{v: 5, want: colors[2]},
+		{v: math.Inf(1), want: colors[2]},
+		{v: math.NaN(), want: nil},
+	} {
+		if got := paletteColor(colors, 0, 3, test.v); got != test.want {
+			t.Errorf("paletteColor(%g) = %v, want %v", test.v, got, test.want)
+		}
+	}
+	if got := paletteColor(colors, 1, 1, math.Inf(1)); got != colors[0] {
+		t.Errorf("paletteColor(+Inf) in an empty range = %v, want %v", got, colors[0])
+	}
+}
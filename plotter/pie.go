@@ -0,0 +1,200 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// Pie implements the Plotter interface, drawing a pie
+// chart: a wedge for each value, clockwise from the
+// top, whose angle is the value's share of the total.
+// A donut chart is a pie with a non-zero InnerRadius.
+//
+// The pie is drawn in the largest circle that fits in
+// the data area, so it does not use the axes of the
+// plot, which should be hidden with the plot's HideAxes
+// method.
+type Pie struct {
+	// Values are the values of the wedges.
+	Values
+
+	// Colors are the colors of the wedges, in turn.
+	// If there are fewer colors than values then the
+	// colors are cycled.
+	Colors []color.Color
+
+	// InnerRadius is the radius of the hole of a
+	// donut chart, as a fraction of the radius of
+	// the pie.
+	InnerRadius float64
+
+	// Explode are the distances by which each wedge,
+	// in turn, is moved out from the center.  Wedges
+	// without a distance are not moved.
+	Explode []vg.Length
+
+	// LineStyle is the style of the outlines of the
+	// wedges.  If its width is zero then the wedges
+	// are not outlined.
+	plot.LineStyle
+
+	// Labels, if true, draws the percentage of the
+	// total of each wedge in its middle.
+	Labels bool
+
+	// TextStyle is the style of the labels.
+	TextStyle plot.TextStyle
+}
+
+// pieArcStep is the greatest angle, in radians,
+// between the points of the arc of a wedge.
+const pieArcStep = math.Pi / 90
+
+// NewPie returns a Pie of the values with the given
+// colors.  The wedges are outlined in white, to set
+// them apart, and labeled.  An error is returned if
+// there are no colors or if a value is negative.
+func NewPie(vs Valuer, colors []color.Color) (*Pie, error) {
+	if len(colors) == 0 {
+		return nil, errors.New("No colors in the palette")
+	}
+	values, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		if v < 0 {
+			return nil, errors.New("Negative pie value")
+		}
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Pie{
+		Values:    values,
+		Colors:    colors,
+		LineStyle: plot.LineStyle{Color: color.White, Width: vg.Points(1)},
+		Labels:    true,
+		TextStyle: plot.TextStyle{Color: color.Black, Font: fnt},
+	}, nil
+}
+
+// Color returns the color of the ith wedge.
+func (pie *Pie) Color(i int) color.Color {
+	return pie.Colors[i%len(pie.Colors)]
+}
+
+// explode returns the distance by which the
+// ith wedge is moved out from the center.
+func (pie *Pie) explode(i int) vg.Length {
+	if i < len(pie.Explode) {
+		return pie.Explode[i]
+	}
+	return 0
+}
+
+// Plot draws the wedges and their labels,
+// implementing the plot.Plotter interface.
+func (pie *Pie) Plot(da plot.DrawArea, plt *plot.Plot) {
+	var total float64
+	var out vg.Length
+	for i, v := range pie.Values {
+		total += v
+		if e := pie.explode(i); e > out {
+			out = e
+		}
+	}
+	if total == 0 {
+		return
+	}
+	c := da.Center()
+	rad := da.Size.X/2 - out
+	if ry := da.Size.Y/2 - out; ry < rad {
+		rad = ry
+	}
+	if rad <= 0 {
+		return
+	}
+	inner := rad * vg.Length(pie.InnerRadius)
+
+	// Angles are measured clockwise from the top.
+	point := func(center plot.Point, r vg.Length, theta float64) plot.Point {
+		return plot.Pt(center.X+r*vg.Length(math.Sin(theta)), center.Y+r*vg.Length(math.Cos(theta)))
+	}
+	var start float64
+	var labels []pieLabel
+	for i, v := range pie.Values {
+		if v == 0 {
+			continue
+		}
+		end := start + 2*math.Pi*v/total
+		mid := (start + end) / 2
+		center := point(c, pie.explode(i), mid)
+
+		n := int(math.Ceil((end-start)/pieArcStep)) + 1
+		pts := make([]plot.Point, 0, 2*n+1)
+		for k := 0; k < n; k++ {
+			pts = append(pts, point(center, rad, start+(end-start)*float64(k)/float64(n-1)))
+		}
+		if inner > 0 {
+			for k := n - 1; k >= 0; k-- {
+				pts = append(pts, point(center, inner, start+(end-start)*float64(k)/float64(n-1)))
+			}
+		} else {
+			pts = append(pts, center)
+		}
+		da.FillPolygon(pie.Color(i), pts)
+		if pie.Width > 0 {
+			da.StrokeLines(pie.LineStyle, append(pts, pts[0]))
+		}
+
+		labels = append(labels, pieLabel{
+			at:  point(center, (rad+inner)/2, mid),
+			txt: plot.FormatFloat(100*v/total, 'f', 1, 64) + "%",
+		})
+		start = end
+	}
+
+	// The labels are drawn after the wedges, so that
+	// an exploded wedge does not cover the label of
+	// its neighbor.
+	if !pie.Labels {
+		return
+	}
+	for _, l := range labels {
+		da.FillText(pie.TextStyle, l.at.X, l.at.Y, -0.5, -0.5, l.txt)
+	}
+}
+
+// A pieLabel is the label of a wedge of a Pie.
+type pieLabel struct {
+	at  plot.Point
+	txt string
+}
+
+// pieWedge is the legend entry of a wedge of a Pie.
+type pieWedge struct {
+	clr color.Color
+}
+
+// Thumbnail fills the draw area with the color of
+// the wedge, implementing the plot.Thumbnailer
+// interface.
+func (w pieWedge) Thumbnail(da *plot.DrawArea) {
+	fillThumbnail(da, w.clr)
+}
+
+// Thumbnailer returns a plot.Thumbnailer of the ith
+// wedge, for giving the wedge an entry in the legend.
+func (pie *Pie) Thumbnailer(i int) plot.Thumbnailer {
+	return pieWedge{clr: pie.Color(i)}
+}
@@ -0,0 +1,49 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"bytes"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/vgsvg"
+)
+
+func TestPie(t *testing.T) {
+	colors := []color.Color{color.Black, color.Gray{128}}
+	if _, err := NewPie(Values{1, -1}, colors); err == nil {
+		t.Errorf("no error for a negative value")
+	}
+	if _, err := NewPie(Values{1, 1}, nil); err == nil {
+		t.Errorf("no error for an empty palette")
+	}
+
+	pie, err := NewPie(Values{1, 3}, colors)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pie.InnerRadius = 0.5
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(pie)
+	p.HideAxes()
+	c := vgsvg.New(vg.Inches(4), vg.Inches(4))
+	p.Draw(plot.MakeDrawArea(c))
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, label := range []string{">25.0%<", ">75.0%<"} {
+		if !strings.Contains(buf.String(), label) {
+			t.Errorf("pie has no label %q", label)
+		}
+	}
+}
@@ -29,7 +29,7 @@ var (
 	// DefaultLineStyle is the default style for drawing
 	// lines.
 	DefaultLineStyle = plot.LineStyle{
-		Color:    color.Black,
+		Color:    defaultColor{color.Black},
 		Width:    vg.Points(1),
 		Dashes:   []vg.Length{},
 		DashOffs: 0,
@@ -38,12 +38,28 @@ var (
 	// DefaultGlyphStyle is the default style used
 	// for gyph marks.
 	DefaultGlyphStyle = plot.GlyphStyle{
-		Color:  color.Black,
+		Color:  defaultColor{color.Black},
 		Radius: vg.Points(2.5),
 		Shape:  plot.RingGlyph{},
 	}
 )
 
+// defaultColor is the type of the colors of the
+// default styles, so that a color that was given by
+// a constructor can be told apart from a color of
+// the same value that was set explicitly.
+type defaultColor struct {
+	color.Color
+}
+
+// unsetColor returns true if the color c has not
+// been changed from the default color given by a
+// constructor.  A nil color is also unset.
+func unsetColor(c color.Color) bool {
+	_, ok := c.(defaultColor)
+	return c == nil || ok
+}
+
 // Valuer wraps the Len and Value methods.
 type Valuer interface {
 	// Len returns the number of values.
@@ -53,12 +69,33 @@ type Valuer interface {
 	Value(int) float64
 }
 
+// fillThumbnail fills the draw area of a legend
+// thumbnail with the color c, if it is not nil, and
+// returns the corners of the draw area so that the
+// thumbnail can also be outlined.
+func fillThumbnail(da *plot.DrawArea, c color.Color) []plot.Point {
+	pts := []plot.Point{
+		{da.Min.X, da.Min.Y},
+		{da.Max().X, da.Min.Y},
+		{da.Max().X, da.Max().Y},
+		{da.Min.X, da.Max().Y},
+	}
+	if c != nil {
+		da.FillPolygon(c, da.ClipPolygonXY(pts))
+	}
+	return pts
+}
+
 // Range returns the minimum and maximum values.
+// NaN and infinite values are ignored.
 func Range(vs Valuer) (min, max float64) {
 	min = math.Inf(1)
 	max = math.Inf(-1)
 	for i := 0; i < vs.Len(); i++ {
 		v := vs.Value(i)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
 		min = math.Min(min, v)
 		max = math.Max(max, v)
 	}
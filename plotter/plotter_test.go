@@ -0,0 +1,65 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// tracedBox is the kind, color and bounding box, in
+// data coordinates, of a traced primitive.  A bound
+// that is NaN is not checked, so that sizes that are
+// given as lengths rather than as data need not be
+// worked out.
+type tracedBox struct {
+	kind                   plot.TraceKind
+	color                  color.Color
+	xmin, xmax, ymin, ymax float64
+}
+
+// checkTrace traces the plot and checks that the
+// primitives that it draws are those in want, in order.
+func checkTrace(t *testing.T, p *plot.Plot, want []tracedBox) {
+	prims, err := p.Trace(vg.Inches(4), vg.Inches(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prims) != len(want) {
+		t.Errorf("traced %d primitives, want %d", len(prims), len(want))
+	}
+	// The traced coordinates are mapped back from
+	// the canvas, so they are not exact.
+	const tol = 1e-9
+	for i := 0; i < len(prims) && i < len(want); i++ {
+		prim, w := prims[i], want[i]
+		if prim.Kind != w.kind || prim.Color != w.color {
+			t.Errorf("primitive %d is of kind %d in %v, want kind %d in %v",
+				i, prim.Kind, prim.Color, w.kind, w.color)
+		}
+		got := tracedBox{
+			xmin: math.Inf(1), xmax: math.Inf(-1),
+			ymin: math.Inf(1), ymax: math.Inf(-1),
+		}
+		for j := range prim.X {
+			got.xmin, got.xmax = math.Min(got.xmin, prim.X[j]), math.Max(got.xmax, prim.X[j])
+			got.ymin, got.ymax = math.Min(got.ymin, prim.Y[j]), math.Max(got.ymax, prim.Y[j])
+		}
+		for _, b := range [][2]float64{
+			{got.xmin, w.xmin}, {got.xmax, w.xmax},
+			{got.ymin, w.ymin}, {got.ymax, w.ymax},
+		} {
+			if !math.IsNaN(b[1]) && !(math.Abs(b[0]-b[1]) <= tol) {
+				t.Errorf("primitive %d spans [%g, %g] by [%g, %g], want [%g, %g] by [%g, %g]",
+					i, got.xmin, got.xmax, got.ymin, got.ymax, w.xmin, w.xmax, w.ymin, w.ymax)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"github.com/gonum/plot/plot"
+)
+
+// PolarXYs returns a copy of the points of data, whose
+// X values are angles in radians and whose Y values are
+// radii, converted to the X and Y data coordinates of the
+// polar coordinate system pl, so that plotters such as
+// Line and Scatter draw them in polar coordinates.  The
+// range of the radial axis of pl must be set first.
+//
+// A Line is drawn straight between the converted points,
+// so points that are far apart in angle should have
+// points between them to draw a curve.
+func PolarXYs(pl *plot.Polar, data XYer) (XYs, error) {
+	xys, err := CopyXYs(data)
+	if err != nil {
+		return nil, err
+	}
+	for i := range xys {
+		xys[i].X, xys[i].Y = pl.XY(xys[i].X, xys[i].Y)
+	}
+	return xys, nil
+}
@@ -0,0 +1,182 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// Polygon implements the Plotter interface, drawing a
+// filled polygon that is bounded by one or more closed
+// rings of vertices.
+//
+// The polygon is filled with the even-odd rule: a point
+// is inside of the polygon if it is inside of an odd
+// number of the rings, so a ring within another ring
+// makes a hole.  The rings must not cross each other.
+type Polygon struct {
+	// XYs is a copy of the vertices of each ring.
+	// The last vertex of a ring is joined to the
+	// first.
+	XYs []XYs
+
+	// LineStyle is the style of the outline of each
+	// ring.  If the width is zero then no outline
+	// is drawn.
+	plot.LineStyle
+
+	// FillColor is the color used to fill the
+	// polygon.  If FillColor is nil then the
+	// polygon is not filled.
+	FillColor color.Color
+}
+
+// NewPolygon returns a Polygon with the given rings,
+// filled with gray and outlined using the default
+// line style.
+func NewPolygon(rings ...XYer) (*Polygon, error) {
+	if len(rings) == 0 {
+		return nil, errors.New("No rings")
+	}
+	p := &Polygon{
+		XYs:       make([]XYs, len(rings)),
+		LineStyle: DefaultLineStyle,
+		FillColor: color.Gray{Y: 192},
+	}
+	for i, r := range rings {
+		data, err := CopyXYs(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) < 3 {
+			return nil, errors.New("Ring has fewer than three vertices")
+		}
+		p.XYs[i] = data
+	}
+	return p, nil
+}
+
+// Plot draws the Polygon, implementing the plot.Plotter
+// interface.
+func (pg *Polygon) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	rings := make([][]plot.Point, len(pg.XYs))
+	for i, r := range pg.XYs {
+		rings[i] = make([]plot.Point, len(r))
+		for j, p := range r {
+			rings[i][j] = plot.Pt(trX(p.X), trY(p.Y))
+		}
+	}
+
+	if pg.FillColor != nil {
+		var path vg.Path
+		for _, r := range orientRings(rings) {
+			r = da.ClipPolygonXY(r)
+			if len(r) == 0 {
+				continue
+			}
+			path.Move(r[0].X, r[0].Y)
+			for _, p := range r[1:] {
+				path.Line(p.X, p.Y)
+			}
+			path.Close()
+		}
+		da.SetColor(pg.FillColor)
+		da.Fill(path)
+	}
+
+	if pg.Width > 0 {
+		for _, r := range rings {
+			closed := append(r[:len(r):len(r)], r[0])
+			da.StrokeLines(pg.LineStyle, da.ClipLinesXY(closed)...)
+		}
+	}
+}
+
+// orientRings returns the rings ordered so that each
+// ring winds counter-clockwise if it is within an even
+// number of the other rings, and clockwise otherwise.
+// A path of the oriented rings has the same interior
+// under the non-zero winding rule, used by some
+// canvases, as the rings have under the even-odd rule.
+func orientRings(rings [][]plot.Point) [][]plot.Point {
+	oriented := make([][]plot.Point, len(rings))
+	for i, r := range rings {
+		depth := 0
+		for j, o := range rings {
+			if j != i && inRing(r[0], o) {
+				depth++
+			}
+		}
+		if (signedArea(r) < 0) == (depth%2 == 0) {
+			r = reversed(r)
+		}
+		oriented[i] = r
+	}
+	return oriented
+}
+
+// signedArea returns the area of a ring, which is
+// positive if the ring winds counter-clockwise.
+func signedArea(r []plot.Point) vg.Length {
+	var a vg.Length
+	for i, p := range r {
+		q := r[(i+1)%len(r)]
+		a += p.X*q.Y - q.X*p.Y
+	}
+	return a / 2
+}
+
+// inRing returns true if the point is inside
+// of the ring, by the even-odd rule.
+func inRing(pt plot.Point, r []plot.Point) bool {
+	in := false
+	for i, a := range r {
+		b := r[(i+1)%len(r)]
+		if (a.Y > pt.Y) != (b.Y > pt.Y) &&
+			pt.X < a.X+(pt.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			in = !in
+		}
+	}
+	return in
+}
+
+// reversed returns a copy of the points in
+// reverse order.
+func reversed(pts []plot.Point) []plot.Point {
+	r := make([]plot.Point, len(pts))
+	for i, p := range pts {
+		r[len(pts)-1-i] = p
+	}
+	return r
+}
+
+// DataRange returns the minimum and maximum x and
+// y values of all of the vertices, implementing the
+// plot.DataRanger interface.
+func (pg *Polygon) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for _, r := range pg.XYs {
+		x0, x1, y0, y1 := XYRange(r)
+		xmin, xmax = math.Min(xmin, x0), math.Max(xmax, x1)
+		ymin, ymax = math.Min(ymin, y0), math.Max(ymax, y1)
+	}
+	return
+}
+
+// Thumbnail draws a filled and outlined rectangle,
+// implementing the plot.Thumbnailer interface.
+func (pg *Polygon) Thumbnail(da *plot.DrawArea) {
+	pts := fillThumbnail(da, pg.FillColor)
+	if pg.Width > 0 {
+		da.StrokeLines(pg.LineStyle, da.ClipLinesXY(append(pts, pts[0]))...)
+	}
+}
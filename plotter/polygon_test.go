@@ -0,0 +1,38 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+func TestOrientRings(t *testing.T) {
+	square := func(min, max float64, ccw bool) []plot.Point {
+		lo, hi := vg.Length(min), vg.Length(max)
+		r := []plot.Point{{lo, lo}, {hi, lo}, {hi, hi}, {lo, hi}}
+		if !ccw {
+			r = reversed(r)
+		}
+		return r
+	}
+	// An outer ring, a hole in it, an island in
+	// the hole, and a separate ring, all wound
+	// clockwise.
+	rings := [][]plot.Point{
+		square(0, 10, false),
+		square(2, 8, false),
+		square(4, 6, false),
+		square(20, 30, false),
+	}
+	want := []bool{true, false, true, true}
+	for i, r := range orientRings(rings) {
+		if ccw := signedArea(r) > 0; ccw != want[i] {
+			t.Errorf("ring %d: counter-clockwise is %t, want %t", i, ccw, want[i])
+		}
+	}
+}
@@ -88,11 +88,12 @@ func (b *QuartPlot) Plot(da plot.DrawArea, plt *plot.Plot) {
 	aLow := trY(b.AdjLow)
 	aHigh := trY(b.AdjHigh)
 
-	da.StrokeLine2(b.WhiskerStyle, x, aHigh, x, q3)
+	whisks := da.ClipLinesY([]plot.Point{{x, aHigh}, {x, q3}},
+		[]plot.Point{{x, aLow}, {x, q1}})
+	da.StrokeLines(b.WhiskerStyle, whisks...)
 	if da.ContainsY(med.Y) {
 		da.DrawGlyphNoClip(b.MedianStyle, med)
 	}
-	da.StrokeLine2(b.WhiskerStyle, x, aLow, x, q1)
 
 	ostyle := b.MedianStyle
 	ostyle.Radius = b.MedianStyle.Radius / 2
@@ -193,11 +194,12 @@ func (b HorizQuartPlot) Plot(da plot.DrawArea, plt *plot.Plot) {
 	aLow := trX(b.AdjLow)
 	aHigh := trX(b.AdjHigh)
 
-	da.StrokeLine2(b.WhiskerStyle, aHigh, y, q3, y)
+	whisks := da.ClipLinesX([]plot.Point{{aHigh, y}, {q3, y}},
+		[]plot.Point{{aLow, y}, {q1, y}})
+	da.StrokeLines(b.WhiskerStyle, whisks...)
 	if da.ContainsX(med.X) {
 		da.DrawGlyphNoClip(b.MedianStyle, med)
 	}
-	da.StrokeLine2(b.WhiskerStyle, aLow, y, q1, y)
 
 	ostyle := b.MedianStyle
 	ostyle.Radius = b.MedianStyle.Radius / 2
@@ -0,0 +1,284 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// DefaultArrowHeadLength is the default length of
+// the head of an arrow.
+var DefaultArrowHeadLength = vg.Points(4)
+
+// VectorField wraps the Len, XY and Vector methods.
+type VectorField interface {
+	// Len returns the number of vectors.
+	Len() int
+
+	// XY returns the location of a vector.
+	XY(int) (x, y float64)
+
+	// Vector returns the u and v components
+	// of a vector.
+	Vector(int) (u, v float64)
+}
+
+// Vectors implements the VectorField interface.
+type Vectors []struct{ X, Y, U, V float64 }
+
+// CopyVectors returns a Vectors that is a copy of the
+// locations and components of the vectors of a
+// VectorField, or an error if there are no vectors or
+// if one of the values is NaN or Infinity.
+func CopyVectors(vf VectorField) (Vectors, error) {
+	if vf.Len() == 0 {
+		return nil, ErrNoData
+	}
+	cpy := make(Vectors, vf.Len())
+	for i := range cpy {
+		cpy[i].X, cpy[i].Y = vf.XY(i)
+		cpy[i].U, cpy[i].V = vf.Vector(i)
+		if err := CheckFloats(cpy[i].X, cpy[i].Y, cpy[i].U, cpy[i].V); err != nil {
+			return nil, err
+		}
+	}
+	return cpy, nil
+}
+
+// Len implements the Len method of the VectorField interface.
+func (vs Vectors) Len() int {
+	return len(vs)
+}
+
+// XY implements the XY method of the VectorField interface.
+func (vs Vectors) XY(i int) (float64, float64) {
+	return vs[i].X, vs[i].Y
+}
+
+// Vector implements the Vector method of the VectorField interface.
+func (vs Vectors) Vector(i int) (float64, float64) {
+	return vs[i].U, vs[i].V
+}
+
+// Quiver implements the Plotter interface, drawing
+// an arrow for each vector of a vector field.  Each
+// arrow starts at the location of its vector and
+// points in the direction of the vector's u and v
+// components, given in the drawing coordinates of
+// the plot.
+type Quiver struct {
+	// Vectors is a copy of the vectors of the field.
+	Vectors
+
+	// LineStyle is the style of the arrows.
+	plot.LineStyle
+
+	// MaxLength is the length of the arrow for the
+	// vector with the greatest magnitude.  The lengths
+	// of the other arrows are scaled linearly by their
+	// magnitude.  If MaxLength is zero then it is
+	// chosen when drawing so that arrows located
+	// on a regular grid do not overlap.
+	MaxLength vg.Length
+
+	// HeadLength is the length of the arrow heads.
+	// Arrow heads are never more than half of the
+	// length of their arrow.
+	HeadLength vg.Length
+
+	// Colors, if non-empty, is used to color each
+	// arrow by the magnitude of its vector.  The
+	// smallest magnitude is given the first color
+	// and the largest is given the last color.
+	Colors []color.Color
+}
+
+// NewQuiver returns a Quiver that uses the default line
+// style and automatic arrow scaling.
+func NewQuiver(vf VectorField) (*Quiver, error) {
+	vs, err := CopyVectors(vf)
+	if err != nil {
+		return nil, err
+	}
+	return &Quiver{
+		Vectors:    vs,
+		LineStyle:  DefaultLineStyle,
+		HeadLength: DefaultArrowHeadLength,
+	}, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (q *Quiver) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	minMag, maxMag := q.magnitudeRange()
+
+	maxLen := q.MaxLength
+	if maxLen == 0 {
+		maxLen = q.gridLength(trX, trY)
+	}
+
+	for _, v := range q.Vectors {
+		tail := plot.Pt(trX(v.X), trY(v.Y))
+		if !da.Contains(tail) {
+			continue
+		}
+		mag := math.Hypot(v.U, v.V)
+		if mag == 0 || maxMag == 0 {
+			continue
+		}
+		l := maxLen * vg.Length(mag/maxMag)
+		dx := l * vg.Length(v.U/mag)
+		dy := l * vg.Length(v.V/mag)
+
+		sty := q.LineStyle
+		if len(q.Colors) > 0 {
+			sty.Color = q.color(mag, minMag, maxMag)
+		}
+		drawArrow(&da, sty, tail, plot.Pt(tail.X+dx, tail.Y+dy), q.HeadLength)
+	}
+}
+
+// color returns the color for a vector of the given magnitude.
+func (q *Quiver) color(mag, min, max float64) color.Color {
+	if max == min {
+		return q.Colors[len(q.Colors)-1]
+	}
+	i := int((mag - min) / (max - min) * float64(len(q.Colors)))
+	if i >= len(q.Colors) {
+		i = len(q.Colors) - 1
+	}
+	return q.Colors[i]
+}
+
+// magnitudeRange returns the minimum and maximum
+// magnitudes of the vectors.
+func (q *Quiver) magnitudeRange() (min, max float64) {
+	min = math.Inf(1)
+	max = math.Inf(-1)
+	for _, v := range q.Vectors {
+		mag := math.Hypot(v.U, v.V)
+		min = math.Min(min, mag)
+		max = math.Max(max, mag)
+	}
+	return
+}
+
+// gridLength returns an arrow length that is slightly
+// shorter than the smallest distance between distinct
+// X or Y locations of the vectors, in drawing coordinates.
+func (q *Quiver) gridLength(trX, trY func(float64) vg.Length) vg.Length {
+	xs := make([]float64, len(q.Vectors))
+	ys := make([]float64, len(q.Vectors))
+	for i, v := range q.Vectors {
+		xs[i] = float64(trX(v.X))
+		ys[i] = float64(trY(v.Y))
+	}
+	step := math.Min(minStep(xs), minStep(ys))
+	if math.IsInf(step, 1) {
+		return DefaultArrowHeadLength * 4
+	}
+	return vg.Length(0.9 * step)
+}
+
+// minStep returns the smallest non-zero difference
+// between any two of the values, or positive
+// infinity if all values are equal.  The values
+// are sorted in place.
+func minStep(vs []float64) float64 {
+	sort.Float64s(vs)
+	step := math.Inf(1)
+	for i := 1; i < len(vs); i++ {
+		if d := vs[i] - vs[i-1]; d > slop {
+			step = math.Min(step, d)
+		}
+	}
+	return step
+}
+
+// slop is the tolerance used when comparing
+// drawing coordinates.
+const slop = 1e-6
+
+// DataRange implements the plot.DataRanger interface.
+func (q *Quiver) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(q)
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+// If MaxLength is zero then the arrow lengths are not
+// known until drawing, and no boxes are returned.
+func (q *Quiver) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if q.MaxLength == 0 {
+		return nil
+	}
+	_, maxMag := q.magnitudeRange()
+	if maxMag == 0 {
+		return nil
+	}
+	bs := make([]plot.GlyphBox, len(q.Vectors))
+	for i, v := range q.Vectors {
+		dx := q.MaxLength * vg.Length(v.U/maxMag)
+		dy := q.MaxLength * vg.Length(v.V/maxMag)
+		bs[i].X = plt.X.Norm(v.X)
+		bs[i].Y = plt.Y.Norm(v.Y)
+		bs[i].Rect = plot.Rect{
+			Min:  plot.Pt(vg.Length(math.Min(0, float64(dx))), vg.Length(math.Min(0, float64(dy)))),
+			Size: plot.Pt(vg.Length(math.Abs(float64(dx))), vg.Length(math.Abs(float64(dy)))),
+		}
+	}
+	return bs
+}
+
+// Thumbnail draws an arrow, implementing the
+// plot.Thumbnailer interface.
+func (q *Quiver) Thumbnail(da *plot.DrawArea) {
+	y := da.Center().Y
+	sty := q.LineStyle
+	if len(q.Colors) > 0 {
+		sty.Color = q.Colors[len(q.Colors)-1]
+	}
+	drawArrow(da, sty, plot.Pt(da.Min.X, y), plot.Pt(da.Max().X, y), q.HeadLength)
+}
+
+// drawArrow draws an arrow from tail to tip with
+// a filled head of at most the given length.
+func drawArrow(da *plot.DrawArea, sty plot.LineStyle, tail, tip plot.Point, head vg.Length) {
+	dx, dy := tip.X-tail.X, tip.Y-tail.Y
+	l := vg.Length(math.Hypot(float64(dx), float64(dy)))
+	if l == 0 {
+		return
+	}
+	if head > l/2 {
+		head = l / 2
+	}
+	base := plot.Pt(tip.X-dx/l*head, tip.Y-dy/l*head)
+	da.StrokeLines(sty, da.ClipLinesXY([]plot.Point{tail, base})...)
+	drawArrowHead(da, sty.Color, tail, tip, head)
+}
+
+// drawArrowHead draws a filled arrow head of the
+// given length at tip, pointing in the direction
+// from the point from to tip.
+func drawArrowHead(da *plot.DrawArea, clr color.Color, from, tip plot.Point, head vg.Length) {
+	dx, dy := tip.X-from.X, tip.Y-from.Y
+	l := vg.Length(math.Hypot(float64(dx), float64(dy)))
+	if l == 0 {
+		return
+	}
+	ux, uy := dx/l, dy/l
+
+	// The head is an isoceles triangle whose base
+	// is at distance head from the tip.
+	const halfWidth = 0.35
+	base := plot.Pt(tip.X-ux*head, tip.Y-uy*head)
+	left := plot.Pt(base.X-uy*head*halfWidth, base.Y+ux*head*halfWidth)
+	right := plot.Pt(base.X+uy*head*halfWidth, base.Y-ux*head*halfWidth)
+	da.FillPolygon(clr, da.ClipPolygonXY([]plot.Point{tip, left, right}))
+}
@@ -0,0 +1,224 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// Radar implements the Plotter interface, drawing a
+// radar, or spider, chart: a spoke for each of a set of
+// named axes, evenly spaced clockwise from the top, and
+// each series of values as a closed polygon with a
+// vertex on each spoke.  All of the spokes share one
+// scale, from Min at the center to Max at the end of
+// the spoke, which is labeled with the axis name.
+//
+// The chart is drawn in the largest circle that fits
+// in the data area with the labels around it, so it
+// does not use the axes of the plot, which should be
+// hidden with the plot's HideAxes method.
+type Radar struct {
+	// Axes are the names of the spokes.
+	Axes []string
+
+	// Series are the series of values drawn on
+	// the spokes, in the order in which they
+	// are drawn.
+	Series []*RadarSeries
+
+	// Min and Max are the values at the center
+	// and at the ends of the spokes.  Values
+	// outside of the range are drawn at the
+	// nearest end of their spoke.
+	Min, Max float64
+
+	// Rings is the number of rings of the grid,
+	// evenly spaced between the center and the
+	// ends of the spokes.
+	Rings int
+
+	// GridStyle is the style of the spokes and
+	// the rings.
+	GridStyle plot.LineStyle
+
+	// TextStyle is the style of the axis labels
+	// and of the values of the rings, which are
+	// drawn beside the first spoke.
+	TextStyle plot.TextStyle
+}
+
+// A RadarSeries is a series of values of a Radar.  It
+// implements the plot.Thumbnailer interface, so that it
+// can be given an entry in the legend.
+type RadarSeries struct {
+	// Values are the values of the series,
+	// one for each axis of its Radar.
+	Values []float64
+
+	// LineStyle is the style of the outline of
+	// the series' polygon.
+	plot.LineStyle
+
+	// FillColor, if non-nil, fills the series'
+	// polygon.  A translucent color keeps the
+	// series beneath it visible.
+	FillColor color.Color
+}
+
+// radarLabelGap is the distance between the end of
+// a spoke and its label.
+var radarLabelGap = vg.Points(4)
+
+// NewRadar returns a Radar with the given axes and
+// no series.  Its Min is zero and its Max is zero
+// until series are added.
+func NewRadar(axes ...string) (*Radar, error) {
+	if len(axes) < 3 {
+		return nil, errors.New("Radar needs at least three axes")
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Radar{
+		Axes:      append([]string(nil), axes...),
+		Rings:     4,
+		GridStyle: DefaultGridLineStyle,
+		TextStyle: plot.TextStyle{Color: color.Black, Font: fnt},
+	}, nil
+}
+
+// Add adds a series of values, one for each axis, to
+// the Radar with the default line style and no fill,
+// and returns it.  Max is raised to the largest of the
+// values if it is smaller.
+func (r *Radar) Add(values ...float64) (*RadarSeries, error) {
+	if len(values) != len(r.Axes) {
+		return nil, errors.New("Radar series needs one value for each axis")
+	}
+	if err := CheckFloats(values...); err != nil {
+		return nil, err
+	}
+	s := &RadarSeries{
+		Values:    append([]float64(nil), values...),
+		LineStyle: DefaultLineStyle,
+	}
+	for _, v := range values {
+		r.Max = math.Max(r.Max, v)
+	}
+	r.Series = append(r.Series, s)
+	return s, nil
+}
+
+// spoke returns the unit vector of the ith spoke.
+func (r *Radar) spoke(i int) (x, y float64) {
+	theta := math.Pi/2 - 2*math.Pi*float64(i)/float64(len(r.Axes))
+	return math.Cos(theta), math.Sin(theta)
+}
+
+// fraction returns the distance along a spoke,
+// from 0 at the center to 1 at its end, of a value.
+func (r *Radar) fraction(v float64) float64 {
+	if r.Max <= r.Min {
+		return 0
+	}
+	return math.Max(0, math.Min(1, (v-r.Min)/(r.Max-r.Min)))
+}
+
+// layout returns the center of the chart in the draw
+// area and the length of its spokes, which leaves room
+// for the labels at their ends.
+func (r *Radar) layout(da plot.DrawArea) (plot.Point, vg.Length) {
+	var w vg.Length
+	for _, name := range r.Axes {
+		if lw := r.TextStyle.Width(name); lw > w {
+			w = lw
+		}
+	}
+	h := r.TextStyle.Height(r.Axes[0])
+	rad := da.Size.X/2 - w - radarLabelGap
+	if ry := da.Size.Y/2 - h - radarLabelGap; ry < rad {
+		rad = ry
+	}
+	if rad < 0 {
+		rad = 0
+	}
+	return da.Center(), rad
+}
+
+// vertices returns the ends of the spokes at the
+// given fractions of their lengths.
+func (r *Radar) vertices(c plot.Point, rad vg.Length, fracs func(i int) float64) []plot.Point {
+	pts := make([]plot.Point, len(r.Axes))
+	for i := range pts {
+		x, y := r.spoke(i)
+		l := rad * vg.Length(fracs(i))
+		pts[i] = plot.Pt(c.X+l*vg.Length(x), c.Y+l*vg.Length(y))
+	}
+	return pts
+}
+
+// Plot draws the grid, the series and the labels,
+// implementing the plot.Plotter interface.
+func (r *Radar) Plot(da plot.DrawArea, plt *plot.Plot) {
+	c, rad := r.layout(da)
+	ends := r.vertices(c, rad, func(int) float64 { return 1 })
+
+	for _, end := range ends {
+		da.StrokeLine2(r.GridStyle, c.X, c.Y, end.X, end.Y)
+	}
+	for k := 1; k <= r.Rings; k++ {
+		f := float64(k) / float64(r.Rings)
+		ring := r.vertices(c, rad, func(int) float64 { return f })
+		da.StrokeLines(r.GridStyle, append(ring, ring[0]))
+
+		v := r.Min + f*(r.Max-r.Min)
+		da.FillText(r.TextStyle, ring[0].X+radarLabelGap/2, ring[0].Y, 0, -0.5, plot.FormatFloat(v, 'g', 4, 64))
+	}
+
+	for _, s := range r.Series {
+		pts := r.vertices(c, rad, func(i int) float64 { return r.fraction(s.Values[i]) })
+		if s.FillColor != nil {
+			da.FillPolygon(s.FillColor, pts)
+		}
+		da.StrokeLines(s.LineStyle, append(pts, pts[0]))
+	}
+
+	for i, name := range r.Axes {
+		x, y := r.spoke(i)
+		gap := rad + radarLabelGap
+		da.FillText(r.TextStyle,
+			c.X+gap*vg.Length(x), c.Y+gap*vg.Length(y),
+			labelAlign(x), labelAlign(y), name)
+	}
+}
+
+// labelAlign returns the alignment along one
+// dimension of a label at the end of a spoke whose
+// unit vector has the given component, so that labels
+// extend away from the center.
+func labelAlign(d float64) float64 {
+	switch {
+	case d > 0.1:
+		return 0
+	case d < -0.1:
+		return -1
+	}
+	return -0.5
+}
+
+// Thumbnail draws a rectangle filled with the fill
+// color, if any, and outlined with the line style,
+// implementing the plot.Thumbnailer interface.
+func (s *RadarSeries) Thumbnail(da *plot.DrawArea) {
+	pts := fillThumbnail(da, s.FillColor)
+	da.StrokeLines(s.LineStyle, append(pts, pts[0]))
+}
@@ -0,0 +1,39 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRadar(t *testing.T) {
+	r, err := NewRadar("a", "b", "c", "d")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := r.Add(1, 2, 3); err == nil {
+		t.Errorf("expected an error for a series with too few values")
+	}
+	if _, err := r.Add(1, 2, 4, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Max != 4 {
+		t.Errorf("Max is %g, want 4", r.Max)
+	}
+
+	// The spokes run clockwise from the top.
+	for i, want := range [][2]float64{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+		x, y := r.spoke(i)
+		if math.Abs(x-want[0]) > 1e-12 || math.Abs(y-want[1]) > 1e-12 {
+			t.Errorf("spoke %d is (%g, %g), want %v", i, x, y, want)
+		}
+	}
+	for v, want := range map[float64]float64{-1: 0, 0: 0, 1: 0.25, 4: 1, 5: 1} {
+		if got := r.fraction(v); got != want {
+			t.Errorf("fraction of %g is %g, want %g", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"github.com/gonum/plot/plot"
+)
+
+// RefLine implements the Plotter interface, drawing
+// a reference line, such as a zero line or a
+// threshold, across the whole width or height of the
+// data area.
+//
+// RefLine does not implement plot.DataRanger, so it
+// does not change the ranges of the axes.  A line at
+// a value outside of the axis range is not drawn.
+type RefLine struct {
+	// Value is the Y value of a horizontal line
+	// or the X value of a vertical line.
+	Value float64
+
+	// Vertical is true for a vertical line.
+	Vertical bool
+
+	// LineStyle is the style of the line.
+	plot.LineStyle
+}
+
+// HLine returns a horizontal RefLine at the
+// given Y value.
+func HLine(y float64, sty plot.LineStyle) *RefLine {
+	return &RefLine{Value: y, LineStyle: sty}
+}
+
+// VLine returns a vertical RefLine at the
+// given X value.
+func VLine(x float64, sty plot.LineStyle) *RefLine {
+	return &RefLine{Value: x, Vertical: true, LineStyle: sty}
+}
+
+// Plot implements the Plotter interface, drawing
+// the line between the edges of the draw area.
+func (r *RefLine) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	if r.Vertical {
+		x := trX(r.Value)
+		if da.ContainsX(x) {
+			da.StrokeLine2(r.LineStyle, x, da.Min.Y, x, da.Max().Y)
+		}
+		return
+	}
+	y := trY(r.Value)
+	if da.ContainsY(y) {
+		da.StrokeLine2(r.LineStyle, da.Min.X, y, da.Max().X, y)
+	}
+}
+
+// Thumbnail draws a line in the direction of the
+// RefLine through the center of the draw area,
+// implementing the plot.Thumbnailer interface.
+func (r *RefLine) Thumbnail(da *plot.DrawArea) {
+	c := da.Center()
+	if r.Vertical {
+		da.StrokeLine2(r.LineStyle, c.X, da.Min.Y, c.X, da.Max().Y)
+		return
+	}
+	da.StrokeLine2(r.LineStyle, da.Min.X, c.Y, da.Max().X, c.Y)
+}
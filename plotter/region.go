@@ -0,0 +1,134 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// Region implements the Plotter interface, drawing a
+// rectangle given in data coordinates, such as to shade
+// a window of time or a range of acceptable values.  An
+// infinite bound, or a bound beyond the range of its
+// axis, extends the rectangle to the edge of the data
+// area, so a region with infinite Y bounds is a
+// vertical band across the whole plot.
+//
+// By default the region does not change the ranges of
+// the axes; see Fit.
+type Region struct {
+	// XMin, XMax, YMin and YMax are the bounds
+	// of the rectangle.
+	XMin, XMax, YMin, YMax float64
+
+	// FillColor, if non-nil, fills the rectangle.
+	FillColor color.Color
+
+	// LineStyle is the style of the outline of
+	// the rectangle.  If its width is zero then
+	// no outline is drawn.
+	plot.LineStyle
+
+	// Fit, if true, makes the ranges of the axes
+	// include the finite bounds of the region.
+	Fit bool
+}
+
+// NewRegion returns a Region with the given bounds,
+// filled with translucent gray and not outlined.
+func NewRegion(xmin, xmax, ymin, ymax float64) (*Region, error) {
+	for _, v := range []float64{xmin, xmax, ymin, ymax} {
+		if math.IsNaN(v) {
+			return nil, errors.New("Region bound is NaN")
+		}
+	}
+	if xmin > xmax || ymin > ymax {
+		return nil, errors.New("Region minimum is greater than its maximum")
+	}
+	return &Region{
+		XMin:      xmin,
+		XMax:      xmax,
+		YMin:      ymin,
+		YMax:      ymax,
+		FillColor: color.NRGBA{R: 128, G: 128, B: 128, A: 64},
+	}, nil
+}
+
+// regionEdge returns the place in the draw area of
+// a region bound on an axis, which is the matching
+// edge of the draw area if the bound is outside of the
+// range of the axis, so that the bound is never passed
+// to the axis scale outside of its domain.
+func regionEdge(v float64, a *plot.Axis, min, max vg.Length, tr func(float64) vg.Length) vg.Length {
+	switch {
+	case v <= a.Min:
+		return min
+	case v >= a.Max:
+		return max
+	}
+	return tr(v)
+}
+
+// Plot draws the rectangle, implementing the
+// plot.Plotter interface.
+func (r *Region) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	xmin := regionEdge(r.XMin, &plt.X, da.Min.X, da.Max().X, trX)
+	xmax := regionEdge(r.XMax, &plt.X, da.Min.X, da.Max().X, trX)
+	ymin := regionEdge(r.YMin, &plt.Y, da.Min.Y, da.Max().Y, trY)
+	ymax := regionEdge(r.YMax, &plt.Y, da.Min.Y, da.Max().Y, trY)
+	if xmin == xmax || ymin == ymax {
+		return
+	}
+	pts := []plot.Point{{xmin, ymin}, {xmax, ymin}, {xmax, ymax}, {xmin, ymax}}
+	if r.FillColor != nil {
+		da.FillPolygon(r.FillColor, pts)
+	}
+	if r.Width > 0 {
+		da.StrokeLines(r.LineStyle, append(pts, pts[0]))
+	}
+}
+
+// DataRange returns the finite bounds of the region
+// if Fit is true, implementing the plot.DataRanger
+// interface.  Otherwise, and for infinite bounds, it
+// returns infinities that do not change the ranges
+// of the axes.
+func (r *Region) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	if !r.Fit {
+		return
+	}
+	finite := func(v float64) bool { return !math.IsInf(v, 0) }
+	if finite(r.XMin) {
+		xmin = r.XMin
+	}
+	if finite(r.XMax) {
+		xmax = r.XMax
+	}
+	if finite(r.YMin) {
+		ymin = r.YMin
+	}
+	if finite(r.YMax) {
+		ymax = r.YMax
+	}
+	return
+}
+
+// Thumbnail fills the draw area with the fill color
+// and outlines it, implementing the plot.Thumbnailer
+// interface.
+func (r *Region) Thumbnail(da *plot.DrawArea) {
+	pts := fillThumbnail(da, r.FillColor)
+	if r.Width > 0 {
+		da.StrokeLines(r.LineStyle, append(pts, pts[0]))
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+func TestRegion(t *testing.T) {
+	l, err := NewLine(XYs{{0, 1}, {10, 10}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err := NewRegion(2, 5, math.Inf(-1), math.Inf(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Y.Scale = plot.LogScale
+	p.Add(l, r)
+	if p.X.Min != 0 || p.X.Max != 10 {
+		t.Errorf("X range is [%g, %g], want the range of the line", p.X.Min, p.X.Max)
+	}
+
+	prims, err := p.Trace(vg.Inches(4), vg.Inches(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var fills int
+	for _, prim := range prims {
+		if prim.Kind != plot.TracedFill || prim.Plotter != r {
+			continue
+		}
+		fills++
+		wantX := []float64{2, 5, 5, 2}
+		wantY := []float64{1, 1, 10, 10}
+		for i := range wantX {
+			if math.Abs(prim.X[i]-wantX[i]) > 1e-6 || math.Abs(prim.Y[i]-wantY[i]) > 1e-6 {
+				t.Errorf("region corners are %v, %v, want %v, %v", prim.X, prim.Y, wantX, wantY)
+				break
+			}
+		}
+	}
+	if fills != 1 {
+		t.Errorf("region drew %d fills, want 1", fills)
+	}
+}
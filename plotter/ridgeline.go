@@ -0,0 +1,135 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// Ridgeline implements the Plotter interface, drawing
+// several traces, such as the densities of several
+// samples, one above another on rows that overlap so
+// that the traces can be compared while each stays
+// readable.  The baseline of trace i is at Y value i,
+// so that the rows can be named with plot.NominalY.
+//
+// The traces are drawn from the top row down, so each
+// trace, when it is filled, hides the parts of the
+// traces above it that it overlaps.
+type Ridgeline struct {
+	// Traces are copies of the points of the
+	// traces.  The Y values are heights above
+	// the baseline of each trace's row.
+	Traces []XYs
+
+	// Overlap is the height, in rows, at which
+	// the greatest Y value of all of the traces
+	// is drawn.  Heights greater than one make
+	// the traces overlap the rows above them.
+	Overlap float64
+
+	// FillColors are the colors that fill the
+	// traces down to their baselines, with trace i
+	// filled with FillColors[i%len(FillColors)].
+	// If FillColors is empty then the traces are
+	// not filled.
+	FillColors []color.Color
+
+	// LineStyle is the style of the traces.
+	plot.LineStyle
+}
+
+// NewRidgeline returns a Ridgeline of the traces with
+// an overlap of 1.5, white fills and the default line
+// style.
+func NewRidgeline(traces ...XYer) (*Ridgeline, error) {
+	if len(traces) == 0 {
+		return nil, ErrNoData
+	}
+	r := &Ridgeline{
+		Traces:     make([]XYs, len(traces)),
+		Overlap:    1.5,
+		FillColors: []color.Color{color.White},
+		LineStyle:  DefaultLineStyle,
+	}
+	for i, t := range traces {
+		var err error
+		if r.Traces[i], err = CopyXYs(t); err != nil {
+			return nil, err
+		}
+		if len(r.Traces[i]) == 0 {
+			return nil, errors.New("Ridgeline trace has no points")
+		}
+	}
+	return r, nil
+}
+
+// scale returns the factor that converts the Y
+// values of the traces to heights in rows.
+func (r *Ridgeline) scale() float64 {
+	max := 0.0
+	for _, t := range r.Traces {
+		_, ymax := Range(YValues{t})
+		max = math.Max(max, ymax)
+	}
+	if max == 0 {
+		return 0
+	}
+	return r.Overlap / max
+}
+
+// Plot draws the traces, implementing the
+// plot.Plotter interface.
+func (r *Ridgeline) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	scale := r.scale()
+	for i := len(r.Traces) - 1; i >= 0; i-- {
+		t := r.Traces[i]
+		base := float64(i)
+		ps := make([]plot.Point, len(t))
+		for j, p := range t {
+			ps[j] = plot.Pt(trX(p.X), trY(base+p.Y*scale))
+		}
+		if len(r.FillColors) > 0 {
+			fill := make([]plot.Point, 0, len(ps)+2)
+			fill = append(fill, plot.Pt(ps[0].X, trY(base)))
+			fill = append(fill, ps...)
+			fill = append(fill, plot.Pt(ps[len(ps)-1].X, trY(base)))
+			da.FillPolygon(r.FillColors[i%len(r.FillColors)], da.ClipPolygonXY(fill))
+		}
+		da.StrokeLines(r.LineStyle, da.ClipLinesXY(ps)...)
+	}
+}
+
+// DataRange returns the range of X values of the
+// traces and the range of rows that they are drawn
+// in, implementing the plot.DataRanger interface.
+func (r *Ridgeline) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = 0, float64(len(r.Traces)-1)
+	scale := r.scale()
+	for i, t := range r.Traces {
+		txmin, txmax, tymin, tymax := XYRange(t)
+		xmin, xmax = math.Min(xmin, txmin), math.Max(xmax, txmax)
+		ymin = math.Min(ymin, float64(i)+tymin*scale)
+		ymax = math.Max(ymax, float64(i)+tymax*scale)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// AutoColor sets the color of the traces if it is
+// the default color, implementing the
+// plot.AutoColorer interface.
+func (r *Ridgeline) AutoColor(c color.Color) bool {
+	if !unsetColor(r.LineStyle.Color) {
+		return false
+	}
+	r.LineStyle.Color = c
+	return true
+}
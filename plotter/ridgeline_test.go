@@ -0,0 +1,51 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"testing"
+
+	"github.com/gonum/plot/plot"
+)
+
+func TestRidgeline(t *testing.T) {
+	if _, err := NewRidgeline(XYs{}); err == nil {
+		t.Errorf("no error for an empty trace")
+	}
+
+	r, err := NewRidgeline(
+		XYs{{0, 0}, {1, 2}, {2, 0}},
+		XYs{{1, 0}, {2, 4}, {3, 0}},
+		XYs{{-1, 0}, {0, 1}, {1, 0}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.Overlap = 2
+	// The tallest peak, of 4, is drawn two rows high
+	// above the baseline of the second trace, at 1.
+	xmin, xmax, ymin, ymax := r.DataRange()
+	if xmin != -1 || xmax != 3 || ymin != 0 || ymax != 3 {
+		t.Errorf("data range = %v, %v, %v, %v, want -1, 3, 0, 3", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(r)
+	// The traces are filled down to their baselines
+	// and stroked, from the top row down, with their
+	// heights scaled by 2/4.
+	fill, line := r.FillColors[0], r.LineStyle.Color
+	checkTrace(t, p, []tracedBox{
+		{plot.TracedFill, fill, -1, 1, 2, 2.5},
+		{plot.TracedLine, line, -1, 1, 2, 2.5},
+		{plot.TracedFill, fill, 1, 3, 1, 3},
+		{plot.TracedLine, line, 1, 3, 1, 3},
+		{plot.TracedFill, fill, 0, 2, 0, 1},
+		{plot.TracedLine, line, 0, 2, 0, 1},
+	})
+}
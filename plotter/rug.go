@@ -0,0 +1,98 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// DefaultRugLength is the default length of rug marks.
+var DefaultRugLength = vg.Points(5)
+
+// Rug implements the Plotter interface, drawing a
+// short mark along an edge of the data area for each
+// value, showing the marginal distribution of the data.
+type Rug struct {
+	// Values is a copy of the values marked by the rug.
+	Values
+
+	// Vertical specifies that the values are Y values
+	// and that the rug is drawn along the left edge of
+	// the data area.  Otherwise the values are X values
+	// and the rug is drawn along the bottom edge.
+	Vertical bool
+
+	// Outside specifies that the marks extend from
+	// the edge of the data area toward the axis.
+	// Otherwise the marks extend into the data area.
+	Outside bool
+
+	// Length is the length of each mark.
+	Length vg.Length
+
+	// LineStyle is the style of the marks.
+	plot.LineStyle
+}
+
+// NewRug returns a Rug along the X axis that uses
+// the default line style and length.
+func NewRug(vs Valuer) (*Rug, error) {
+	values, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	return &Rug{
+		Values:    values,
+		Length:    DefaultRugLength,
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (r *Rug) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	length := r.Length
+	if r.Outside {
+		length = -length
+	}
+	for _, v := range r.Values {
+		if r.Vertical {
+			y := trY(v)
+			if !da.ContainsY(y) {
+				continue
+			}
+			da.StrokeLine2(r.LineStyle, da.Min.X, y, da.Min.X+length, y)
+			continue
+		}
+		x := trX(v)
+		if !da.ContainsX(x) {
+			continue
+		}
+		da.StrokeLine2(r.LineStyle, x, da.Min.Y, x, da.Min.Y+length)
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+// The range of the axis that the rug is not drawn along
+// is empty, so the rug does not change it.
+func (r *Rug) DataRange() (xmin, xmax, ymin, ymax float64) {
+	min, max := Range(r)
+	if r.Vertical {
+		return math.Inf(1), math.Inf(-1), min, max
+	}
+	return min, max, math.Inf(1), math.Inf(-1)
+}
+
+// Thumbnail draws a few marks, implementing the
+// plot.Thumbnailer interface.
+func (r *Rug) Thumbnail(da *plot.DrawArea) {
+	for _, f := range []float64{0.2, 0.35, 0.45, 0.7} {
+		x := da.X(f)
+		da.StrokeLine2(r.LineStyle, x, da.Min.Y, x, da.Max().Y)
+	}
+}
@@ -5,7 +5,12 @@
 package plotter
 
 import (
+	"image/color"
+	"math"
+	"math/rand"
+
 	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
 )
 
 // Scatter implements the Plotter interface, drawing
@@ -17,6 +22,38 @@ type Scatter struct {
 	// GlyphStyle is the style of the glyphs drawn
 	// at each point.
 	plot.GlyphStyle
+
+	// Jitter is the maximum distance, in X data
+	// coordinates, by which each point is offset
+	// horizontally when drawn.  Jittering spreads
+	// out points that share the same X value, such
+	// as points in a category of a strip plot.  If
+	// Jitter is zero then the points are not offset.
+	Jitter float64
+
+	// JitterSeed seeds the pseudo-random offsets
+	// used for jittering.  The same seed always
+	// gives the same offsets for the same data.
+	JitterSeed int64
+
+	// Tooltips, if non-nil, gives a title for the
+	// glyph of each point.  The titles are only
+	// drawn by canvases that implement vg.Titler,
+	// such as the SVG canvas, where they are
+	// shown as tooltips.
+	Tooltips []string
+
+	// Tooltip, if non-nil, returns the title for
+	// the glyph of the ith point, instead of
+	// Tooltips.
+	Tooltip func(i int) string
+
+	// Data, if non-nil, returns named values that
+	// are attached to the glyph of the ith point.
+	// They are only drawn by canvases that implement
+	// vg.Annotator, such as the SVG canvas, where
+	// they are data- attributes.
+	Data func(i int) map[string]string
 }
 
 // NewScatter returns a Scatter that uses the
@@ -36,24 +73,102 @@ func NewScatter(xys XYer) (*Scatter, error) {
 // interface.
 func (pts *Scatter) Plot(da plot.DrawArea, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&da)
-	for _, p := range pts.XYs {
-		da.DrawGlyph(pts.GlyphStyle, plot.Pt(trX(p.X), trY(p.Y)))
+	xoffs := pts.jitter()
+	annotator, _ := da.Canvas.(vg.Annotator)
+	titler, _ := da.Canvas.(vg.Titler)
+	for i, p := range pts.XYs {
+		pt := plot.Pt(trX(p.X+xoffs[i]), trY(p.Y))
+		a := pts.annotation(i)
+		switch {
+		case !da.Contains(pt) || a.Title == "" && a.Data == nil:
+			da.DrawGlyph(pts.GlyphStyle, pt)
+		case annotator != nil:
+			annotator.BeginAnnotation(a)
+			da.DrawGlyph(pts.GlyphStyle, pt)
+			annotator.EndAnnotation()
+		case titler != nil && a.Title != "":
+			titler.BeginTitle(a.Title)
+			da.DrawGlyph(pts.GlyphStyle, pt)
+			titler.EndTitle()
+		default:
+			da.DrawGlyph(pts.GlyphStyle, pt)
+		}
+	}
+}
+
+// annotation returns the annotation of the
+// glyph of the ith point.
+func (pts *Scatter) annotation(i int) vg.Annotation {
+	var a vg.Annotation
+	switch {
+	case pts.Tooltip != nil:
+		a.Title = pts.Tooltip(i)
+	case i < len(pts.Tooltips):
+		a.Title = pts.Tooltips[i]
+	}
+	if pts.Data != nil {
+		a.Data = pts.Data(i)
 	}
+	return a
+}
+
+// jitter returns the X offset of each point.
+func (pts *Scatter) jitter() []float64 {
+	offs := make([]float64, len(pts.XYs))
+	if pts.Jitter == 0 {
+		return offs
+	}
+	rnd := rand.New(rand.NewSource(pts.JitterSeed))
+	for i := range offs {
+		offs[i] = (2*rnd.Float64() - 1) * pts.Jitter
+	}
+	return offs
+}
+
+// AutoColor sets the color of the glyphs if it is
+// the default color, implementing the
+// plot.AutoColorer interface.
+func (pts *Scatter) AutoColor(c color.Color) bool {
+	if !unsetColor(pts.GlyphStyle.Color) {
+		return false
+	}
+	pts.GlyphStyle.Color = c
+	return true
+}
+
+// Clone returns a copy of the scatter, implementing
+// the plot.Cloner interface.
+func (pts *Scatter) Clone() plot.Plotter {
+	c := *pts
+	c.XYs = append(XYs(nil), pts.XYs...)
+	c.Tooltips = append([]string(nil), pts.Tooltips...)
+	c.Outline.Dashes = append([]vg.Length(nil), pts.Outline.Dashes...)
+	return &c
 }
 
 // DataRange returns the minimum and maximum
 // x and y values, implementing the plot.DataRanger
 // interface.
 func (pts *Scatter) DataRange() (xmin, xmax, ymin, ymax float64) {
-	return XYRange(pts)
+	if pts.Jitter == 0 {
+		return XYRange(pts)
+	}
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	for i, off := range pts.jitter() {
+		xmin = math.Min(xmin, pts.XYs[i].X+off)
+		xmax = math.Max(xmax, pts.XYs[i].X+off)
+	}
+	ymin, ymax = Range(YValues{pts})
+	return
 }
 
 // GlyphBoxes returns a slice of plot.GlyphBoxes,
 // implementing the plot.GlyphBoxer interface.
 func (pts *Scatter) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	bs := make([]plot.GlyphBox, len(pts.XYs))
+	xoffs := pts.jitter()
 	for i, p := range pts.XYs {
-		bs[i].X = plt.X.Norm(p.X)
+		bs[i].X = plt.X.Norm(p.X + xoffs[i])
 		bs[i].Y = plt.Y.Norm(p.Y)
 		bs[i].Rect = pts.GlyphStyle.Rect()
 	}
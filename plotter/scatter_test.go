@@ -0,0 +1,115 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+func TestScatterJitter(t *testing.T) {
+	xys := make(XYs, 100)
+	for i := range xys {
+		xys[i].X = float64(i % 3)
+		xys[i].Y = float64(i)
+	}
+	s, err := NewScatter(xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Jitter = 0.25
+	s.JitterSeed = 1
+
+	offs := s.jitter()
+	again := s.jitter()
+	xmin, xmax := math.Inf(1), math.Inf(-1)
+	for i, off := range offs {
+		if off != again[i] {
+			t.Errorf("jitter is not deterministic: %g != %g", off, again[i])
+		}
+		if math.Abs(off) > s.Jitter {
+			t.Errorf("jitter %g out of range ±%g", off, s.Jitter)
+		}
+		xmin = math.Min(xmin, xys[i].X+off)
+		xmax = math.Max(xmax, xys[i].X+off)
+	}
+
+	dxmin, dxmax, _, _ := s.DataRange()
+	if dxmin != xmin || dxmax != xmax {
+		t.Errorf("DataRange x = [%g, %g], want [%g, %g]", dxmin, dxmax, xmin, xmax)
+	}
+
+	s.Jitter = 0
+	for _, off := range s.jitter() {
+		if off != 0 {
+			t.Errorf("got non-zero offset %g without jitter", off)
+		}
+	}
+}
+
+func TestScatterTrace(t *testing.T) {
+	s, err := NewScatter(XYs{{1, 2}, {3, 4}, {5, 7}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(s)
+	prims, err := p.Trace(vg.Inches(4), vg.Inches(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var glyphs []plot.TracedPrimitive
+	for _, prim := range prims {
+		if prim.Kind == plot.TracedGlyph {
+			glyphs = append(glyphs, prim)
+		}
+	}
+	if len(glyphs) != 3 {
+		t.Fatalf("traced %d glyphs, want 3", len(glyphs))
+	}
+	// The traced coordinates are mapped back from
+	// the canvas, so they are not exact.
+	const tol = 1e-9
+	if g := glyphs[1]; math.Abs(g.X[0]-3) > tol || math.Abs(g.Y[0]-4) > tol || g.Plotter != plot.Plotter(s) {
+		t.Errorf("second glyph at (%v, %v), want (3, 4)", g.X[0], g.Y[0])
+	}
+}
+
+func TestScatterAnnotations(t *testing.T) {
+	s, err := NewScatter(XYs{{1, 2}, {3, 4}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Tooltip = func(i int) string { return []string{"a<b", "c"}[i] }
+	s.Data = func(i int) map[string]string {
+		return map[string]string{"y": "2", "x": "1"}
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(s)
+	var buf bytes.Buffer
+	if err := p.WriteHTML(&buf, vg.Inches(4), vg.Inches(3)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+	for _, want := range []string{
+		`<g data-x="1" data-y="2"><title>a&lt;b</title>`,
+		`<g data-x="1" data-y="2"><title>c</title>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG does not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// onY2 is a Plotter that is bound to the
+// secondary Y axis of its plot.
+type onY2 struct {
+	plot.Plotter
+}
+
+// OnY2 returns a Plotter that draws p through the
+// secondary Y axis of the plot to which it is added,
+// implementing the plot.AxisBinder interface.
+//
+// The returned Plotter implements plot.DataRanger,
+// plot.GlyphBoxer and plot.Thumbnailer by calling the
+// methods of p, if p implements them.
+func OnY2(p plot.Plotter) plot.Plotter {
+	return onY2{Plotter: p}
+}
+
+// YAxis implements the plot.AxisBinder interface.
+func (onY2) YAxis() plot.AxisID {
+	return plot.SecondaryAxis
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (o onY2) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if d, ok := o.Plotter.(plot.DataRanger); ok {
+		return d.DataRange()
+	}
+	return math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+func (o onY2) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if g, ok := o.Plotter.(plot.GlyphBoxer); ok {
+		return g.GlyphBoxes(plt)
+	}
+	return nil
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (o onY2) Thumbnail(da *plot.DrawArea) {
+	if t, ok := o.Plotter.(plot.Thumbnailer); ok {
+		t.Thumbnail(da)
+	}
+}
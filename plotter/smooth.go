@@ -0,0 +1,179 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// A SmoothKernel is a way of smoothing the
+// points of a Smooth.
+type SmoothKernel int
+
+const (
+	// MovingAverage replaces each Y value by the
+	// mean of the Y values in its window.
+	MovingAverage SmoothKernel = iota
+
+	// SavitzkyGolay replaces each Y value by the
+	// value at its X of the quadratic that best fits
+	// the points in its window in the least squares
+	// sense.  It follows peaks more closely than a
+	// moving average of the same window.
+	SavitzkyGolay
+)
+
+// Smooth implements the Plotter interface, drawing a
+// smoothed line through noisy data.  The smoothed line
+// is computed from the raw points each time that it is
+// drawn, so the window and kernel can be changed after
+// the Smooth is made.
+//
+// Each point is smoothed over a window that is centered
+// on it.  Near the ends of the data the window shrinks,
+// keeping it centered, to the points that are available:
+// the half width of the window of the ith of n points
+// is at most i and n-1-i.  The smoothed line does not lag
+// the data, and its end points are the raw end points.
+type Smooth struct {
+	// XYs is a copy of the raw points.  The X
+	// values should be in increasing order.
+	XYs
+
+	// Window is the number of points in the window
+	// of each smoothed point.  An even window is
+	// widened by one point so that it is centered.
+	Window int
+
+	// Kernel is the way that the points in each
+	// window are smoothed.
+	Kernel SmoothKernel
+
+	// LineStyle is the style of the smoothed line.
+	plot.LineStyle
+}
+
+// NewSmooth returns a Smooth that draws a moving
+// average of the points over the given window with
+// the default line style.
+func NewSmooth(xys XYer, window int) (*Smooth, error) {
+	if window < 1 {
+		return nil, errors.New("Window must be positive")
+	}
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &Smooth{
+		XYs:       data,
+		Window:    window,
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// Smoothed returns the smoothed points, which have the
+// X values of the raw points.
+func (s *Smooth) Smoothed() XYs {
+	half := s.Window / 2
+	smooth := make(XYs, len(s.XYs))
+	for i, p := range s.XYs {
+		h := half
+		if i < h {
+			h = i
+		}
+		if n := len(s.XYs) - 1 - i; n < h {
+			h = n
+		}
+		win := s.XYs[i-h : i+h+1]
+		smooth[i].X = p.X
+		switch s.Kernel {
+		case SavitzkyGolay:
+			smooth[i].Y = quadraticAt(win, p.X)
+		default:
+			smooth[i].Y = meanY(win)
+		}
+	}
+	return smooth
+}
+
+// meanY returns the mean of the Y values.
+func meanY(xys XYs) float64 {
+	var sum float64
+	for _, p := range xys {
+		sum += p.Y
+	}
+	return sum / float64(len(xys))
+}
+
+// quadraticAt returns the value at x of the quadratic
+// that is the least squares fit to the points.  If
+// there are too few distinct X values to fit a
+// quadratic then the mean of the Y values is returned.
+func quadraticAt(xys XYs, x float64) float64 {
+	// The quadratic is a + b*d + c*d², where d is the
+	// distance from x, so its value at x is a.  The
+	// normal equations are solved by Cramer's rule.
+	var s [5]float64
+	var t [3]float64
+	for _, p := range xys {
+		d := p.X - x
+		dk := 1.0
+		for k := range s {
+			if k < len(t) {
+				t[k] += p.Y * dk
+			}
+			s[k] += dk
+			dk *= d
+		}
+	}
+	det := s[0]*(s[2]*s[4]-s[3]*s[3]) - s[1]*(s[1]*s[4]-s[3]*s[2]) + s[2]*(s[1]*s[3]-s[2]*s[2])
+	if math.Abs(det) <= 1e-12*s[0]*s[2]*s[4] {
+		return meanY(xys)
+	}
+	a := t[0]*(s[2]*s[4]-s[3]*s[3]) - s[1]*(t[1]*s[4]-s[3]*t[2]) + s[2]*(t[1]*s[3]-s[2]*t[2])
+	return a / det
+}
+
+// Plot draws the smoothed line, implementing the
+// plot.Plotter interface.
+func (s *Smooth) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	smooth := s.Smoothed()
+	ps := make([]plot.Point, len(smooth))
+	for i, p := range smooth {
+		ps[i] = plot.Pt(trX(p.X), trY(p.Y))
+	}
+	da.StrokeLines(s.LineStyle, da.ClipLinesXY(ps)...)
+}
+
+// DataRange returns the minimum and maximum X and Y
+// values of the smoothed line, implementing the
+// plot.DataRanger interface.
+func (s *Smooth) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(s.Smoothed())
+}
+
+// AutoColor sets the color of the line if it is
+// the default color, implementing the
+// plot.AutoColorer interface.
+func (s *Smooth) AutoColor(c color.Color) bool {
+	if !unsetColor(s.LineStyle.Color) {
+		return false
+	}
+	s.LineStyle.Color = c
+	return true
+}
+
+// Thumbnail draws a line through the center of the
+// draw area, implementing the plot.Thumbnailer
+// interface.
+func (s *Smooth) Thumbnail(da *plot.DrawArea) {
+	y := da.Center().Y
+	da.StrokeLine2(s.LineStyle, da.Min.X, y, da.Max().X, y)
+}
@@ -0,0 +1,40 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSmooth(t *testing.T) {
+	xys := make(XYs, 10)
+	for i := range xys {
+		xys[i].X = float64(i)
+		xys[i].Y = float64(i * i)
+	}
+	s, err := NewSmooth(xys, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The window shrinks to stay centered, so the
+	// ends are not smoothed, and a moving average of
+	// i² over i-h..i+h is i² + h(h+1)/3.
+	want := []float64{0, 1 + 2.0/3, 4 + 2, 9 + 2, 16 + 2, 25 + 2, 36 + 2, 49 + 2, 64 + 2.0/3, 81}
+	for i, p := range s.Smoothed() {
+		if math.Abs(p.Y-want[i]) > 1e-12 || p.X != xys[i].X {
+			t.Errorf("moving average point %d is %v, want {%g %g}", i, p, xys[i].X, want[i])
+		}
+	}
+
+	// A quadratic is its own least squares fit.
+	s.Kernel = SavitzkyGolay
+	for i, p := range s.Smoothed() {
+		if math.Abs(p.Y-xys[i].Y) > 1e-9 {
+			t.Errorf("Savitzky-Golay point %d is %v, want %v", i, p, xys[i])
+		}
+	}
+}
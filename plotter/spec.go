@@ -0,0 +1,162 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"encoding/json"
+
+	"github.com/gonum/plot/plot"
+)
+
+// The plotters of this package that are registered
+// with plot.RegisterPlotter, so that plots made of them
+// can be written as JSON, are Line as "line", Scatter
+// as "scatter" and LinePoints as "linepoints".  Their
+// data gives their points, as [x, y] pairs, and their
+// line and glyph styles.  Other fields, such as the
+// shading of a Line, are not written and have their
+// default values when the plot is read back.
+func init() {
+	plot.RegisterPlotter("line", plot.PlotterCodec{
+		Encode: encodeLine,
+		Decode: decodeLine,
+	})
+	plot.RegisterPlotter("scatter", plot.PlotterCodec{
+		Encode: encodeScatter,
+		Decode: decodeScatter,
+	})
+	plot.RegisterPlotter("linepoints", plot.PlotterCodec{
+		Encode: encodeLinePoints,
+		Decode: decodeLinePoints,
+	})
+}
+
+// specPoints are points written as [x, y] pairs.
+type specPoints [][2]float64
+
+// specOfXYs returns the specPoints of the points.
+func specOfXYs(xys XYs) specPoints {
+	pts := make(specPoints, len(xys))
+	for i, p := range xys {
+		pts[i] = [2]float64{p.X, p.Y}
+	}
+	return pts
+}
+
+// Len implements the XYer interface.
+func (pts specPoints) Len() int {
+	return len(pts)
+}
+
+// XY implements the XYer interface.
+func (pts specPoints) XY(i int) (float64, float64) {
+	return pts[i][0], pts[i][1]
+}
+
+// lineSpec is the data of a Line.
+type lineSpec struct {
+	Points        specPoints         `json:"points"`
+	Line          plot.LineStyleSpec `json:"line"`
+	Interpolation Interpolation      `json:"interpolation,omitempty"`
+}
+
+// encodeLine returns the data of a *Line.
+func encodeLine(d plot.Plotter) (interface{}, bool) {
+	l, ok := d.(*Line)
+	if !ok {
+		return nil, false
+	}
+	return lineSpec{
+		Points:        specOfXYs(l.XYs),
+		Line:          plot.SpecOfLineStyle(l.LineStyle),
+		Interpolation: l.Interpolation,
+	}, true
+}
+
+// decodeLine returns a *Line made from its data.
+func decodeLine(data json.RawMessage) (plot.Plotter, error) {
+	var s lineSpec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	l, err := NewLine(s.Points)
+	if err != nil {
+		return nil, err
+	}
+	l.Interpolation = s.Interpolation
+	return l, s.Line.Apply(&l.LineStyle)
+}
+
+// scatterSpec is the data of a Scatter.
+type scatterSpec struct {
+	Points specPoints          `json:"points"`
+	Glyph  plot.GlyphStyleSpec `json:"glyph"`
+}
+
+// encodeScatter returns the data of a *Scatter.
+func encodeScatter(d plot.Plotter) (interface{}, bool) {
+	s, ok := d.(*Scatter)
+	if !ok {
+		return nil, false
+	}
+	g, err := plot.SpecOfGlyphStyle(s.GlyphStyle)
+	if err != nil {
+		return nil, false
+	}
+	return scatterSpec{Points: specOfXYs(s.XYs), Glyph: g}, true
+}
+
+// decodeScatter returns a *Scatter made from its data.
+func decodeScatter(data json.RawMessage) (plot.Plotter, error) {
+	var s scatterSpec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	sc, err := NewScatter(s.Points)
+	if err != nil {
+		return nil, err
+	}
+	return sc, s.Glyph.Apply(&sc.GlyphStyle)
+}
+
+// linePointsSpec is the data of a LinePoints.
+type linePointsSpec struct {
+	Points specPoints          `json:"points"`
+	Line   plot.LineStyleSpec  `json:"line"`
+	Glyph  plot.GlyphStyleSpec `json:"glyph"`
+}
+
+// encodeLinePoints returns the data of a *LinePoints.
+func encodeLinePoints(d plot.Plotter) (interface{}, bool) {
+	lp, ok := d.(*LinePoints)
+	if !ok {
+		return nil, false
+	}
+	g, err := plot.SpecOfGlyphStyle(lp.GlyphStyle)
+	if err != nil {
+		return nil, false
+	}
+	return linePointsSpec{
+		Points: specOfXYs(lp.XYs),
+		Line:   plot.SpecOfLineStyle(lp.LineStyle),
+		Glyph:  g,
+	}, true
+}
+
+// decodeLinePoints returns a *LinePoints made from its data.
+func decodeLinePoints(data json.RawMessage) (plot.Plotter, error) {
+	var s linePointsSpec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	lp, err := NewLinePointsPlotter(s.Points)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Line.Apply(&lp.LineStyle); err != nil {
+		return nil, err
+	}
+	return lp, s.Glyph.Apply(&lp.GlyphStyle)
+}
@@ -0,0 +1,107 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"encoding/json"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+func TestPlotJSONRoundTrip(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Round trip"
+	p.X.Label.Text = "X"
+	p.Y.Scale = plot.LogScale
+	p.Y.Tick.Marker = plot.LogTicks
+	p.Legend.Top = true
+
+	l, err := NewLine(XYs{{0, 1}, {1, 10}, {2, 100}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l.Color = color.NRGBA{R: 0xff, A: 0x80}
+	l.Dashes = []vg.Length{vg.Points(2), vg.Points(1)}
+	s, err := NewScatter(XYs{{0.5, 5}, {1.5, 50}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Shape = plot.PyramidGlyph{}
+	p.Add(l, s)
+	p.Legend.Add("line", l)
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var q plot.Plot
+	if err := json.Unmarshal(data, &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := p.Spec()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := q.Spec()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip spec is\n%+v\nwant\n%+v", got, want)
+	}
+	if q.Y.Min != p.Y.Min || q.Y.Max != p.Y.Max {
+		t.Errorf("round trip Y range is [%g, %g], want [%g, %g]", q.Y.Min, q.Y.Max, p.Y.Min, p.Y.Max)
+	}
+}
+
+func TestPlotJSONEmptyAndSymLog(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The axes of a plot with no data have
+	// infinite bounds, which are left out.
+	if _, err := json.Marshal(p); err != nil {
+		t.Fatalf("unexpected error marshaling an empty plot: %v", err)
+	}
+
+	if err := p.Y.SetScale("symlog", 0.5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var q plot.Plot
+	if err := json.Unmarshal(data, &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s, err := q.Spec()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Y.Scale != "symlog" || s.Y.ScaleParam != 0.5 {
+		t.Errorf("round trip Y scale is %q with parameter %v, want symlog with 0.5", s.Y.Scale, s.Y.ScaleParam)
+	}
+
+	r, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.Y.Scale = plot.SymLogScale(1)
+	if _, err := r.Spec(); err == nil {
+		t.Errorf("no error for a symmetric log scale that was not set by SetScale")
+	}
+	if err := r.Y.SetScale("symlog", 0); err == nil {
+		t.Errorf("no error for a zero symmetric log threshold")
+	}
+}
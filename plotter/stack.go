@@ -0,0 +1,78 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+)
+
+// A StackBaseline is the line from which
+// stacked layers are stacked.
+type StackBaseline int
+
+const (
+	// ZeroBaseline stacks the layers up
+	// from zero.
+	ZeroBaseline StackBaseline = iota
+
+	// CenteredBaseline stacks the layers so
+	// that at each X value they are centered
+	// on zero, as in a stream graph.
+	CenteredBaseline
+)
+
+// StackAreas returns an Area for each of the layers,
+// stacked one on top of another from the baseline in
+// the order given: the Area of a layer is bounded below
+// by the sum of the layers before it and above by that
+// sum plus the layer.  The layers must share the same
+// X values.  The Areas are filled with translucent gray
+// and have no bounding lines, so each should be given
+// a color before it is added to a plot, for example with
+// plotutil.AddStackedAreas.
+func StackAreas(baseline StackBaseline, layers ...XYer) ([]*Area, error) {
+	if len(layers) == 0 {
+		return nil, nil
+	}
+	data := make([]XYs, len(layers))
+	for i, l := range layers {
+		var err error
+		if data[i], err = CopyXYs(l); err != nil {
+			return nil, err
+		}
+		if len(data[i]) != len(data[0]) {
+			return nil, errors.New("Stacked layers have different numbers of points")
+		}
+		for j, p := range data[i] {
+			if p.X != data[0][j].X {
+				return nil, errors.New("Stacked layers do not share X values")
+			}
+		}
+	}
+
+	below := make(XYs, len(data[0]))
+	for j := range below {
+		below[j].X = data[0][j].X
+		if baseline == CenteredBaseline {
+			for _, d := range data {
+				below[j].Y -= d[j].Y / 2
+			}
+		}
+	}
+	areas := make([]*Area, len(data))
+	for i, d := range data {
+		above := make(XYs, len(d))
+		for j, p := range d {
+			above[j].X, above[j].Y = p.X, below[j].Y+p.Y
+		}
+		a, err := NewAreaBetween(above, below)
+		if err != nil {
+			return nil, err
+		}
+		areas[i] = a
+		below = above
+	}
+	return areas, nil
+}
@@ -0,0 +1,110 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// Stem implements the Plotter interface, drawing
+// a stem plot: a vertical line from a baseline to
+// each point, with a glyph at the end of the line.
+type Stem struct {
+	// XYs is a copy of the points for this stem plot.
+	XYs
+
+	// Baseline is the Y value from which the
+	// stems are drawn.
+	Baseline float64
+
+	// LineStyle is the style of the stems.
+	plot.LineStyle
+
+	// GlyphStyle is the style of the glyphs drawn
+	// at the end of each stem.
+	plot.GlyphStyle
+}
+
+// NewStem returns a Stem that uses the default
+// line and glyph styles, with stems drawn from
+// the given baseline.
+func NewStem(xys XYer, baseline float64) (*Stem, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckFloats(baseline); err != nil {
+		return nil, err
+	}
+	return &Stem{
+		XYs:        data,
+		Baseline:   baseline,
+		LineStyle:  DefaultLineStyle,
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
+// Plot draws the Stem, implementing the plot.Plotter
+// interface.
+func (s *Stem) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	base := trY(s.Baseline)
+	for _, p := range s.XYs {
+		x, y := trX(p.X), trY(p.Y)
+		da.StrokeLines(s.LineStyle, da.ClipLinesXY([]plot.Point{{x, base}, {x, y}})...)
+		if da.Contains(plot.Pt(x, y)) {
+			da.DrawGlyph(s.GlyphStyle, plot.Pt(x, y))
+		}
+	}
+}
+
+// AutoColor sets the colors of the stems and the
+// glyphs that have the default color, implementing
+// the plot.AutoColorer interface.
+func (s *Stem) AutoColor(c color.Color) bool {
+	used := false
+	if unsetColor(s.LineStyle.Color) {
+		s.LineStyle.Color = c
+		used = true
+	}
+	if unsetColor(s.GlyphStyle.Color) {
+		s.GlyphStyle.Color = c
+		used = true
+	}
+	return used
+}
+
+// DataRange returns the minimum and maximum
+// x and y values, implementing the plot.DataRanger
+// interface.  The Y range includes the baseline.
+func (s *Stem) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(s)
+	return xmin, xmax, math.Min(ymin, s.Baseline), math.Max(ymax, s.Baseline)
+}
+
+// GlyphBoxes returns a slice of plot.GlyphBoxes,
+// one for the glyph at the end of each stem,
+// implementing the plot.GlyphBoxer interface.
+func (s *Stem) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(s.XYs))
+	for i, p := range s.XYs {
+		bs[i].X = plt.X.Norm(p.X)
+		bs[i].Y = plt.Y.Norm(p.Y)
+		bs[i].Rect = s.GlyphStyle.Rect()
+	}
+	return bs
+}
+
+// Thumbnail draws a single stem from the bottom to
+// the center of the draw area, implementing the
+// plot.Thumbnailer interface.
+func (s *Stem) Thumbnail(da *plot.DrawArea) {
+	c := da.Center()
+	da.StrokeLine2(s.LineStyle, c.X, da.Min.Y, c.X, c.Y)
+	da.DrawGlyph(s.GlyphStyle, c)
+}
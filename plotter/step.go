@@ -0,0 +1,155 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+)
+
+// A StepKind is the way that a Step connects
+// each of its points to the next.
+type StepKind int
+
+const (
+	// PostStep connects the points horizontally
+	// and then vertically, so each Y value holds
+	// from its point until the next point.
+	PostStep StepKind = iota
+
+	// PreStep connects the points vertically
+	// and then horizontally, so each Y value holds
+	// from the previous point up to its point.
+	PreStep
+
+	// MidStep connects the points horizontally
+	// to halfway between their X values, then
+	// vertically, and then horizontally again, so
+	// each point is in the middle of its step.
+	MidStep
+)
+
+// Step implements the Plotter interface, drawing a
+// line that connects the points with horizontal and
+// vertical steps instead of straight segments.
+type Step struct {
+	// XYs is a copy of the points for this line.
+	XYs
+
+	// Kind is the way that the points are
+	// connected.  The default is PostStep.
+	Kind StepKind
+
+	// LineStyle is the style of the line.
+	plot.LineStyle
+
+	// FillColor, if non-nil, fills the area
+	// between the steps and the Baseline.
+	FillColor color.Color
+
+	// Baseline is the Y value down, or up, to
+	// which the steps are filled.  The Y axis is
+	// extended to include it if FillColor is set.
+	Baseline float64
+}
+
+// NewStep returns a Step of the points that uses
+// the default line style and is not filled.
+func NewStep(xys XYer) (*Step, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &Step{
+		XYs:       data,
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// points returns the data coordinates of the corners
+// of the steps between the points.
+func (s *Step) points() XYs {
+	if len(s.XYs) == 0 {
+		return nil
+	}
+	pts := XYs{s.XYs[0]}
+	add := func(x, y float64) {
+		pts = append(pts, struct{ X, Y float64 }{x, y})
+	}
+	for i, p := range s.XYs[1:] {
+		prev := s.XYs[i]
+		switch s.Kind {
+		case PreStep:
+			add(prev.X, p.Y)
+		case MidStep:
+			mid := (prev.X + p.X) / 2
+			add(mid, prev.Y)
+			add(mid, p.Y)
+		default:
+			add(p.X, prev.Y)
+		}
+		add(p.X, p.Y)
+	}
+	return pts
+}
+
+// Plot draws the steps, and the fill beneath them,
+// implementing the plot.Plotter interface.
+func (s *Step) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	pts := s.points()
+	if len(pts) == 0 {
+		return
+	}
+	ps := make([]plot.Point, len(pts))
+	for i, p := range pts {
+		ps[i] = plot.Pt(trX(p.X), trY(p.Y))
+	}
+
+	if s.FillColor != nil {
+		base := trY(s.Baseline)
+		fill := make([]plot.Point, 0, len(ps)+2)
+		fill = append(fill, plot.Pt(ps[0].X, base))
+		fill = append(fill, ps...)
+		fill = append(fill, plot.Pt(ps[len(ps)-1].X, base))
+		da.FillPolygon(s.FillColor, da.ClipPolygonXY(fill))
+	}
+	da.StrokeLines(s.LineStyle, da.ClipLinesXY(ps)...)
+}
+
+// DataRange returns the minimum and maximum X and Y
+// values, including the Baseline if the steps are
+// filled, implementing the plot.DataRanger interface.
+func (s *Step) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(s)
+	if s.FillColor != nil {
+		ymin = math.Min(ymin, s.Baseline)
+		ymax = math.Max(ymax, s.Baseline)
+	}
+	return
+}
+
+// AutoColor sets the color of the line if it is
+// the default color, implementing the
+// plot.AutoColorer interface.
+func (s *Step) AutoColor(c color.Color) bool {
+	if !unsetColor(s.LineStyle.Color) {
+		return false
+	}
+	s.LineStyle.Color = c
+	return true
+}
+
+// Thumbnail draws a filled rectangle, if the steps
+// are filled, and a line through the center of the
+// draw area, implementing the plot.Thumbnailer
+// interface.
+func (s *Step) Thumbnail(da *plot.DrawArea) {
+	fillThumbnail(da, s.FillColor)
+	y := da.Center().Y
+	da.StrokeLine2(s.LineStyle, da.Min.X, y, da.Max().X, y)
+}
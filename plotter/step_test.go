@@ -0,0 +1,47 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestStepPoints(t *testing.T) {
+	data := XYs{{0, 1}, {2, 3}}
+	for _, test := range []struct {
+		kind StepKind
+		want XYs
+	}{
+		{PostStep, XYs{{0, 1}, {2, 1}, {2, 3}}},
+		{PreStep, XYs{{0, 1}, {0, 3}, {2, 3}}},
+		{MidStep, XYs{{0, 1}, {1, 1}, {1, 3}, {2, 3}}},
+	} {
+		s, err := NewStep(data)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		s.Kind = test.kind
+		if got := s.points(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("kind %d: points() = %v, want %v", test.kind, got, test.want)
+		}
+	}
+}
+
+func TestStepDataRange(t *testing.T) {
+	s, err := NewStep(XYs{{0, 1}, {2, 3}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Baseline = -1
+	if _, _, ymin, _ := s.DataRange(); ymin != 1 {
+		t.Errorf("unfilled ymin = %v, want 1", ymin)
+	}
+	s.FillColor = color.Gray{200}
+	if _, _, ymin, _ := s.DataRange(); ymin != -1 {
+		t.Errorf("filled ymin = %v, want -1", ymin)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// GridVectors describes a vector field whose vectors
+// are arranged on a rectangular grid.
+type GridVectors interface {
+	// Dims returns the number of columns
+	// and rows of the grid.
+	Dims() (c, r int)
+
+	// Vector returns the u and v components
+	// of the vector at column c and row r.
+	Vector(c, r int) (u, v float64)
+
+	// X returns the X coordinate of
+	// column c.  The X coordinates must
+	// be increasing.
+	X(c int) float64
+
+	// Y returns the Y coordinate of row r.
+	// The Y coordinates must be increasing.
+	Y(r int) float64
+}
+
+// Streamlines implements the Plotter interface, drawing
+// the streamlines of a vector field: lines that follow
+// the direction of the field, found by integrating
+// through the vectors of the grid, which are
+// interpolated bilinearly between grid points.
+//
+// The streamlines are spread evenly over the grid: its
+// extent is divided into Density×Density cells, a line
+// is started in each cell that no line has crossed, and
+// each line is followed in both directions until it
+// leaves the grid, reaches a point where the field is
+// zero, or enters a cell that a line has already
+// crossed.
+type Streamlines struct {
+	// GridVectors is the vector field.
+	GridVectors
+
+	// Density is the number of cells in each
+	// direction among which the streamlines
+	// are spread.
+	Density int
+
+	// LineStyle is the style of the streamlines.
+	plot.LineStyle
+
+	// HeadLength is the length of the arrow head
+	// drawn at the middle of each streamline, in
+	// the direction of the field.  If HeadLength
+	// is zero then no arrow heads are drawn.
+	HeadLength vg.Length
+}
+
+// streamStep is the length of an integration step
+// of a streamline, as a fraction of a cell.
+const streamStep = 0.2
+
+// NewStreamlines returns Streamlines of the vector
+// field with a density of 20, the default line style
+// and arrow heads of the default length.
+func NewStreamlines(g GridVectors) (*Streamlines, error) {
+	c, r := g.Dims()
+	if c < 2 || r < 2 {
+		return nil, errors.New("Streamlines need at least two rows and columns")
+	}
+	return &Streamlines{
+		GridVectors: g,
+		Density:     20,
+		LineStyle:   DefaultLineStyle,
+		HeadLength:  DefaultArrowHeadLength,
+	}, nil
+}
+
+// Lines returns the streamlines, each in the
+// direction of the field.
+func (s *Streamlines) Lines() []XYs {
+	if s.Density < 1 {
+		return nil
+	}
+	c, r := s.Dims()
+	x0, x1 := s.X(0), s.X(c-1)
+	y0, y1 := s.Y(0), s.Y(r-1)
+	n := s.Density
+	used := make([]bool, n*n)
+	cell := func(x, y float64) int {
+		i := int(math.Min(float64(n-1), (x-x0)/(x1-x0)*float64(n)))
+		j := int(math.Min(float64(n-1), (y-y0)/(y1-y0)*float64(n)))
+		return j*n + i
+	}
+
+	var lines []XYs
+	for k := range used {
+		if used[k] {
+			continue
+		}
+		x := x0 + (float64(k%n)+0.5)/float64(n)*(x1-x0)
+		y := y0 + (float64(k/n)+0.5)/float64(n)*(y1-y0)
+		used[k] = true
+		back := s.trace(x, y, -1, used, cell)
+		fwd := s.trace(x, y, 1, used, cell)
+		line := make(XYs, 0, len(back)+len(fwd)+1)
+		for i := len(back) - 1; i >= 0; i-- {
+			line = append(line, back[i])
+		}
+		line = append(line, struct{ X, Y float64 }{x, y})
+		line = append(line, fwd...)
+		if len(line) > 1 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// trace follows the field from x, y in the given
+// direction, 1 along the field and -1 against it,
+// marking the cells that it enters as used, and returns
+// the points after x, y.
+func (s *Streamlines) trace(x, y, dir float64, used []bool, cell func(x, y float64) int) XYs {
+	c, r := s.Dims()
+	x0, x1 := s.X(0), s.X(c-1)
+	y0, y1 := s.Y(0), s.Y(r-1)
+	// The field is followed in coordinates in which
+	// the grid is a unit square, so that a step has
+	// the same size in both directions.
+	sx, sy := x1-x0, y1-y0
+	h := streamStep / float64(s.Density)
+	dirAt := func(x, y float64) (dx, dy float64, ok bool) {
+		u, v := s.at(x, y)
+		u, v = u/sx, v/sy
+		l := math.Hypot(u, v)
+		if l == 0 || math.IsNaN(l) || math.IsInf(l, 0) {
+			return 0, 0, false
+		}
+		return dir * u / l, dir * v / l, true
+	}
+
+	var pts XYs
+	last := cell(x, y)
+	// A line that does not leave its cell, such
+	// as one circling a center, is stopped after
+	// going around the grid a few times.
+	maxSteps := int(4 * float64(s.Density) / streamStep)
+	for steps := 0; steps < maxSteps; steps++ {
+		// Each step is a second order
+		// Runge-Kutta step.
+		dx, dy, ok := dirAt(x, y)
+		if !ok {
+			break
+		}
+		mx, my := x+dx*h/2*sx, y+dy*h/2*sy
+		if mx < x0 || mx > x1 || my < y0 || my > y1 {
+			break
+		}
+		if dx, dy, ok = dirAt(mx, my); !ok {
+			break
+		}
+		x, y = x+dx*h*sx, y+dy*h*sy
+		if x < x0 || x > x1 || y < y0 || y > y1 {
+			break
+		}
+		if k := cell(x, y); k != last {
+			if used[k] {
+				break
+			}
+			used[k] = true
+			last = k
+		}
+		pts = append(pts, struct{ X, Y float64 }{x, y})
+	}
+	return pts
+}
+
+// at returns the field at x, y, interpolated
+// bilinearly between the grid points.
+func (s *Streamlines) at(x, y float64) (u, v float64) {
+	c, r := s.Dims()
+	i := sort.Search(c-1, func(i int) bool { return s.X(i+1) >= x })
+	j := sort.Search(r-1, func(j int) bool { return s.Y(j+1) >= y })
+	if i >= c-1 {
+		i = c - 2
+	}
+	if j >= r-1 {
+		j = r - 2
+	}
+	fx := (x - s.X(i)) / (s.X(i+1) - s.X(i))
+	fy := (y - s.Y(j)) / (s.Y(j+1) - s.Y(j))
+	u00, v00 := s.Vector(i, j)
+	u10, v10 := s.Vector(i+1, j)
+	u01, v01 := s.Vector(i, j+1)
+	u11, v11 := s.Vector(i+1, j+1)
+	u = (u00*(1-fx)+u10*fx)*(1-fy) + (u01*(1-fx)+u11*fx)*fy
+	v = (v00*(1-fx)+v10*fx)*(1-fy) + (v01*(1-fx)+v11*fx)*fy
+	return u, v
+}
+
+// Plot draws the streamlines, implementing the
+// plot.Plotter interface.
+func (s *Streamlines) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	for _, line := range s.Lines() {
+		ps := make([]plot.Point, len(line))
+		for i, p := range line {
+			ps[i] = plot.Pt(trX(p.X), trY(p.Y))
+		}
+		da.StrokeLines(s.LineStyle, da.ClipLinesXY(ps)...)
+		if s.HeadLength == 0 {
+			continue
+		}
+		mid := len(ps) / 2
+		if tip := ps[mid]; da.Contains(tip) {
+			drawArrowHead(&da, s.Color, ps[mid-1], tip, s.HeadLength)
+		}
+	}
+}
+
+// DataRange returns the extent of the grid,
+// implementing the plot.DataRanger interface.
+func (s *Streamlines) DataRange() (xmin, xmax, ymin, ymax float64) {
+	c, r := s.Dims()
+	return s.X(0), s.X(c - 1), s.Y(0), s.Y(r - 1)
+}
+
+// AutoColor sets the color of the streamlines if it
+// is the default color, implementing the
+// plot.AutoColorer interface.
+func (s *Streamlines) AutoColor(c color.Color) bool {
+	if !unsetColor(s.LineStyle.Color) {
+		return false
+	}
+	s.LineStyle.Color = c
+	return true
+}
+
+// Thumbnail draws an arrow, implementing the
+// plot.Thumbnailer interface.
+func (s *Streamlines) Thumbnail(da *plot.DrawArea) {
+	y := da.Center().Y
+	drawArrow(da, s.LineStyle, plot.Pt(da.Min.X, y), plot.Pt(da.Max().X, y), s.HeadLength)
+}
@@ -0,0 +1,57 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"testing"
+)
+
+// fieldGrid is a GridVectors of a field
+// given by a function on a 10×10 grid from
+// -1 to 1.
+type fieldGrid func(x, y float64) (u, v float64)
+
+func (f fieldGrid) Dims() (c, r int) { return 10, 10 }
+func (f fieldGrid) X(c int) float64  { return -1 + 2*float64(c)/9 }
+func (f fieldGrid) Y(r int) float64  { return -1 + 2*float64(r)/9 }
+func (f fieldGrid) Vector(c, r int) (u, v float64) {
+	return f(f.X(c), f.Y(r))
+}
+
+func TestStreamlinesUniform(t *testing.T) {
+	s, err := NewStreamlines(fieldGrid(func(x, y float64) (float64, float64) { return 2, 0 }))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Density = 5
+	lines := s.Lines()
+	if len(lines) != 5 {
+		t.Fatalf("got %d streamlines, want 5", len(lines))
+	}
+	for i, l := range lines {
+		for j := 1; j < len(l); j++ {
+			if l[j].Y != l[0].Y || l[j].X <= l[j-1].X {
+				t.Errorf("streamline %d is not a line to the right at point %d", i, j)
+				break
+			}
+		}
+	}
+}
+
+func TestStreamlinesRotation(t *testing.T) {
+	s, err := NewStreamlines(fieldGrid(func(x, y float64) (float64, float64) { return -y, x }))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Density = 8
+	for i, l := range s.Lines() {
+		for _, p := range l {
+			if p.X < -1 || p.X > 1 || p.Y < -1 || p.Y > 1 {
+				t.Errorf("streamline %d leaves the grid at %v", i, p)
+				break
+			}
+		}
+	}
+}
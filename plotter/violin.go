@@ -0,0 +1,235 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// Violin implements the Plotter interface, drawing a
+// violin plot of the distribution of values: the
+// kernel density estimate of the values, mirrored on
+// both sides of the violin's x location, so that, unlike
+// a box plot, it shows when the values have more than
+// one mode.  A box plot of the values may be drawn
+// inside of the violin.
+//
+// Each Violin draws one sample, so several samples are
+// drawn by adding a Violin for each at its own location,
+// as with BoxPlot.
+type Violin struct {
+	fiveStatPlot
+
+	// Offset is added to the x location of the
+	// violin.
+	Offset vg.Length
+
+	// Width is the width of the violin where the
+	// density is MaxDensity.
+	Width vg.Length
+
+	// Bandwidth is the standard deviation of the
+	// Gaussian kernel of the density estimate, in the
+	// units of the values.  NewViolin sets it by
+	// Silverman's rule of thumb.
+	Bandwidth float64
+
+	// MaxDensity is the density drawn at the full
+	// width of the violin.  If it is zero then the
+	// violin's own greatest density is used, so every
+	// violin is as wide as Width.  Giving several
+	// violins the same MaxDensity scales them alike,
+	// so that they have the same area.
+	MaxDensity float64
+
+	// FillColor, if non-nil, fills the violin.
+	FillColor color.Color
+
+	// LineStyle is the style of the outline of
+	// the violin.
+	plot.LineStyle
+
+	// Box, if true, draws a box plot inside of
+	// the violin: a box from the first to the third
+	// quartile, a line to each adjacent value and a
+	// line across the box at the median.
+	Box bool
+
+	// BoxWidth is the width of the inner box.
+	BoxWidth vg.Length
+
+	// BoxStyle is the line style of the inner box
+	// and of the lines to the adjacent values.
+	BoxStyle plot.LineStyle
+
+	// MedianStyle is the line style of the line
+	// at the median.
+	MedianStyle plot.LineStyle
+}
+
+// violinSamples is the number of values at which
+// the density of a violin is evaluated.
+const violinSamples = 100
+
+// NewViolin returns a Violin of the given width at the
+// given x location that represents the distribution of
+// the values, which extends from the smallest to the
+// largest of them.  An error is returned if there are
+// no values.
+func NewViolin(w vg.Length, loc float64, values Valuer) (*Violin, error) {
+	if w < 0 {
+		return nil, errors.New("Negative violin width")
+	}
+	if values.Len() == 0 {
+		return nil, ErrNoData
+	}
+	v := new(Violin)
+	var err error
+	if v.fiveStatPlot, err = newFiveStat(w, loc, values); err != nil {
+		return nil, err
+	}
+	v.Width = w
+	v.Bandwidth = silverman(v.Values, v.Quartile3-v.Quartile1)
+	v.LineStyle = DefaultLineStyle
+	v.BoxWidth = w / 8
+	v.BoxStyle = DefaultLineStyle
+	v.MedianStyle = DefaultLineStyle
+	return v, nil
+}
+
+// silverman returns the bandwidth given by Silverman's
+// rule of thumb for values with the given interquartile
+// range.  It is 1 if the values do not vary.
+func silverman(vs Values, iqr float64) float64 {
+	n := float64(len(vs))
+	var mean float64
+	for _, v := range vs {
+		mean += v
+	}
+	mean /= n
+	var ss float64
+	for _, v := range vs {
+		ss += (v - mean) * (v - mean)
+	}
+	sd := 0.0
+	if n > 1 {
+		sd = math.Sqrt(ss / (n - 1))
+	}
+	spread := sd
+	if s := iqr / 1.34; s > 0 && s < spread {
+		spread = s
+	}
+	if spread == 0 {
+		return 1
+	}
+	return 0.9 * spread * math.Pow(n, -0.2)
+}
+
+// Density returns the kernel density estimate of the
+// values at y.
+func (v *Violin) Density(y float64) float64 {
+	return gaussianDensity(v.Values, v.Bandwidth, y)
+}
+
+// gaussianDensity returns the kernel density estimate
+// of the values at x with a Gaussian kernel whose
+// standard deviation is h.
+func gaussianDensity(vs Values, h, x float64) float64 {
+	var d float64
+	for _, v := range vs {
+		u := (x - v) / h
+		d += math.Exp(-u * u / 2)
+	}
+	return d / (float64(len(vs)) * h * math.Sqrt(2*math.Pi))
+}
+
+// densities returns the values between the smallest and
+// largest of the values at which the density is drawn,
+// the density at each, and the greatest of them.
+func (v *Violin) densities() (ys, ds []float64, max float64) {
+	ys = make([]float64, violinSamples)
+	ds = make([]float64, violinSamples)
+	for i := range ys {
+		ys[i] = v.Min + (v.Max-v.Min)*float64(i)/(violinSamples-1)
+		ds[i] = v.Density(ys[i])
+		max = math.Max(max, ds[i])
+	}
+	return ys, ds, max
+}
+
+// Plot draws the violin and its inner box plot,
+// implementing the plot.Plotter interface.
+func (v *Violin) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	x := trX(v.Location)
+	if !da.ContainsX(x) {
+		return
+	}
+	x += v.Offset
+
+	if v.Max > v.Min {
+		ys, ds, max := v.densities()
+		if v.MaxDensity > 0 {
+			max = v.MaxDensity
+		}
+		pts := make([]plot.Point, 2*len(ys))
+		for i, y := range ys {
+			w := v.Width / 2 * vg.Length(ds[i]/max)
+			pts[i] = plot.Pt(x+w, trY(y))
+			pts[len(pts)-1-i] = plot.Pt(x-w, trY(y))
+		}
+		if v.FillColor != nil {
+			da.FillPolygon(v.FillColor, da.ClipPolygonY(pts))
+		}
+		da.StrokeLines(v.LineStyle, da.ClipLinesY(append(pts, pts[0]))...)
+	}
+
+	if !v.Box {
+		return
+	}
+	q1, q3 := trY(v.Quartile1), trY(v.Quartile3)
+	half := v.BoxWidth / 2
+	da.StrokeLines(v.BoxStyle, da.ClipLinesY(
+		[]plot.Point{{x, q3}, {x, trY(v.AdjHigh)}},
+		[]plot.Point{{x, q1}, {x, trY(v.AdjLow)}},
+		[]plot.Point{{x - half, q1}, {x + half, q1}, {x + half, q3}, {x - half, q3}, {x - half, q1}},
+	)...)
+	med := trY(v.Median)
+	da.StrokeLines(v.MedianStyle, da.ClipLinesY([]plot.Point{{x - half, med}, {x + half, med}})...)
+}
+
+// DataRange returns the minimum and maximum x
+// and y values, implementing the plot.DataRanger
+// interface.
+func (v *Violin) DataRange() (float64, float64, float64, float64) {
+	return v.Location, v.Location, v.Min, v.Max
+}
+
+// GlyphBoxes returns a GlyphBox as wide as the violin
+// at its median, implementing the plot.GlyphBoxer
+// interface.
+func (v *Violin) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return []plot.GlyphBox{{
+		X: plt.X.Norm(v.Location),
+		Y: plt.Y.Norm(v.Median),
+		Rect: plot.Rect{
+			Min:  plot.Point{X: v.Offset - (v.Width/2 + v.LineStyle.Width/2)},
+			Size: plot.Point{X: v.Width + v.LineStyle.Width},
+		},
+	}}
+}
+
+// Thumbnail fills the draw area with the fill color
+// and outlines it, implementing the plot.Thumbnailer
+// interface.
+func (v *Violin) Thumbnail(da *plot.DrawArea) {
+	pts := fillThumbnail(da, v.FillColor)
+	da.StrokeLines(v.LineStyle, append(pts, pts[0]))
+}
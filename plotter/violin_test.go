@@ -0,0 +1,44 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/plot/vg"
+)
+
+func TestViolin(t *testing.T) {
+	var vs Values
+	for i := 0; i < 50; i++ {
+		vs = append(vs, float64(i%5)/10, 5+float64(i%5)/10)
+	}
+	v, err := NewViolin(vg.Points(20), 0, vs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !(v.Bandwidth > 0 && v.Bandwidth < 1) {
+		t.Errorf("bandwidth is %g, want between 0 and 1", v.Bandwidth)
+	}
+
+	// The density of the two clusters has a peak
+	// at each of them, with a trough between.
+	if mid, peak := v.Density(2.6), v.Density(0.2); mid > peak/10 {
+		t.Errorf("density between the modes is %g, want much less than %g", mid, peak)
+	}
+
+	var total float64
+	for y := -5.0; y < 10; y += 0.01 {
+		total += v.Density(y) * 0.01
+	}
+	if math.Abs(total-1) > 1e-3 {
+		t.Errorf("density integrates to %g, want 1", total)
+	}
+
+	if _, err := NewViolin(vg.Points(20), 0, Values{}); err == nil {
+		t.Errorf("no error for a violin of no values")
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// Waterfall implements the Plotter interface, drawing a
+// waterfall chart of a sequence of contributions to a
+// running total: each contribution is a bar that floats
+// from the total before it to the total after it, and is
+// joined to the next bar by a connector line at that
+// total.  Increases, decreases and the final total have
+// their own colors.
+//
+// As in a BarChart, the bars are at the X locations
+// XMin, XMin+1 and so on, so they can be named with
+// plot.NominalX.
+type Waterfall struct {
+	// Values are the contributions.
+	Values
+
+	// Width is the width of the bars.
+	Width vg.Length
+
+	// XMin is the X location of the first bar.
+	XMin float64
+
+	// Total, if true, draws a bar from zero to
+	// the sum of the contributions after the last
+	// contribution.
+	Total bool
+
+	// IncreaseColor, DecreaseColor and TotalColor
+	// are the fill colors of the bars of positive
+	// and of negative contributions, and of the
+	// total bar.  A contribution of zero is drawn
+	// as an increase.
+	IncreaseColor, DecreaseColor, TotalColor color.Color
+
+	// LineStyle is the style of the outlines of
+	// the bars.
+	plot.LineStyle
+
+	// ConnectorStyle is the style of the lines that
+	// join each bar to the next.  If its width is
+	// zero then the connectors are not drawn.
+	ConnectorStyle plot.LineStyle
+}
+
+// NewWaterfall returns a Waterfall of the contributions
+// with bars of the given width, a total bar, green
+// increases, red decreases, a gray total and dashed
+// connectors.
+func NewWaterfall(vs Valuer, width vg.Length) (*Waterfall, error) {
+	if width <= 0 {
+		return nil, errors.New("Width parameter was not positive")
+	}
+	values, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	connector := DefaultLineStyle
+	connector.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+	return &Waterfall{
+		Values:         values,
+		Width:          width,
+		Total:          true,
+		IncreaseColor:  color.RGBA{G: 160, A: 255},
+		DecreaseColor:  color.RGBA{R: 200, A: 255},
+		TotalColor:     color.Gray{Y: 128},
+		LineStyle:      DefaultLineStyle,
+		ConnectorStyle: connector,
+	}, nil
+}
+
+// Bars returns the bottom and top of each bar, in
+// order, including the total bar if Total is set, and
+// the color with which each is filled.
+func (w *Waterfall) Bars() (bottoms, tops []float64, colors []color.Color) {
+	sum := 0.0
+	for _, v := range w.Values {
+		bottoms = append(bottoms, sum)
+		sum += v
+		tops = append(tops, sum)
+		if v < 0 {
+			colors = append(colors, w.DecreaseColor)
+		} else {
+			colors = append(colors, w.IncreaseColor)
+		}
+	}
+	if w.Total {
+		bottoms = append(bottoms, 0)
+		tops = append(tops, sum)
+		colors = append(colors, w.TotalColor)
+	}
+	return bottoms, tops, colors
+}
+
+// Plot draws the bars and the connectors between
+// them, implementing the plot.Plotter interface.
+func (w *Waterfall) Plot(da plot.DrawArea, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&da)
+	bottoms, tops, colors := w.Bars()
+	for i := range bottoms {
+		x := trX(w.XMin + float64(i))
+		xmin, xmax := x-w.Width/2, x+w.Width/2
+		ymin, ymax := trY(bottoms[i]), trY(tops[i])
+		pts := []plot.Point{
+			{xmin, ymin},
+			{xmin, ymax},
+			{xmax, ymax},
+			{xmax, ymin},
+		}
+		// As in a BarChart, the bars are clipped only
+		// in Y, so that the first and last bars are
+		// drawn whole, in the padding made for them
+		// by GlyphBoxes.
+		if colors[i] != nil {
+			da.FillPolygon(colors[i], da.ClipPolygonY(pts))
+		}
+		da.StrokeLines(w.LineStyle, da.ClipLinesY(append(pts, pts[0]))...)
+
+		if i+1 < len(bottoms) && w.ConnectorStyle.Width > 0 {
+			next := trX(w.XMin+float64(i+1)) - w.Width/2
+			da.StrokeLines(w.ConnectorStyle, da.ClipLinesY([]plot.Point{{xmax, ymax}, {next, ymax}})...)
+		}
+	}
+}
+
+// DataRange returns the range of X locations of the
+// bars and the range of totals from zero, implementing
+// the plot.DataRanger interface.
+func (w *Waterfall) DataRange() (xmin, xmax, ymin, ymax float64) {
+	bottoms, tops, _ := w.Bars()
+	ymin, ymax = 0, 0
+	for i := range bottoms {
+		ymin = math.Min(ymin, math.Min(bottoms[i], tops[i]))
+		ymax = math.Max(ymax, math.Max(bottoms[i], tops[i]))
+	}
+	return w.XMin, w.XMin + float64(len(bottoms)-1), ymin, ymax
+}
+
+// GlyphBoxes returns a GlyphBox as wide as each bar,
+// including its outline, implementing the
+// plot.GlyphBoxer interface.
+func (w *Waterfall) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bottoms, _, _ := w.Bars()
+	boxes := make([]plot.GlyphBox, len(bottoms))
+	for i := range boxes {
+		boxes[i].X = plt.X.Norm(w.XMin + float64(i))
+		boxes[i].Rect = plot.Rect{
+			Min:  plot.Point{X: -(w.Width + w.LineStyle.Width) / 2},
+			Size: plot.Point{X: w.Width + w.LineStyle.Width},
+		}
+	}
+	return boxes
+}
@@ -0,0 +1,73 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot/plot"
+	"github.com/gonum/plot/vg"
+)
+
+func TestWaterfall(t *testing.T) {
+	w, err := NewWaterfall(Values{3, -1, 2}, vg.Points(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bottoms, tops, colors := w.Bars()
+	if want := []float64{0, 3, 2, 0}; !reflect.DeepEqual(bottoms, want) {
+		t.Errorf("bottoms = %v, want %v", bottoms, want)
+	}
+	if want := []float64{3, 2, 4, 4}; !reflect.DeepEqual(tops, want) {
+		t.Errorf("tops = %v, want %v", tops, want)
+	}
+	want := []interface{}{w.IncreaseColor, w.DecreaseColor, w.IncreaseColor, w.TotalColor}
+	for i, c := range colors {
+		if c != want[i] {
+			t.Errorf("color of bar %d = %v, want %v", i, c, want[i])
+		}
+	}
+	xmin, xmax, ymin, ymax := w.DataRange()
+	if xmin != 0 || xmax != 3 || ymin != 0 || ymax != 4 {
+		t.Errorf("data range = %v, %v, %v, %v, want 0, 3, 0, 4", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(w)
+	prims, err := p.Trace(vg.Inches(4), vg.Inches(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prims) < 4 {
+		t.Fatalf("traced %d primitives, want at least 4", len(prims))
+	}
+	// The width of the bars is a length, so it is
+	// taken from the fill of the second bar.
+	xmin, xmax = Range(Values(prims[3].X))
+	hw := (xmax - xmin) / 2
+
+	// Each bar is filled and outlined, whole, and
+	// joined to the next by a connector at its top.
+	// The first and last bars are drawn half in the
+	// padding beyond the ends of the X axis.
+	line, conn := w.LineStyle.Color, w.ConnectorStyle.Color
+	checkTrace(t, p, []tracedBox{
+		{plot.TracedFill, w.IncreaseColor, -hw, hw, 0, 3},
+		{plot.TracedLine, line, -hw, hw, 0, 3},
+		{plot.TracedLine, conn, hw, 1 - hw, 3, 3},
+		{plot.TracedFill, w.DecreaseColor, 1 - hw, 1 + hw, 2, 3},
+		{plot.TracedLine, line, 1 - hw, 1 + hw, 2, 3},
+		{plot.TracedLine, conn, 1 + hw, 2 - hw, 2, 2},
+		{plot.TracedFill, w.IncreaseColor, 2 - hw, 2 + hw, 2, 4},
+		{plot.TracedLine, line, 2 - hw, 2 + hw, 2, 4},
+		{plot.TracedLine, conn, 2 + hw, 3 - hw, 4, 4},
+		{plot.TracedFill, w.TotalColor, 3 - hw, 3 + hw, 0, 4},
+		{plot.TracedLine, line, 3 - hw, 3 + hw, 0, 4},
+	})
+}
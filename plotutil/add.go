@@ -24,57 +24,149 @@ func (c combineXYs) XY(i int) (float64, float64) { return c.xs.Value(i), c.ys.Va
 // plot to the plot below the stacked area plots added
 // before it.  If a plotter.Valuer is immediately
 // preceeded by a string then the string value is used to
-// label the legend.
+// label the legend.  The legend entries are in the order
+// of the plots.
 // Plots should be added in order of tallest to shortest,
 // because they will be drawn in the order they are added
 // (i.e. later plots will be painted over earlier plots).
+// The values are the tops of the plots, already stacked;
+// AddStackedAreas stacks the layers itself.
 //
 // If an error occurs then none of the plotters are added
 // to the plot, and the error is returned.
 func AddStackedAreaPlots(plt *plot.Plot, xs plotter.Valuer, vs ...interface{}) error {
-	var ps []plot.Plotter
-	names := make(map[*plotter.Line]string)
-	name := ""
-	var i int
-
-	for _, v := range vs {
-		switch t := v.(type) {
-		case string:
-			name = t
-
-		case plotter.Valuer:
-			if xs.Len() != t.Len() {
-				return errors.New("X/Y length mismatch")
-			}
-
-			// Make a line plotter and set its style.
-			l, err := plotter.NewLine(combineXYs{xs: xs, ys: t})
-			if err != nil {
-				return err
-			}
+	args, names := namedArgs(vs)
+	ls := make([]*plotter.Line, len(args))
+	for i, v := range args {
+		t, ok := v.(plotter.Valuer)
+		if !ok {
+			panic(fmt.Sprintf("AddStackedAreaPlots handles strings and plotter.Valuers, got %T", v))
+		}
+		if xs.Len() != t.Len() {
+			return errors.New("X/Y length mismatch")
+		}
 
-			l.LineStyle.Width = vg.Points(0)
-			color := Color(i)
-			i++
-			l.ShadeColor = &color
+		// Make a line plotter and set its style.
+		l, err := plotter.NewLine(combineXYs{xs: xs, ys: t})
+		if err != nil {
+			return err
+		}
 
-			ps = append(ps, l)
+		l.LineStyle.Width = vg.Points(0)
+		color := Color(i)
+		l.ShadeColor = &color
+		ls[i] = l
+	}
 
-			if name != "" {
-				names[l] = name
-				name = ""
-			}
+	// The plots are added from the top of the
+	// stack down.
+	for i, l := range ls {
+		plt.Add(l)
+		if names[i] != "" {
+			plt.Legend.Add(names[i], l)
+		}
+	}
+	return nil
+}
 
-		default:
-			panic(fmt.Sprintf("AddStackedAreaPlots handles strings and plotter.Valuers, got %T", t))
+// namedArgs returns the arguments that are not
+// strings, and the name of each: the string that
+// immediately precedes it, or "" if there is none.
+func namedArgs(vs []interface{}) (args []interface{}, names []string) {
+	name := ""
+	for _, v := range vs {
+		if s, ok := v.(string); ok {
+			name = s
+			continue
 		}
+		args = append(args, v)
+		names = append(names, name)
+		name = ""
 	}
+	return args, names
+}
 
-	plt.Add(ps...)
-	for p, n := range names {
-		plt.Legend.Add(n, p)
+// AddStackedAreas adds stacked plotter.Areas to a plot.
+// The variadic arguments must be either strings or
+// plotter.XYers that share the same X values.  Each
+// plotter.XYer is stacked, from the given baseline, on
+// top of those before it, and is filled using the next
+// color via the Color function.  If a plotter.XYer is
+// immediately preceeded by a string then a legend entry
+// is added to the plot using the string as the name.
+// The legend entries are in the order of the layers
+// from the top down, as they are drawn.  Unlike
+// AddStackedAreaPlots, the layers are the thicknesses
+// of the areas rather than their stacked tops.
+//
+// If an error occurs then none of the plotters are added
+// to the plot, and the error is returned.
+func AddStackedAreas(plt *plot.Plot, baseline plotter.StackBaseline, vs ...interface{}) error {
+	args, names := namedArgs(vs)
+	layers := make([]plotter.XYer, len(args))
+	for i, v := range args {
+		t, ok := v.(plotter.XYer)
+		if !ok {
+			panic(fmt.Sprintf("AddStackedAreas handles strings and plotter.XYers, got %T", v))
+		}
+		layers[i] = t
+	}
+	areas, err := plotter.StackAreas(baseline, layers...)
+	if err != nil {
+		return err
+	}
+	for i, a := range areas {
+		a.FillColor = Color(i)
+		plt.Add(a)
+	}
+	for i := len(areas) - 1; i >= 0; i-- {
+		if names[i] != "" {
+			plt.Legend.Add(names[i], areas[i])
+		}
 	}
+	return nil
+}
 
+// AddStackedBars adds stacked BarCharts to a plot.
+// The variadic arguments must be either strings or
+// plotter.Valuers.  Each plotter.Valuer adds a bar chart
+// of the given bar width, filled using the next color via
+// the Color function, that is stacked on the bar charts
+// added before it with StackOnSigned, so that negative
+// values are stacked downward from zero.  If a
+// plotter.Valuer is immediately preceeded by a string
+// then a legend entry is added to the plot using the
+// string as the name.  The legend entries are in the
+// order of the stack from the top down.
+//
+// If an error occurs then none of the plotters are added
+// to the plot, and the error is returned.
+func AddStackedBars(plt *plot.Plot, width vg.Length, vs ...interface{}) error {
+	args, names := namedArgs(vs)
+	bars := make([]*plotter.BarChart, len(args))
+	for i, v := range args {
+		t, ok := v.(plotter.Valuer)
+		if !ok {
+			panic(fmt.Sprintf("AddStackedBars handles strings and plotter.Valuers, got %T", v))
+		}
+		b, err := plotter.NewBarChart(t, width)
+		if err != nil {
+			return err
+		}
+		b.Color = Color(i)
+		if i > 0 {
+			b.StackOnSigned(bars[i-1])
+		}
+		bars[i] = b
+	}
+	for _, b := range bars {
+		plt.Add(b)
+	}
+	for i := len(bars) - 1; i >= 0; i-- {
+		if names[i] != "" {
+			plt.Legend.Add(names[i], bars[i])
+		}
+	}
 	return nil
 }
 
@@ -209,7 +301,7 @@ func AddLines(plt *plot.Plot, vs ...interface{}) error {
 	return nil
 }
 
-// AddLinePoints adds Line and Scatter plotters to a
+// AddLinePoints adds LinePoints plotters to a
 // plot.  The variadic arguments must be either strings
 // or plotter.XYers.  Each plotter.XYer is added to
 // the plot using the next color, dashes, and glyph
@@ -222,7 +314,7 @@ func AddLines(plt *plot.Plot, vs ...interface{}) error {
 // to the plot, and the error is returned.
 func AddLinePoints(plt *plot.Plot, vs ...interface{}) error {
 	var ps []plot.Plotter
-	names := make(map[[2]plot.Thumbnailer]string)
+	names := make(map[*plotter.LinePoints]string)
 	name := ""
 	var i int
 	for _, v := range vs {
@@ -231,18 +323,18 @@ func AddLinePoints(plt *plot.Plot, vs ...interface{}) error {
 			name = t
 
 		case plotter.XYer:
-			l, s, err := plotter.NewLinePoints(t)
+			lp, err := plotter.NewLinePointsPlotter(t)
 			if err != nil {
 				return err
 			}
-			l.Color = Color(i)
-			l.Dashes = Dashes(i)
-			s.Color = Color(i)
-			s.Shape = Shape(i)
+			lp.LineStyle.Color = Color(i)
+			lp.Dashes = Dashes(i)
+			lp.GlyphStyle.Color = Color(i)
+			lp.Shape = Shape(i)
 			i++
-			ps = append(ps, l, s)
+			ps = append(ps, lp)
 			if name != "" {
-				names[[2]plot.Thumbnailer{l, s}] = name
+				names[lp] = name
 				name = ""
 			}
 
@@ -251,8 +343,8 @@ func AddLinePoints(plt *plot.Plot, vs ...interface{}) error {
 		}
 	}
 	plt.Add(ps...)
-	for ps, n := range names {
-		plt.Legend.Add(n, ps[0], ps[1])
+	for p, n := range names {
+		plt.Legend.Add(n, p)
 	}
 	return nil
 }
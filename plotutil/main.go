@@ -7,11 +7,13 @@
 package main
 
 import (
+	"fmt"
 	"math/rand"
 
 	"github.com/gonum/plot/plot"
 	"github.com/gonum/plot/plotter"
 	"github.com/gonum/plot/plotutil"
+	"github.com/gonum/plot/vg"
 )
 
 var examples = []struct {
@@ -20,6 +22,8 @@ var examples = []struct {
 }{
 	{"example_errpoints", Example_errpoints},
 	{"example_stackedAreaChart", Example_stackedAreaChart},
+	{"example_streamGraph", Example_streamGraph},
+	{"example_stackedBars", Example_stackedBars},
 }
 
 func main() {
@@ -168,3 +172,53 @@ func Example_stackedAreaChart() *plot.Plot {
 
 	return p
 }
+
+// An example of making a stream graph: stacked areas
+// centered on zero.
+func Example_streamGraph() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Example: Stream graph"
+	p.X.Label.Text = "Week"
+	p.HideY()
+
+	var vs []interface{}
+	for i := 0; i < 5; i++ {
+		layer := make(plotter.XYs, 20)
+		for j := range layer {
+			layer[j].X = float64(j)
+			layer[j].Y = 1 + rand.Float64()*float64(i+1)
+		}
+		vs = append(vs, fmt.Sprintf("Topic %d", i+1), layer)
+	}
+	if err := plotutil.AddStackedAreas(p, plotter.CenteredBaseline, vs...); err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// An example of stacking bar charts of gains and
+// losses, with the losses stacked downward.
+func Example_stackedBars() *plot.Plot {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Example: Stacked bars"
+	p.Y.Label.Text = "Change"
+
+	err = plotutil.AddStackedBars(p, vg.Points(20),
+		"Sales", plotter.Values{5, 6, 4, 7},
+		"Returns", plotter.Values{-1, -2, -1.5, -0.5},
+		"Services", plotter.Values{2, 1.5, 3, 2.5},
+		"Refunds", plotter.Values{-0.5, -1, 0, -1},
+	)
+	if err != nil {
+		panic(err)
+	}
+	p.NominalX("Q1", "Q2", "Q3", "Q4")
+	p.Legend.Top = true
+	return p
+}
@@ -174,6 +174,12 @@ func (f *Font) Width(s string) Length {
 	return Points(float64(width)) * scale
 }
 
+// HasGlyph returns true if the font has a glyph
+// for the given rune.
+func (f *Font) HasGlyph(r rune) bool {
+	return f.font.Index(r) != 0
+}
+
 // AddFont associates a truetype.Font with the given name.
 func AddFont(name string, font *truetype.Font) {
 	fontLock.RLock()
@@ -78,6 +78,66 @@ type Canvas interface {
 	DPI() float64
 }
 
+// Titler wraps the BeginTitle and EndTitle methods.
+// It may be implemented by Canvases whose output
+// format can associate a title, such as a tooltip,
+// with a group of drawing operations.
+type Titler interface {
+	// BeginTitle starts a group of drawing
+	// operations that are given the title.
+	BeginTitle(string)
+
+	// EndTitle ends the group started by the
+	// corresponding call to BeginTitle.
+	EndTitle()
+}
+
+// An Annotation is metadata that is associated with
+// a group of drawing operations, such as the glyph of
+// a data point.
+type Annotation struct {
+	// Title is the title of the group, which
+	// viewers may show as a tooltip.  An empty
+	// Title is not drawn.
+	Title string
+
+	// Data are named values that are attached to
+	// the group for use by scripts.
+	Data map[string]string
+
+	// Class, if non-empty, names the role of the
+	// group, such as "axis", for stylesheets.  It
+	// may hold several names separated by spaces.
+	Class string
+}
+
+// Annotator wraps the BeginAnnotation and EndAnnotation
+// methods.  It may be implemented by Canvases whose
+// output format can attach metadata to a group of
+// drawing operations.  Other Canvases ignore metadata.
+type Annotator interface {
+	// BeginAnnotation starts a group of drawing
+	// operations that are given the annotation.
+	BeginAnnotation(Annotation)
+
+	// EndAnnotation ends the group started by the
+	// corresponding call to BeginAnnotation.
+	EndAnnotation()
+}
+
+// Clipper wraps the Clip method.  It may be
+// implemented by Canvases that can restrict drawing
+// to the inside of a path.
+type Clipper interface {
+	// Clip restricts later drawing to the inside
+	// of the path, within any region to which
+	// drawing is already restricted.  The region is
+	// saved by Push and restored by Pop, so Clip is
+	// usually called after Push and undone by the
+	// corresponding call to Pop.
+	Clip(Path)
+}
+
 // Initialize sets all of the canvas's values to their
 // initial values.
 func Initialize(c Canvas) {
@@ -148,6 +148,13 @@ func (e *Canvas) Fill(path vg.Path) {
 	e.buf.WriteString("fill\n")
 }
 
+// Clip implements the vg.Clipper interface.  The
+// clip is undone by the matching call to Pop.
+func (e *Canvas) Clip(path vg.Path) {
+	e.trace(path)
+	e.buf.WriteString("clip\nnewpath\n")
+}
+
 func (e *Canvas) trace(path vg.Path) {
 	e.buf.WriteString("newpath\n")
 	for _, comp := range path {
@@ -0,0 +1,217 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgimg
+
+import (
+	"bufio"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"sort"
+)
+
+// maxColors is the maximum size of a GIF palette.
+const maxColors = 256
+
+// WriteGIF writes the images of the given canvases as
+// the frames of an animated GIF, with delay hundredths of
+// a second between frames.  All of the canvases must
+// have images of the same size.
+//
+// If the frames use no more than 256 colors in total then
+// they share a single global palette with the exact
+// colors of the images.  Otherwise each frame is given
+// its own palette of the 256 colors that best represent
+// it, chosen by median cut, and its pixels are mapped to
+// the nearest colors of its palette.
+func WriteGIF(w io.Writer, frames []*Canvas, delay int) error {
+	if len(frames) == 0 {
+		return errors.New("No frames to write")
+	}
+	bounds := frames[0].img.Bounds()
+	for _, f := range frames[1:] {
+		if f.img.Bounds().Size() != bounds.Size() {
+			return errors.New("GIF frames have different sizes")
+		}
+	}
+
+	hists := make([]map[color.RGBA]int, len(frames))
+	global := make(map[color.RGBA]int)
+	for i, f := range frames {
+		hists[i] = histogram(f.img)
+		for c, n := range hists[i] {
+			global[c] += n
+		}
+	}
+	var shared color.Palette
+	if len(global) <= maxColors {
+		shared = medianCut(global, maxColors)
+	}
+
+	anim := &gif.GIF{
+		Image: make([]*image.Paletted, len(frames)),
+		Delay: make([]int, len(frames)),
+	}
+	for i, f := range frames {
+		pal := shared
+		if pal == nil {
+			pal = medianCut(hists[i], maxColors)
+		}
+		b := f.img.Bounds()
+		img := image.NewPaletted(image.Rect(0, 0, b.Dx(), b.Dy()), pal)
+		draw.Draw(img, img.Bounds(), f.img, b.Min, draw.Src)
+		anim.Image[i] = img
+		anim.Delay[i] = delay
+	}
+
+	b := bufio.NewWriter(w)
+	if err := gif.EncodeAll(b, anim); err != nil {
+		return err
+	}
+	return b.Flush()
+}
+
+// histogram returns the number of pixels of
+// each color in an image.
+func histogram(img image.Image) map[color.RGBA]int {
+	hist := make(map[color.RGBA]int)
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+			hist[c]++
+		}
+	}
+	return hist
+}
+
+// colorCount is a color and the number of
+// pixels that have it.
+type colorCount struct {
+	color.RGBA
+	n int
+}
+
+// medianCut returns a palette of at most n colors
+// representing the colors of the histogram.  If the
+// histogram has no more than n colors then they are
+// returned exactly.  Otherwise the colors are
+// repeatedly divided at the median of the channel with
+// the widest range, and the palette holds the mean of
+// each division weighted by the pixel counts.
+func medianCut(hist map[color.RGBA]int, n int) color.Palette {
+	all := make([]colorCount, 0, len(hist))
+	for c, k := range hist {
+		all = append(all, colorCount{c, k})
+	}
+	if len(all) <= n {
+		pal := make(color.Palette, len(all))
+		for i, c := range all {
+			pal[i] = c.RGBA
+		}
+		return pal
+	}
+
+	boxes := [][]colorCount{all}
+	for len(boxes) < n {
+		// Split the box with the widest range.
+		widest, ch, span := -1, 0, uint8(0)
+		for i, box := range boxes {
+			if len(box) < 2 {
+				continue
+			}
+			if c, s := widestChannel(box); widest < 0 || s > span {
+				widest, ch, span = i, c, s
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		box := boxes[widest]
+		sort.Sort(byChannel{box, ch})
+		total := 0
+		for _, c := range box {
+			total += c.n
+		}
+		cut, sum := 1, box[0].n
+		for cut < len(box)-1 && 2*sum < total {
+			sum += box[cut].n
+			cut++
+		}
+		boxes[widest] = box[:cut]
+		boxes = append(boxes, box[cut:])
+	}
+
+	pal := make(color.Palette, len(boxes))
+	for i, box := range boxes {
+		var r, g, b, a, total int
+		for _, c := range box {
+			r += int(c.R) * c.n
+			g += int(c.G) * c.n
+			b += int(c.B) * c.n
+			a += int(c.A) * c.n
+			total += c.n
+		}
+		pal[i] = color.RGBA{
+			R: uint8(r / total),
+			G: uint8(g / total),
+			B: uint8(b / total),
+			A: uint8(a / total),
+		}
+	}
+	return pal
+}
+
+// channel returns the value of the ith
+// channel of a color.
+func channel(c color.RGBA, i int) uint8 {
+	switch i {
+	case 0:
+		return c.R
+	case 1:
+		return c.G
+	case 2:
+		return c.B
+	}
+	return c.A
+}
+
+// widestChannel returns the channel with the
+// widest range of values among the colors,
+// and the width of that range.
+func widestChannel(cs []colorCount) (ch int, span uint8) {
+	for i := 0; i < 4; i++ {
+		min, max := channel(cs[0].RGBA, i), channel(cs[0].RGBA, i)
+		for _, c := range cs[1:] {
+			v := channel(c.RGBA, i)
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+		}
+		if max-min > span || i == 0 {
+			ch, span = i, max-min
+		}
+	}
+	return ch, span
+}
+
+// byChannel sorts colors by the value
+// of one of their channels.
+type byChannel struct {
+	cs []colorCount
+	ch int
+}
+
+func (b byChannel) Len() int { return len(b.cs) }
+func (b byChannel) Less(i, j int) bool {
+	return channel(b.cs[i].RGBA, b.ch) < channel(b.cs[j].RGBA, b.ch)
+}
+func (b byChannel) Swap(i, j int) { b.cs[i], b.cs[j] = b.cs[j], b.cs[i] }
@@ -0,0 +1,36 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgimg
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestMedianCut(t *testing.T) {
+	hist := make(map[color.RGBA]int)
+	for r := 0; r < 256; r += 4 {
+		for g := 0; g < 256; g += 16 {
+			hist[color.RGBA{R: uint8(r), G: uint8(g), A: 255}] = 1 + r
+		}
+	}
+	if pal := medianCut(hist, maxColors); len(pal) != maxColors {
+		t.Errorf("got %d colors, want %d", len(pal), maxColors)
+	}
+
+	small := map[color.RGBA]int{
+		{R: 255, A: 255}: 10,
+		{G: 255, A: 255}: 1,
+	}
+	pal := medianCut(small, maxColors)
+	if len(pal) != 2 {
+		t.Fatalf("got %d colors, want the exact 2", len(pal))
+	}
+	for c := range small {
+		if i := pal.Index(c); pal[i] != c {
+			t.Errorf("color %v is not in the palette", c)
+		}
+	}
+}
@@ -16,36 +16,126 @@ import (
 	"image/jpeg"
 	"image/png"
 	"io"
+	"math"
 
 	"code.google.com/p/draw2d/draw2d"
 	"github.com/gonum/plot/vg"
 	"golang.org/x/image/tiff"
 )
 
-// dpi is the number of dots per inch.
-const dpi = 96
+// DefaultDPI is the number of dots per inch of
+// canvases returned by New and NewImage.
+const DefaultDPI = 96
 
 // Canvas implements the vg.Canvas interface,
 // drawing to an image.Image using draw2d.
+//
+// Fills with opaque colors are drawn directly by
+// draw2d.  Fills with translucent colors are
+// composited explicitly: the coverage of the path
+// is rasterized to a scratch layer the size of the
+// bounds of the path, which is used as a mask to
+// draw the color over the image with the Porter-Duff
+// over operator.  Colors are interpreted as
+// alpha-premultiplied, as by color.Color, so
+// overlapping translucent fills blend the same way
+// as they do in the SVG and PDF back-ends.
+//
+// Drawing that is restricted by Clip is drawn to
+// a scratch layer in the same way and composited
+// over the image with the coverage of the clip path
+// as the mask.
 type Canvas struct {
-	gc    draw2d.GraphicContext
-	img   image.Image
-	w, h  vg.Length
-	color []color.Color
+	gc   draw2d.GraphicContext
+	img  draw.Image
+	w, h vg.Length
+	dpi  int
+
+	// stk is the stack of graphics states, saved
+	// by Push and restored by Pop along with the
+	// state of gc.
+	stk []state
+}
+
+// state is the part of the graphics state of a
+// Canvas that is needed to draw to a scratch layer
+// with the same settings as gc.
+type state struct {
+	color color.Color
+
+	// width is the line width.  dashes and offs
+	// are the dash pattern in dots.
+	width  vg.Length
+	dashes []float64
+	offs   float64
+
+	// ctm is the current transformation matrix,
+	// mapping canvas coordinates in dots to image
+	// pixels.
+	ctm affine
+
+	// clip is the coverage of the clip paths, or
+	// nil if drawing is not restricted.  Its bounds
+	// are the rectangle outside of which the
+	// coverage is zero.
+	clip *image.Alpha
+}
+
+// affine is an affine transformation matrix,
+// mapping x, y to a*x + c*y + e, b*x + d*y + f.
+type affine struct {
+	a, b, c, d, e, f float64
+}
+
+// identity is the identity transformation.
+var identity = affine{a: 1, d: 1}
+
+// mul returns the transformation that applies
+// n and then m.
+func (m affine) mul(n affine) affine {
+	return affine{
+		a: m.a*n.a + m.c*n.b,
+		b: m.b*n.a + m.d*n.b,
+		c: m.a*n.c + m.c*n.d,
+		d: m.b*n.c + m.d*n.d,
+		e: m.a*n.e + m.c*n.f + m.e,
+		f: m.b*n.e + m.d*n.f + m.f,
+	}
+}
 
-	// width is the current line width.
-	width vg.Length
+// apply returns the transformation of a point.
+func (m affine) apply(x, y float64) (float64, float64) {
+	return m.a*x + m.c*y + m.e, m.b*x + m.d*y + m.f
+}
+
+// decompose returns a rotation by phi, a scaling
+// by sx and sy and a rotation by theta that, applied
+// in reverse order, are the linear part of m.  It is
+// the singular value decomposition of the matrix.
+func (m affine) decompose() (phi, sx, sy, theta float64) {
+	e, f := (m.a+m.d)/2, (m.a-m.d)/2
+	g, h := (m.b+m.c)/2, (m.b-m.c)/2
+	q, r := math.Hypot(e, h), math.Hypot(f, g)
+	a1, a2 := math.Atan2(g, f), math.Atan2(h, e)
+	return (a2 + a1) / 2, q + r, q - r, (a2 - a1) / 2
 }
 
 // New returns a new image canvas with
 // the size specified  rounded up to the
 // nearest pixel.
 func New(width, height vg.Length) *Canvas {
-	w := width.Inches() * dpi
-	h := height.Inches() * dpi
+	return NewDPI(width, height, DefaultDPI)
+}
+
+// NewDPI is like New, but the canvas has the
+// given number of dots per inch, so its image is
+// width.Inches()*dpi by height.Inches()*dpi pixels.
+func NewDPI(width, height vg.Length, dpi int) *Canvas {
+	w := width.Inches() * float64(dpi)
+	h := height.Inches() * float64(dpi)
 	img := image.NewRGBA(image.Rect(0, 0, int(w+0.5), int(h+0.5)))
 
-	return NewImage(img)
+	return NewImageDPI(img, dpi)
 }
 
 // NewImage returns a new image canvas
@@ -53,30 +143,74 @@ func New(width, height vg.Length) *Canvas {
 // minimum point of the given image
 // should probably be 0,0.
 func NewImage(img draw.Image) *Canvas {
-	w := float64(img.Bounds().Max.X - img.Bounds().Min.X)
-	h := float64(img.Bounds().Max.Y - img.Bounds().Min.Y)
+	return NewImageDPI(img, DefaultDPI)
+}
+
+// NewImageDPI is like NewImage, but the canvas
+// has the given number of dots per inch.
+func NewImageDPI(img draw.Image, dpi int) *Canvas {
 	draw.Draw(img, img.Bounds(), image.White, image.ZP, draw.Src)
-	gc := draw2d.NewGraphicContext(img)
-	gc.SetDPI(dpi)
-	gc.Scale(1, -1)
-	gc.Translate(0, -h)
+	return newImage(img, dpi)
+}
+
+// NewImageRegion returns a new image canvas with
+// the given number of dots per inch that draws to
+// the region r of img, so that a plot can be
+// composited into a larger image.  The origin of
+// the canvas is at the bottom left corner of the
+// region and nothing is drawn outside of it.
+//
+// Unlike NewImage, NewImageRegion does not clear
+// the region, so anything that is not covered by
+// the drawing, such as the area behind a plot with
+// a nil or translucent BackgroundColor, shows the
+// existing contents of the image.
+func NewImageRegion(img *image.RGBA, r image.Rectangle, dpi int) *Canvas {
+	r = r.Intersect(img.Bounds())
+	sub := img.SubImage(r).(*image.RGBA)
+
+	// draw2d expects the minimum point of the image
+	// to be 0,0, so draw to a view of the same pixels
+	// whose bounds are offset to the origin.
+	view := &image.RGBA{
+		Pix:    sub.Pix,
+		Stride: sub.Stride,
+		Rect:   image.Rect(0, 0, r.Dx(), r.Dy()),
+	}
+	return newImage(view, dpi)
+}
+
+// newImage returns a new image canvas that
+// draws to img without clearing it first.
+func newImage(img draw.Image, dpi int) *Canvas {
+	w := float64(img.Bounds().Max.X-img.Bounds().Min.X) / float64(dpi)
+	h := float64(img.Bounds().Max.Y-img.Bounds().Min.Y) / float64(dpi)
 	c := &Canvas{
-		gc:    gc,
-		img:   img,
-		w:     vg.Inches(w / dpi),
-		h:     vg.Inches(h / dpi),
-		color: []color.Color{color.Black},
+		gc:  draw2d.NewGraphicContext(img),
+		img: img,
+		w:   vg.Inches(w),
+		h:   vg.Inches(h),
+		dpi: dpi,
+		stk: []state{{color: color.Black, ctm: identity}},
 	}
+	c.gc.SetDPI(dpi)
+	c.Scale(1, -1)
+	c.Translate(0, vg.Inches(-h))
 	vg.Initialize(c)
 	return c
 }
 
+// cur returns the top state on the stack.
+func (c *Canvas) cur() *state {
+	return &c.stk[len(c.stk)-1]
+}
+
 func (c *Canvas) Size() (w, h vg.Length) {
 	return c.w, c.h
 }
 
 func (c *Canvas) SetLineWidth(w vg.Length) {
-	c.width = w
+	c.cur().width = w
 	c.gc.SetLineWidth(w.Dots(c))
 }
 
@@ -85,6 +219,7 @@ func (c *Canvas) SetLineDash(ds []vg.Length, offs vg.Length) {
 	for i, d := range ds {
 		dashes[i] = d.Dots(c)
 	}
+	c.cur().dashes, c.cur().offs = dashes, offs.Dots(c)
 	c.gc.SetLineDash(dashes, offs.Dots(c))
 }
 
@@ -94,61 +229,208 @@ func (c *Canvas) SetColor(clr color.Color) {
 	}
 	c.gc.SetFillColor(clr)
 	c.gc.SetStrokeColor(clr)
-	c.color[len(c.color)-1] = clr
+	c.cur().color = clr
 }
 
 func (c *Canvas) Rotate(t float64) {
 	c.gc.Rotate(t)
+	sin, cos := math.Sincos(t)
+	c.transform(affine{a: cos, b: sin, c: -sin, d: cos})
 }
 
 func (c *Canvas) Translate(x, y vg.Length) {
 	c.gc.Translate(x.Dots(c), y.Dots(c))
+	c.transform(affine{a: 1, d: 1, e: x.Dots(c), f: y.Dots(c)})
 }
 
 func (c *Canvas) Scale(x, y float64) {
 	c.gc.Scale(x, y)
+	c.transform(affine{a: x, d: y})
+}
+
+// transform applies a transformation to
+// the current transformation matrix.
+func (c *Canvas) transform(m affine) {
+	top := c.cur()
+	top.ctm = top.ctm.mul(m)
 }
 
 func (c *Canvas) Push() {
-	c.color = append(c.color, c.color[len(c.color)-1])
+	c.stk = append(c.stk, *c.cur())
 	c.gc.Save()
 }
 
 func (c *Canvas) Pop() {
-	c.color = c.color[:len(c.color)-1]
+	c.stk = c.stk[:len(c.stk)-1]
 	c.gc.Restore()
 }
 
 func (c *Canvas) Stroke(p vg.Path) {
-	if c.width == 0 {
+	if c.cur().width == 0 {
+		return
+	}
+	if c.cur().clip != nil {
+		c.clipped(c.bounds(p, c.cur().width), func(gc draw2d.GraphicContext) {
+			c.outline(gc, p)
+			gc.Stroke()
+		})
 		return
 	}
-	c.outline(p)
+	c.outline(c.gc, p)
 	c.gc.Stroke()
 }
 
 func (c *Canvas) Fill(p vg.Path) {
-	c.outline(p)
-	c.gc.Fill()
+	clr := c.cur().color
+	_, _, _, a := clr.RGBA()
+	switch {
+	case a == 0:
+		return
+	case c.cur().clip != nil:
+		c.clipped(c.bounds(p, 0), func(gc draw2d.GraphicContext) {
+			c.outline(gc, p)
+			gc.Fill()
+		})
+	case a == 0xFFFF:
+		c.outline(c.gc, p)
+		c.gc.Fill()
+	default:
+		c.compositeFill(p, clr)
+	}
+}
+
+// compositeFill fills a path with a translucent color
+// using the Porter-Duff over operator.
+func (c *Canvas) compositeFill(p vg.Path, clr color.Color) {
+	r := c.bounds(p, 0)
+	if r.Empty() {
+		return
+	}
+	draw.DrawMask(c.img, r, image.NewUniform(clr), image.ZP, c.coverage(p, r), image.ZP, draw.Over)
+}
+
+// layer returns a transparent scratch image the size
+// of the rectangle r of the image, and a graphic
+// context that draws to it with the current state,
+// as though the image were drawn to with gc.
+func (c *Canvas) layer(r image.Rectangle) (*image.RGBA, draw2d.GraphicContext) {
+	img := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	gc := draw2d.NewGraphicContext(img)
+	gc.SetDPI(c.dpi)
+	top := c.cur()
+	gc.Translate(top.ctm.e-float64(r.Min.X), top.ctm.f-float64(r.Min.Y))
+	phi, sx, sy, theta := top.ctm.decompose()
+	gc.Rotate(phi)
+	gc.Scale(sx, sy)
+	gc.Rotate(theta)
+	gc.SetLineWidth(top.width.Dots(c))
+	gc.SetLineDash(top.dashes, top.offs)
+	gc.SetFillColor(top.color)
+	gc.SetStrokeColor(top.color)
+	return img, gc
+}
+
+// coverage returns a layer for the region r of the
+// image, which should contain the bounds of the path,
+// with the coverage of the path in its alpha.
+func (c *Canvas) coverage(p vg.Path, r image.Rectangle) *image.RGBA {
+	img, gc := c.layer(r)
+	gc.SetFillColor(color.Opaque)
+	c.outline(gc, p)
+	gc.Fill()
+	return img
+}
+
+// Clip implements the vg.Clipper interface.  The
+// clip is undone by the matching call to Pop.
+func (c *Canvas) Clip(p vg.Path) {
+	top := c.cur()
+	r := c.bounds(p, 0)
+	if top.clip != nil {
+		r = r.Intersect(top.clip.Bounds())
+	}
+	m := image.NewAlpha(r)
+	if !r.Empty() {
+		cov := c.coverage(p, r)
+		if top.clip == nil {
+			draw.Draw(m, r, cov, image.ZP, draw.Src)
+		} else {
+			draw.DrawMask(m, r, cov, image.ZP, top.clip, r.Min, draw.Src)
+		}
+	}
+	top.clip = m
+}
+
+// clipped calls paint to draw to a layer for the
+// region r of the image, and composites the layer
+// over the image through the current clip mask.
+func (c *Canvas) clipped(r image.Rectangle, paint func(draw2d.GraphicContext)) {
+	m := c.cur().clip
+	r = r.Intersect(m.Bounds())
+	if r.Empty() {
+		return
+	}
+	img, gc := c.layer(r)
+	paint(gc)
+	draw.DrawMask(c.img, r, img, image.ZP, m, r.Min, draw.Over)
+}
+
+// bounds returns the pixel rectangle of the image
+// that may be covered by drawing the path with
+// lines of the given width, or by filling it if
+// the width is zero.
+func (c *Canvas) bounds(p vg.Path, width vg.Length) image.Rectangle {
+	m := c.cur().ctm
+	minx, miny := math.Inf(1), math.Inf(1)
+	maxx, maxy := math.Inf(-1), math.Inf(-1)
+	add := func(x, y float64) {
+		x, y = m.apply(x, y)
+		minx, maxx = math.Min(minx, x), math.Max(maxx, x)
+		miny, maxy = math.Min(miny, y), math.Max(maxy, y)
+	}
+	for _, comp := range p {
+		x, y := comp.X.Dots(c), comp.Y.Dots(c)
+		switch comp.Type {
+		case vg.MoveComp, vg.LineComp:
+			add(x, y)
+		case vg.ArcComp:
+			r := comp.Radius.Dots(c)
+			add(x-r, y-r)
+			add(x+r, y-r)
+			add(x+r, y+r)
+			add(x-r, y+r)
+		}
+	}
+	if minx > maxx || miny > maxy {
+		return image.Rectangle{}
+	}
+
+	// Pad the rectangle to account for anti-aliasing
+	// and for the width of the lines.
+	scale := math.Max(math.Hypot(m.a, m.b), math.Hypot(m.c, m.d))
+	pad := 2 + int(math.Ceil(width.Dots(c)*scale))
+	r := image.Rect(int(math.Floor(minx))-pad, int(math.Floor(miny))-pad,
+		int(math.Ceil(maxx))+pad, int(math.Ceil(maxy))+pad)
+	return r.Intersect(c.img.Bounds())
 }
 
-func (c *Canvas) outline(p vg.Path) {
-	c.gc.BeginPath()
+func (c *Canvas) outline(gc draw2d.GraphicContext, p vg.Path) {
+	gc.BeginPath()
 	for _, comp := range p {
 		switch comp.Type {
 		case vg.MoveComp:
-			c.gc.MoveTo(comp.X.Dots(c), comp.Y.Dots(c))
+			gc.MoveTo(comp.X.Dots(c), comp.Y.Dots(c))
 
 		case vg.LineComp:
-			c.gc.LineTo(comp.X.Dots(c), comp.Y.Dots(c))
+			gc.LineTo(comp.X.Dots(c), comp.Y.Dots(c))
 
 		case vg.ArcComp:
-			c.gc.ArcTo(comp.X.Dots(c), comp.Y.Dots(c),
+			gc.ArcTo(comp.X.Dots(c), comp.Y.Dots(c),
 				comp.Radius.Dots(c), comp.Radius.Dots(c),
 				comp.Start, comp.Angle)
 
 		case vg.CloseComp:
-			c.gc.Close()
+			gc.Close()
 
 		default:
 			panic(fmt.Sprintf("Unknown path component: %d", comp.Type))
@@ -161,8 +443,19 @@ func (c *Canvas) DPI() float64 {
 }
 
 func (c *Canvas) FillString(font vg.Font, x, y vg.Length, str string) {
-	c.gc.Save()
-	defer c.gc.Restore()
+	if m := c.cur().clip; m != nil {
+		c.clipped(m.Bounds(), func(gc draw2d.GraphicContext) {
+			c.fillString(gc, font, x, y, str)
+		})
+		return
+	}
+	c.fillString(c.gc, font, x, y, str)
+}
+
+// fillString draws a string with a graphic context.
+func (c *Canvas) fillString(gc draw2d.GraphicContext, font vg.Font, x, y vg.Length, str string) {
+	gc.Save()
+	defer gc.Restore()
 
 	data, ok := fontMap[font.Name()]
 	if !ok {
@@ -172,10 +465,10 @@ func (c *Canvas) FillString(font vg.Font, x, y vg.Length, str string) {
 		draw2d.RegisterFont(data, font.Font())
 		registeredFont[font.Name()] = true
 	}
-	c.gc.SetFontData(data)
-	c.gc.Translate(x.Dots(c), y.Dots(c))
-	c.gc.Scale(1, -1)
-	c.gc.FillString(str)
+	gc.SetFontData(data)
+	gc.Translate(x.Dots(c), y.Dots(c))
+	gc.Scale(1, -1)
+	gc.FillString(str)
 }
 
 var (
@@ -0,0 +1,148 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgimg
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/vgsvg"
+)
+
+// rect returns the path of a square from
+// min, min to max, max.
+func rect(min, max vg.Length) vg.Path {
+	var p vg.Path
+	p.Move(min, min)
+	p.Line(max, min)
+	p.Line(max, max)
+	p.Line(min, max)
+	p.Close()
+	return p
+}
+
+func TestNewDPI(t *testing.T) {
+	c := NewDPI(vg.Centimeters(10), vg.Centimeters(8), 300)
+	if got := c.img.Bounds().Size(); got.X != 1181 || got.Y != 945 {
+		t.Errorf("image size is %v, want (1181,945)", got)
+	}
+	if c.DPI() != 300 {
+		t.Errorf("DPI is %g, want 300", c.DPI())
+	}
+	w, _ := c.Size()
+	if cm := w.Centimeters(); cm < 9.99 || cm > 10.01 {
+		t.Errorf("width is %gcm, want 10cm", cm)
+	}
+}
+
+func TestNewImageRegion(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 300, 200))
+	blue := color.RGBA{B: 255, A: 255}
+	img.Set(0, 0, blue)
+	img.Set(100, 50, blue)
+
+	c := NewImageRegion(img, image.Rect(100, 50, 196, 146), DefaultDPI)
+	if w, h := c.Size(); w != vg.Inches(1) || h != vg.Inches(1) {
+		t.Errorf("size is %v by %v, want 1in by 1in", w, h)
+	}
+	if got := img.At(100, 50); got != color.Color(blue) {
+		t.Errorf("region was cleared: pixel is %v, want %v", got, blue)
+	}
+
+	red := color.RGBA{R: 255, A: 255}
+	c.img.Set(0, 0, red)
+	c.img.Set(95, 95, red)
+	if got := img.At(100, 50); got != color.Color(red) {
+		t.Errorf("pixel at the region origin is %v, want %v", got, red)
+	}
+	if got := img.At(195, 145); got != color.Color(red) {
+		t.Errorf("pixel at the region corner is %v, want %v", got, red)
+	}
+	if got := img.At(0, 0); got != color.Color(blue) {
+		t.Errorf("pixel outside of the region is %v, want %v", got, blue)
+	}
+}
+
+func TestClip(t *testing.T) {
+	c := NewDPI(vg.Inches(1), vg.Inches(1), 96)
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+
+	c.Push()
+	c.Clip(rect(0, vg.Inches(0.5)))
+	c.SetColor(red)
+	c.Fill(rect(0, vg.Inches(1)))
+	c.Pop()
+	c.SetColor(blue)
+	c.Fill(rect(vg.Inches(0.75), vg.Inches(1)))
+
+	for _, test := range []struct {
+		x, y int
+		want color.Color
+	}{
+		{x: 10, y: 85, want: red},
+		{x: 40, y: 55, want: red},
+		{x: 60, y: 85, want: color.White},
+		{x: 10, y: 30, want: color.White},
+		{x: 90, y: 5, want: blue},
+	} {
+		r, g, b, a := c.img.At(test.x, test.y).RGBA()
+		wr, wg, wb, wa := test.want.RGBA()
+		if r != wr || g != wg || b != wb || a != wa {
+			t.Errorf("pixel at (%d,%d) is %v, want %v", test.x, test.y, c.img.At(test.x, test.y), test.want)
+		}
+	}
+}
+
+// svgFill matches the fill color and opacity of an SVG path.
+var svgFill = regexp.MustCompile(`fill:#([0-9A-F]{6});fill-opacity:([0-9.]+)`)
+
+func TestAlphaMatchesSVG(t *testing.T) {
+	drawBands := func(c vg.Canvas) {
+		c.SetColor(color.NRGBA{R: 255, G: 128, A: 128})
+		c.Fill(rect(0, vg.Inches(0.75)))
+		c.SetColor(color.NRGBA{B: 255, A: 64})
+		c.Fill(rect(vg.Inches(0.25), vg.Inches(1)))
+	}
+
+	// Composite the fills written to the SVG over the
+	// white background, as an SVG renderer does with
+	// fill-opacity.
+	svg := vgsvg.New(vg.Inches(1), vg.Inches(1))
+	drawBands(svg)
+	var buf bytes.Buffer
+	if _, err := svg.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fills := svgFill.FindAllStringSubmatch(buf.String(), -1)
+	if len(fills) != 2 {
+		t.Fatalf("found %d translucent fills in the SVG, want 2", len(fills))
+	}
+	want := [3]float64{255, 255, 255}
+	for _, f := range fills {
+		rgb, _ := strconv.ParseUint(f[1], 16, 32)
+		a, _ := strconv.ParseFloat(f[2], 64)
+		for i := range want {
+			v := float64(rgb >> uint(16-8*i) & 0xff)
+			want[i] = a*v + (1-a)*want[i]
+		}
+	}
+
+	c := NewDPI(vg.Inches(1), vg.Inches(1), 96)
+	drawBands(c)
+	got := color.RGBAModel.Convert(c.img.At(48, 48)).(color.RGBA)
+	for i, v := range []uint8{got.R, got.G, got.B} {
+		if math.Abs(float64(v)-want[i]) > 2 {
+			t.Errorf("overlap is %v, want %.0f", got, want)
+			break
+		}
+	}
+}
@@ -3,135 +3,215 @@
 // license that can be found in the LICENSE file.
 
 // Package vgpdf implements the vg.Canvas interface
-// using gopdf (bitbucket.org/zombiezen/gopdf/pdf).
+// by writing a single page PDF document.
 package vgpdf
 
 import (
-	"bufio"
+	"bytes"
+	"compress/zlib"
 	"fmt"
 	"image/color"
 	"io"
 	"math"
+	"strconv"
+	"strings"
 
-	"bitbucket.org/zombiezen/gopdf/pdf"
 	"github.com/gonum/plot/vg"
 )
 
 // Canvas implements the vg.Canvas interface,
 // drawing to a PDF.
 type Canvas struct {
-	doc         *pdf.Document
-	w, h        vg.Length
-	page        *pdf.Canvas
+	w, h vg.Length
+
+	// buf is the content stream of the page.
+	buf *bytes.Buffer
+
+	// stk is the stack of graphics states,
+	// which mirrors the q and Q operators.
+	stk []ctx
+
+	// fonts and alphas are the font and
+	// ExtGState resources of the page, in the
+	// order in which they were first used.
+	// The resource names are /F and /GS
+	// followed by the index in the slice.
+	fonts  []string
+	alphas []float64
+}
+
+type ctx struct {
+	// alpha is the constant alpha of strokes
+	// and fills, set by the gs operator.
+	alpha float64
+
 	lineVisible bool
 }
 
 // New creates a new PDF Canvas.
 func New(w, h vg.Length) *Canvas {
 	c := &Canvas{
-		doc:         pdf.New(),
-		w:           w,
-		h:           h,
-		lineVisible: true,
+		w:   w,
+		h:   h,
+		buf: new(bytes.Buffer),
+		stk: []ctx{{alpha: 1, lineVisible: true}},
 	}
-	c.page = c.doc.NewPage(unit(w), unit(h))
 	vg.Initialize(c)
 	return c
 }
 
+// cur returns the top context on the stack.
+func (c *Canvas) cur() *ctx {
+	return &c.stk[len(c.stk)-1]
+}
+
 func (c *Canvas) Size() (w, h vg.Length) {
 	return c.w, c.h
 }
 
 func (c *Canvas) SetLineWidth(w vg.Length) {
-	c.page.SetLineWidth(unit(w))
-	c.lineVisible = w != 0
+	fmt.Fprintf(c.buf, "%s w\n", num(w.Points()))
+	c.cur().lineVisible = w != 0
 }
 
 func (c *Canvas) SetLineDash(dashes []vg.Length, offs vg.Length) {
-	ds := make([]pdf.Unit, len(dashes))
+	c.buf.WriteString("[")
 	for i, d := range dashes {
-		ds[i] = unit(d)
+		if i > 0 {
+			c.buf.WriteString(" ")
+		}
+		c.buf.WriteString(num(d.Points()))
 	}
-	c.page.SetLineDash(unit(offs), ds)
+	fmt.Fprintf(c.buf, "] %s d\n", num(offs.Points()))
 }
 
+// SetColor sets the color of strokes and fills.
+// The alpha of the color is set with an ExtGState
+// resource, so translucent strokes and fills are
+// composited with what is below them, as they are
+// by the raster back-ends.
 func (c *Canvas) SetColor(clr color.Color) {
-	c.page.SetStrokeColor(pdfColor(clr))
-	c.page.SetColor(pdfColor(clr))
+	r, g, b, a := pdfColor(clr)
+	rgb := num(r) + " " + num(g) + " " + num(b)
+	fmt.Fprintf(c.buf, "%s RG\n%s rg\n", rgb, rgb)
+	if a != c.cur().alpha {
+		fmt.Fprintf(c.buf, "/GS%d gs\n", c.alphaState(a))
+		c.cur().alpha = a
+	}
+}
+
+// alphaState returns the index of the ExtGState
+// resource that sets the stroke and fill alpha
+// to a, adding the resource if needed.
+func (c *Canvas) alphaState(a float64) int {
+	for i, b := range c.alphas {
+		if a == b {
+			return i
+		}
+	}
+	c.alphas = append(c.alphas, a)
+	return len(c.alphas) - 1
 }
 
 func (c *Canvas) Rotate(r float64) {
-	c.page.Rotate(float32(r))
+	s, co := num(math.Sin(r)), num(math.Cos(r))
+	fmt.Fprintf(c.buf, "%s %s %s %s 0 0 cm\n", co, s, num(-math.Sin(r)), co)
 }
 
 func (c *Canvas) Translate(x vg.Length, y vg.Length) {
-	c.page.Translate(unit(x), unit(y))
+	fmt.Fprintf(c.buf, "1 0 0 1 %s %s cm\n", num(x.Points()), num(y.Points()))
 }
 
 func (c *Canvas) Scale(x float64, y float64) {
-	c.page.Scale(float32(x), float32(y))
+	fmt.Fprintf(c.buf, "%s 0 0 %s 0 0 cm\n", num(x), num(y))
 }
 
 func (c *Canvas) Push() {
-	c.page.Push()
+	c.stk = append(c.stk, *c.cur())
+	c.buf.WriteString("q\n")
 }
 
 func (c *Canvas) Pop() {
-	c.page.Pop()
+	c.stk = c.stk[:len(c.stk)-1]
+	c.buf.WriteString("Q\n")
 }
 
 func (c *Canvas) Stroke(p vg.Path) {
-	if c.lineVisible {
-		c.page.Stroke(pdfPath(c, p))
+	if c.cur().lineVisible {
+		c.path(p)
+		c.buf.WriteString("S\n")
 	}
 }
 
 func (c *Canvas) Fill(p vg.Path) {
-	c.page.Fill(pdfPath(c, p))
+	c.path(p)
+	c.buf.WriteString("f\n")
+}
+
+// Clip implements the vg.Clipper interface.  The
+// clip is undone by the matching call to Pop.
+func (c *Canvas) Clip(p vg.Path) {
+	c.path(p)
+	c.buf.WriteString("W n\n")
 }
 
 func (c *Canvas) FillString(fnt vg.Font, x, y vg.Length, str string) {
-	t := new(pdf.Text)
-	t.SetFont(fnt.Name(), unit(fnt.Size))
-	t.NextLineOffset(unit(x), unit(y))
-	t.Text(str)
-	c.page.DrawText(t)
+	fmt.Fprintf(c.buf, "BT\n/F%d %s Tf\n%s %s Td\n%s Tj\nET\n",
+		c.font(fnt.Name()), num(fnt.Size.Points()),
+		num(x.Points()), num(y.Points()), pdfString(str))
+}
+
+// font returns the index of the font resource
+// for the named font, adding the resource if
+// needed.
+func (c *Canvas) font(name string) int {
+	for i, n := range c.fonts {
+		if n == name {
+			return i
+		}
+	}
+	c.fonts = append(c.fonts, name)
+	return len(c.fonts) - 1
 }
 
 func (*Canvas) DPI() float64 {
-	return float64(pdf.Inch)
+	return 72
 }
 
-// pdfPath returns a pdf.Path from a vg.Path.
-func pdfPath(c *Canvas, path vg.Path) *pdf.Path {
-	p := new(pdf.Path)
-	for _, comp := range path {
+// path writes the operators that construct
+// a vg.Path to the content stream.
+func (c *Canvas) path(path vg.Path) {
+	for i, comp := range path {
 		switch comp.Type {
 		case vg.MoveComp:
-			p.Move(pdfPoint(comp.X, comp.Y))
+			fmt.Fprintf(c.buf, "%s m\n", point(comp.X, comp.Y))
 		case vg.LineComp:
-			p.Line(pdfPoint(comp.X, comp.Y))
+			fmt.Fprintf(c.buf, "%s l\n", point(comp.X, comp.Y))
 		case vg.ArcComp:
-			arc(p, comp)
+			c.arc(comp, i == 0)
 		case vg.CloseComp:
-			p.Close()
+			c.buf.WriteString("h\n")
 		default:
 			panic(fmt.Sprintf("Unknown path component type: %d\n", comp.Type))
 		}
 	}
-	return p
 }
 
 // Approximate a circular arc using multiple
 // cubic Bézier curves, one for each π/2 segment.
+// The arc is joined to the current point with a
+// line, or starts the path if first is true.
 //
 // This is from:
 // 	http://hansmuller-flex.blogspot.com/2011/04/approximating-circular-arc-with-cubic.html
-func arc(p *pdf.Path, comp vg.PathComp) {
+func (c *Canvas) arc(comp vg.PathComp, first bool) {
 	x0 := comp.X + comp.Radius*vg.Length(math.Cos(comp.Start))
 	y0 := comp.Y + comp.Radius*vg.Length(math.Sin(comp.Start))
-	p.Line(pdfPoint(x0, y0))
+	op := "l"
+	if first {
+		op = "m"
+	}
+	fmt.Fprintf(c.buf, "%s %s\n", point(x0, y0), op)
 
 	a1 := comp.Start
 	end := a1 + comp.Angle
@@ -148,7 +228,7 @@ func arc(p *pdf.Path, comp vg.PathComp) {
 
 	for left > epsilon {
 		a2 := a1 + sign*math.Min(math.Pi/2, left)
-		partialArc(p, comp.X, comp.Y, comp.Radius, a1, a2)
+		c.partialArc(comp.X, comp.Y, comp.Radius, a1, a2)
 		left -= math.Abs(a2 - a1)
 		a1 = a2
 	}
@@ -156,7 +236,7 @@ func arc(p *pdf.Path, comp vg.PathComp) {
 
 // Approximate a circular arc of fewer than π/2
 // radians with cubic Bézier curve.
-func partialArc(p *pdf.Path, x, y, r vg.Length, a1, a2 float64) {
+func (c *Canvas) partialArc(x, y, r vg.Length, a1, a2 float64) {
 	a := (a2 - a1) / 2
 	x4 := r * vg.Length(math.Cos(a))
 	y4 := r * vg.Length(math.Sin(a))
@@ -180,51 +260,136 @@ func partialArc(p *pdf.Path, x, y, r vg.Length, a1, a2 float64) {
 	y3r := x3*sinar + y3*cosar + y
 	x4 = r*vg.Length(math.Cos(a2)) + x
 	y4 = r*vg.Length(math.Sin(a2)) + y
-	p.Curve(pdfPoint(x2r, y2r), pdfPoint(x3r, y3r), pdfPoint(x4, y4))
+	fmt.Fprintf(c.buf, "%s %s %s c\n", point(x2r, y2r), point(x3r, y3r), point(x4, y4))
 }
 
-func pdfPoint(x, y vg.Length) pdf.Point {
-	return pdf.Point{X: unit(x), Y: unit(y)}
+// point returns the operands of a point
+// in the content stream.
+func point(x, y vg.Length) string {
+	return num(x.Points()) + " " + num(y.Points())
 }
 
-func pdfColor(clr color.Color) (float32, float32, float32) {
-	if clr == nil {
-		clr = color.Black
+// num returns a PDF number, which may not
+// be written with an exponent.
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 4, 64)
+	s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
 	}
-	r, g, b, _ := clr.RGBA()
-	return float32(r) / math.MaxUint16,
-		float32(g) / math.MaxUint16,
-		float32(b) / math.MaxUint16
+	return s
 }
 
-// unit returns a pdf.Unit, converted from a vg.Length.
-func unit(l vg.Length) pdf.Unit {
-	return pdf.Unit(l.Points()) * pdf.Pt
+// pdfColor returns the unpremultiplied red, green
+// and blue components of a color and its alpha.
+// A fully transparent color is black.
+func pdfColor(clr color.Color) (r, g, b, a float64) {
+	if clr == nil {
+		clr = color.Black
+	}
+	cr, cg, cb, ca := clr.RGBA()
+	if ca == 0 {
+		return 0, 0, 0, 0
+	}
+	return float64(cr) / float64(ca),
+		float64(cg) / float64(ca),
+		float64(cb) / float64(ca),
+		float64(ca) / 0xffff
 }
 
-// WriterCounter implements the io.Writer interface, and counts
-// the total number of bytes written.
-type writerCounter struct {
-	io.Writer
-	n int64
+// winAnsi maps the runes of the WinAnsiEncoding
+// that are not in Latin-1 to their codes.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85,
+	'†': 0x86, '‡': 0x87, 'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a,
+	'‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c,
+	'ž': 0x9e, 'Ÿ': 0x9f,
 }
 
-func (w *writerCounter) Write(p []byte) (int, error) {
-	n, err := w.Writer.Write(p)
-	w.n += int64(n)
-	return n, err
+// pdfString returns str as a PDF literal string
+// in the WinAnsiEncoding of the page's fonts.
+// Runes that the encoding lacks are written as '?'.
+func pdfString(str string) string {
+	b := []byte{'('}
+	for _, r := range str {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b = append(b, '\\', byte(r))
+		case r < 0x80 || r >= 0xa0 && r <= 0xff:
+			b = append(b, byte(r))
+		case winAnsi[r] != 0:
+			b = append(b, winAnsi[r])
+		default:
+			b = append(b, '?')
+		}
+	}
+	return string(append(b, ')'))
 }
 
 // WriteTo writes the Canvas to an io.Writer.
-// After calling Write, the canvas is closed
-// and may no longer be used for drawing.
 func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
-	c.page.Close()
-	wc := writerCounter{Writer: w}
-	b := bufio.NewWriter(&wc)
-	if err := c.doc.Encode(b); err != nil {
-		return wc.n, err
-	}
-	err := b.Flush()
-	return wc.n, err
+	doc, err := c.document()
+	if err != nil {
+		return 0, err
+	}
+	return doc.WriteTo(w)
+}
+
+// document returns the PDF document of the
+// Canvas.  The page is object 3, its content
+// stream is object 4 and the resources of the
+// page follow it: the fonts, then the ExtGStates.
+func (c *Canvas) document() (*bytes.Buffer, error) {
+	var content bytes.Buffer
+	z := zlib.NewWriter(&content)
+	if _, err := z.Write(c.buf.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := z.Close(); err != nil {
+		return nil, err
+	}
+
+	doc := new(bytes.Buffer)
+	var offs []int
+	obj := func(format string, args ...interface{}) {
+		offs = append(offs, doc.Len())
+		fmt.Fprintf(doc, "%d 0 obj\n", len(offs))
+		fmt.Fprintf(doc, format, args...)
+		doc.WriteString("\nendobj\n")
+	}
+
+	res := new(bytes.Buffer)
+	res.WriteString("/Font <<")
+	for i := range c.fonts {
+		fmt.Fprintf(res, " /F%d %d 0 R", i, 5+i)
+	}
+	res.WriteString(" >> /ExtGState <<")
+	for i := range c.alphas {
+		fmt.Fprintf(res, " /GS%d %d 0 R", i, 5+len(c.fonts)+i)
+	}
+	res.WriteString(" >>")
+
+	doc.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	obj("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << %s >> /Contents 4 0 R >>",
+		num(c.w.Points()), num(c.h.Points()), res)
+	obj("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream",
+		content.Len(), content.Bytes())
+	for _, name := range c.fonts {
+		obj("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name)
+	}
+	for _, a := range c.alphas {
+		obj("<< /Type /ExtGState /CA %s /ca %s >>", num(a), num(a))
+	}
+
+	xref := doc.Len()
+	fmt.Fprintf(doc, "xref\n0 %d\n0000000000 65535 f \n", len(offs)+1)
+	for _, off := range offs {
+		fmt.Fprintf(doc, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(doc, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offs)+1, xref)
+	return doc, nil
 }
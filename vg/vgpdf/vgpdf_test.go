@@ -0,0 +1,142 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgpdf
+
+import (
+	"bytes"
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot/vg"
+)
+
+func TestTranslucentFill(t *testing.T) {
+	c := New(vg.Inches(1), vg.Inches(1))
+	var p vg.Path
+	p.Move(0, 0)
+	p.Line(10, 0)
+	p.Line(10, 10)
+	p.Close()
+
+	red := color.NRGBA{R: 255, A: 128}
+	c.Push()
+	c.SetColor(red)
+	c.Fill(p)
+	c.Pop()
+	c.SetColor(red)
+	c.Fill(p)
+	c.SetColor(color.Black)
+	c.Fill(p)
+
+	// Pop restores the opaque state, so the
+	// second fill must set the alpha again.
+	content := c.buf.String()
+	if n := strings.Count(content, "/GS0 gs"); n != 2 {
+		t.Errorf("content sets the translucent state %d times, want 2:\n%s", n, content)
+	}
+	if !strings.Contains(content, "1 0 0 rg") {
+		t.Errorf("content does not fill with unpremultiplied red:\n%s", content)
+	}
+	if !strings.Contains(content, "/GS1 gs") {
+		t.Errorf("content does not reset the alpha for black:\n%s", content)
+	}
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	doc := buf.String()
+	for _, want := range []string{
+		"/ExtGState << /GS0 5 0 R /GS1 6 0 R >>",
+		"<< /Type /ExtGState /CA 0.502 /ca 0.502 >>",
+		"<< /Type /ExtGState /CA 1 /ca 1 >>",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("PDF does not contain %s", want)
+		}
+	}
+}
+
+func TestClip(t *testing.T) {
+	c := New(vg.Inches(1), vg.Inches(1))
+	var p vg.Path
+	p.Arc(10, 10, 5, 0, 2*math.Pi)
+	c.Push()
+	c.Clip(p)
+	c.Pop()
+
+	content := c.buf.String()
+	want := "q\n15 10 m\n"
+	if !strings.Contains(content, want) {
+		t.Errorf("clip path does not start with a move:\n%s", content)
+	}
+	if !strings.HasSuffix(content, "c\nW n\nQ\n") {
+		t.Errorf("content does not end the clip path with W n:\n%s", content)
+	}
+}
+
+func TestFillString(t *testing.T) {
+	roman, err := vg.MakeFont("Times-Roman", 12)
+	if err != nil {
+		t.Skipf("font not available: %v", err)
+	}
+	bold, err := vg.MakeFont("Times-Bold", 10)
+	if err != nil {
+		t.Skipf("font not available: %v", err)
+	}
+
+	c := New(vg.Inches(1), vg.Inches(1))
+	c.FillString(roman, 1, 2, "a")
+	c.SetColor(color.NRGBA{A: 128})
+	c.FillString(bold, 3, 4, "b")
+	c.FillString(roman, 5, 6, "c")
+
+	// Each font is a resource of the page, and
+	// the ExtGStates are numbered after them.
+	content := c.buf.String()
+	for _, want := range []string{
+		"BT\n/F0 12 Tf\n1 2 Td\n(a) Tj\nET\n",
+		"BT\n/F1 10 Tf\n3 4 Td\n(b) Tj\nET\n",
+		"BT\n/F0 12 Tf\n5 6 Td\n(c) Tj\nET\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content does not contain %q:\n%s", want, content)
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	doc := buf.String()
+	for _, want := range []string{
+		"/Font << /F0 5 0 R /F1 6 0 R >> /ExtGState << /GS0 7 0 R >>",
+		"5 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Times-Roman /Encoding /WinAnsiEncoding >>",
+		"6 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Times-Bold /Encoding /WinAnsiEncoding >>",
+		"7 0 obj\n<< /Type /ExtGState /CA 0.502 /ca 0.502 >>",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("PDF does not contain %s", want)
+		}
+	}
+}
+
+func TestPDFString(t *testing.T) {
+	for _, test := range []struct {
+		str, want string
+	}{
+		{"f(x)", `(f\(x\))`},
+		{`a\b`, `(a\\b)`},
+		{"x²", "(x\xb2)"},
+		{"5–10 €", "(5\x9610 \x80)"},
+		{"α", "(?)"},
+	} {
+		if got := pdfString(test.str); got != test.want {
+			t.Errorf("pdfString(%q) = %q, want %q", test.str, got, test.want)
+		}
+	}
+}
@@ -9,10 +9,14 @@ package vgsvg
 import (
 	"bufio"
 	"bytes"
+	"encoding/xml"
 	"fmt"
 	"image/color"
 	"io"
 	"math"
+	"sort"
+	"strings"
+	"unicode"
 
 	svgo "github.com/ajstarks/svgo"
 	"github.com/gonum/plot/vg"
@@ -34,6 +38,27 @@ type Canvas struct {
 	buf  *bytes.Buffer
 	ht   float64
 	stk  []context
+
+	// clips is the number of clip paths
+	// defined, used to make their ids.
+	clips int
+
+	// groups records, for each annotation that
+	// has begun and not ended, whether it wrote
+	// a group element.
+	groups []bool
+
+	// Classes, if true, gives the groups started by
+	// BeginAnnotation the class attributes of their
+	// annotations, such as the classes of the parts of
+	// a plot, and writes the styles of the elements as
+	// presentation attributes, such as stroke="#ff0000",
+	// instead of style attributes.  Unlike style
+	// attributes, presentation attributes are overridden
+	// by the rules of a stylesheet, so the drawing can be
+	// themed by CSS, for example when it is embedded in
+	// a web page.
+	Classes bool
 }
 
 type context struct {
@@ -56,10 +81,10 @@ func New(w, h vg.Length) *Canvas {
 	}
 
 	// This is like svg.Start, except it uses floats
-	// and specifies the units.
-	fmt.Fprintf(buf, `<?xml version="1.0"?>
-<!-- Generated by SVGo and Plotinum VG -->
-<svg width="%.*gin" height="%.*gin"
+	// and specifies the units.  The XML prolog is
+	// written by WriteTo so that the SVG element
+	// can also be written inline by WriteInline.
+	fmt.Fprintf(buf, `<svg width="%.*gin" height="%.*gin"
 	xmlns="http://www.w3.org/2000/svg" 
 	xmlns:xlink="http://www.w3.org/1999/xlink">`+"\n",
 		pr, w.Inches(), pr, h.Inches())
@@ -125,7 +150,7 @@ func (c *Canvas) Pop() {
 
 func (c *Canvas) Stroke(path vg.Path) {
 	c.svg.Path(c.pathData(path),
-		style(elm("fill", "#000000", "none"),
+		c.style(elm("fill", "#000000", "none"),
 			elm("stroke", "none", colorString(c.cur().color)),
 			elm("stroke-opacity", "1", opacityString(c.cur().color)),
 			elm("stroke-width", "1", "%.*g", pr, c.cur().lineWidth.Dots(c)),
@@ -135,7 +160,7 @@ func (c *Canvas) Stroke(path vg.Path) {
 
 func (c *Canvas) Fill(path vg.Path) {
 	c.svg.Path(c.pathData(path),
-		style(elm("fill", "#000000", colorString(c.cur().color)),
+		c.style(elm("fill", "#000000", colorString(c.cur().color)),
 			elm("fill-opacity", "1", opacityString(c.cur().color))))
 }
 
@@ -244,7 +269,7 @@ func (c *Canvas) FillString(font vg.Font, x, y vg.Length, str string) {
 	if !ok {
 		panic(fmt.Sprintf("Unknown font: %s", font.Name()))
 	}
-	sty := style(fontStr,
+	sty := c.style(fontStr,
 		elm("font-size", "medium", "%.*gpt", pr, font.Size.Points()),
 		elm("fill", "#000000", colorString(c.cur().color)))
 	if sty != "" {
@@ -277,10 +302,137 @@ func (c *Canvas) DPI() float64 {
 	return dpi
 }
 
+// BeginTitle implements the vg.Titler interface,
+// starting a group of elements that is given the title.
+// Most viewers show the title as a tooltip.
+func (c *Canvas) BeginTitle(title string) {
+	c.BeginAnnotation(vg.Annotation{Title: title})
+}
+
+// EndTitle implements the vg.Titler interface, ending
+// the group started by the matching call to BeginTitle.
+func (c *Canvas) EndTitle() {
+	c.EndAnnotation()
+}
+
+// BeginAnnotation implements the vg.Annotator interface,
+// starting a group of elements that has a data- attribute
+// for each of the annotation's data, in order of their
+// names, and the annotation's title, if any.  Data whose
+// names are not valid in an attribute name, as reported
+// by validDataName, are left out.  If the canvas writes
+// Classes then the group also has the annotation's class.
+// An annotation that has nothing to write, such as one
+// with only a class when the canvas does not write
+// Classes, writes no group.
+func (c *Canvas) BeginAnnotation(a vg.Annotation) {
+	names := make([]string, 0, len(a.Data))
+	for name := range a.Data {
+		if validDataName(name) {
+			names = append(names, name)
+		}
+	}
+	class := c.Classes && a.Class != ""
+	if len(names) == 0 && a.Title == "" && !class {
+		c.groups = append(c.groups, false)
+		return
+	}
+	c.groups = append(c.groups, true)
+	sort.Strings(names)
+	c.buf.WriteString("<g")
+	if class {
+		c.buf.WriteString(" class=\"")
+		xml.EscapeText(c.buf, []byte(a.Class))
+		c.buf.WriteString("\"")
+	}
+	for _, name := range names {
+		fmt.Fprintf(c.buf, " data-%s=\"", name)
+		xml.EscapeText(c.buf, []byte(a.Data[name]))
+		c.buf.WriteString("\"")
+	}
+	c.buf.WriteString(">")
+	if a.Title != "" {
+		c.buf.WriteString("<title>")
+		xml.EscapeText(c.buf, []byte(a.Title))
+		c.buf.WriteString("</title>")
+	}
+	c.buf.WriteString("\n")
+}
+
+// validDataName returns true if name can follow
+// "data-" in the name of an attribute: if it is
+// not empty and is made of letters, digits, and
+// the punctuation '-', '_' and '.' that XML allows
+// in names.  Names with other characters, such as
+// quotes, spaces or '>', would break out of the
+// attribute, so they are not written.
+func validDataName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+		case r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// EndAnnotation implements the vg.Annotator interface,
+// ending the group started by the matching call to
+// BeginAnnotation.
+func (c *Canvas) EndAnnotation() {
+	n := len(c.groups) - 1
+	if n < 0 {
+		return
+	}
+	wrote := c.groups[n]
+	c.groups = c.groups[:n]
+	if wrote {
+		c.svg.Gend()
+	}
+}
+
+// Clip implements the vg.Clipper interface, starting
+// a group of elements that is clipped to the path.
+// The group is ended by the matching call to Pop.
+func (c *Canvas) Clip(path vg.Path) {
+	c.clips++
+	fmt.Fprintf(c.buf, "<clipPath id=\"clip%d\"><path d=\"%s\"/></clipPath>\n", c.clips, c.pathData(path))
+	fmt.Fprintf(c.buf, "<g clip-path=\"url(#clip%d)\">\n", c.clips)
+	c.cur().gEnds++
+}
+
+// prolog is written before the SVG element by WriteTo.
+const prolog = `<?xml version="1.0"?>
+<!-- Generated by SVGo and Plotinum VG -->
+`
+
 // WriteTo writes the canvas to an io.Writer.
 func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
 	b := bufio.NewWriter(w)
-	n, err := c.buf.WriteTo(b)
+	n, err := io.WriteString(b, prolog)
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := c.writeSVG(b)
+	return int64(n) + m, err
+}
+
+// WriteInline writes the canvas to an io.Writer as an
+// SVG element without the XML prolog, suitable for
+// embedding directly in an HTML document.
+func (c *Canvas) WriteInline(w io.Writer) (int64, error) {
+	return c.writeSVG(bufio.NewWriter(w))
+}
+
+// writeSVG writes the SVG element to a buffered
+// writer and flushes it.
+func (c *Canvas) writeSVG(b *bufio.Writer) (int64, error) {
+	n, err := bytes.NewReader(c.buf.Bytes()).WriteTo(b)
 	if err != nil {
 		return n, err
 	}
@@ -318,8 +470,10 @@ func (c *Canvas) nEnds() int {
 // style returns a style string composed of
 // all of the given elements.  If the elements
 // are all empty then the empty string is
-// returned.
-func style(elms ...string) string {
+// returned.  If the canvas writes Classes then
+// the elements are written as presentation
+// attributes.
+func (c *Canvas) style(elms ...string) string {
 	str := ""
 	for _, e := range elms {
 		if e == "" {
@@ -333,9 +487,23 @@ func style(elms ...string) string {
 	if str == "" {
 		return ""
 	}
+	if c.Classes {
+		return attributes(str)
+	}
 	return "style=\"" + str + "\""
 }
 
+// attributes returns the presentation attributes
+// of the elements of a style string.
+func attributes(sty string) string {
+	var attrs []string
+	for _, e := range strings.Split(sty, ";") {
+		kv := strings.SplitN(e, ":", 2)
+		attrs = append(attrs, kv[0]+"=\""+kv[1]+"\"")
+	}
+	return strings.Join(attrs, " ")
+}
+
 // elm returns a style element string with the
 // given key and value.  If the value matches
 // default then the empty string is returned.
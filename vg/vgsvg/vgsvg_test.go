@@ -0,0 +1,68 @@
+// Copyright ©2015 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgsvg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot/vg"
+)
+
+func TestAnnotationDataNames(t *testing.T) {
+	c := New(vg.Inches(1), vg.Inches(1))
+	c.BeginAnnotation(vg.Annotation{Data: map[string]string{
+		"series":              "a",
+		"x.value":             "1",
+		`x" onload="alert(1)`: "2",
+		"":                    "3",
+		"a>b":                 "4",
+	}})
+	c.EndAnnotation()
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+	for _, want := range []string{`data-series="a"`, `data-x.value="1"`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG does not contain %s", want)
+		}
+	}
+	for _, bad := range []string{"onload", `data-=`, "a>b"} {
+		if strings.Contains(svg, bad) {
+			t.Errorf("SVG contains the invalid data name %q", bad)
+		}
+	}
+}
+
+func TestAnnotationWithoutClasses(t *testing.T) {
+	for _, classes := range []bool{false, true} {
+		c := New(vg.Inches(1), vg.Inches(1))
+		c.Classes = classes
+		c.BeginAnnotation(vg.Annotation{Class: "axis"})
+		c.BeginTitle("tip")
+		c.EndTitle()
+		c.EndAnnotation()
+
+		var buf bytes.Buffer
+		if _, err := c.WriteTo(&buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		svg := buf.String()
+		groups, ends := strings.Count(svg, "<g"), strings.Count(svg, "</g>")
+		if groups != ends {
+			t.Errorf("classes %t: %d groups begun and %d ended", classes, groups, ends)
+		}
+		if got := strings.Contains(svg, `class="axis"`); got != classes {
+			t.Errorf("classes %t: SVG has the class: %t", classes, got)
+		}
+		if !strings.Contains(svg, "<title>tip</title>") {
+			t.Errorf("classes %t: SVG does not have the title", classes)
+		}
+	}
+}